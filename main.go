@@ -1,11 +1,29 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/terraform-providers/terraform-provider-postgresql/postgresql"
 )
 
+// version is set at build time by goreleaser.
+var version = "dev"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: postgresql.Provider})
+	postgresql.Version = version
+
+	serverFactory, err := postgresql.ProtoV6ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf6server.Serve("registry.terraform.io/cyrilgdn/postgresql", serverFactory)
+
+	// Serve returns when Terraform stops the plugin.
+	postgresql.Shutdown()
+	if err != nil {
+		log.Fatal(err)
+	}
 }
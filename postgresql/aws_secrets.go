@@ -0,0 +1,81 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// getSecretsManagerPassword returns the password stored in an AWS Secrets Manager secret.
+// The secret is looked up in the region of its ARN, or the default region if it's a name.
+func getSecretsManagerPassword(secretID string) (string, error) {
+	ctx := context.Background()
+
+	var options []func(*awsConfig.LoadOptions) error
+	if secretARN, err := arn.Parse(secretID); err == nil {
+		options = append(options, awsConfig.WithRegion(secretARN.Region))
+	}
+	awscfg, err := awsConfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := secretsmanager.NewFromConfig(awscfg).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not read password secret %s: %w", secretID, err)
+	}
+	if output.SecretString == nil {
+		return "", fmt.Errorf("password secret %s is not a string", secretID)
+	}
+
+	return parseSecretPassword(*output.SecretString)
+}
+
+// parseSecretPassword returns the password of a secret, which is either the password itself
+// or a JSON object with a password key (e.g.: the secrets managed by RDS).
+func parseSecretPassword(secret string) (string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(secret), "{") {
+		return secret, nil
+	}
+
+	var value struct {
+		Password *string `json:"password"`
+	}
+	if err := json.Unmarshal([]byte(secret), &value); err != nil {
+		return "", fmt.Errorf("could not parse JSON password secret: %w", err)
+	}
+	if value.Password == nil {
+		return "", fmt.Errorf("no password key in JSON password secret")
+	}
+	return *value.Password, nil
+}
+
+// getSSMParameterPassword returns the password stored in an AWS SSM parameter,
+// decrypted if it's a SecureString.
+func getSSMParameterPassword(name string) (string, error) {
+	ctx := context.Background()
+
+	awscfg, err := awsConfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := ssm.NewFromConfig(awscfg).GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not read password parameter %s: %w", name, err)
+	}
+
+	return aws.ToString(output.Parameter.Value), nil
+}
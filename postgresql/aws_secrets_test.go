@@ -0,0 +1,54 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseSecretPassword(t *testing.T) {
+	var tests = []struct {
+		secret  string
+		want    string
+		wantErr bool
+	}{
+		{"s3cr3t", "s3cr3t", false},
+		{`{"username": "postgres", "password": "s3cr3t"}`, "s3cr3t", false},
+		{`{"username": "postgres"}`, "", true},
+		{`{"password": `, "", true},
+	}
+
+	for _, test := range tests {
+		password, err := parseSecretPassword(test.secret)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseSecretPassword(%q) returned error %v, want error: %t", test.secret, err, test.wantErr)
+			continue
+		}
+		if password != test.want {
+			t.Errorf("parseSecretPassword(%q) returned %q, want %q", test.secret, password, test.want)
+		}
+	}
+}
+
+func TestProviderConfigurePasswordSecret(t *testing.T) {
+	for attribute, secret := range map[string]string{
+		"password_secret_arn":    "arn:aws:secretsmanager:us-east-1:123456789012:secret:postgres-password",
+		"password_ssm_parameter": "/postgres/password",
+	} {
+		t.Run(attribute, func(t *testing.T) {
+			provider := Provider()
+			// The secret is read when connecting, not while configuring the provider.
+			diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				attribute: secret,
+			}))
+			if diags.HasError() {
+				t.Fatalf("Configure returned an error: %v", diags)
+			}
+
+			if provider.Meta().(*Client).config.authTokenGenerator() == nil {
+				t.Errorf("no password generator for %s", attribute)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+// CockroachDB implementation of the core resources (compatibility_mode = "cockroachdb").
+// CockroachDB roles have no SUPERUSER (the admin role), INHERIT or CONNECTION LIMIT
+// attributes, and its catalogs don't have the ACLs, the privileges are read from
+// information_schema and SHOW GRANTS.
+
+// cockroachDBRoleCustomizeDiff fails the plan if the role uses attributes CockroachDB doesn't support.
+func cockroachDBRoleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*Client)
+	if !ok || client.config.CompatibilityMode != compatibilityCockroachDB {
+		return nil
+	}
+
+	switch {
+	case d.Get(roleSuperuserAttr).(bool):
+		return fmt.Errorf("%s is not supported with compatibility_mode cockroachdb, grant the admin role instead", roleSuperuserAttr)
+	case !d.Get(roleInheritAttr).(bool):
+		return fmt.Errorf("%s = false is not supported with compatibility_mode cockroachdb, roles always inherit the privileges of their roles", roleInheritAttr)
+	case d.Get(roleConnLimitAttr).(int) != -1:
+		return fmt.Errorf("%s is not supported with compatibility_mode cockroachdb", roleConnLimitAttr)
+	case d.Get(roleReplicationAttr).(bool), d.Get(roleBypassRLSAttr).(bool):
+		return fmt.Errorf("%s and %s are not supported with compatibility_mode cockroachdb", roleReplicationAttr, roleBypassRLSAttr)
+	}
+	return nil
+}
+
+// readCockroachDBRolePrivileges is readRolePrivileges for CockroachDB.
+func readCockroachDBRolePrivileges(txn *sql.Tx, d *schema.ResourceData) error {
+	role := d.Get("role").(string)
+
+	var objectsQuery string
+	switch d.Get("object_type").(string) {
+	case "database":
+		privileges, err := queryPrivilegesByObject(txn,
+			fmt.Sprintf("SELECT '', privilege_type FROM [SHOW GRANTS ON DATABASE %s] WHERE grantee = $1", pq.QuoteIdentifier(d.Get("database").(string))),
+			role,
+		)
+		if err != nil {
+			return err
+		}
+		d.Set("privileges", stringSliceToSet(privileges[""]))
+		return nil
+
+	case "schema":
+		privileges, err := queryPrivilegesByObject(txn,
+			"SELECT '', privilege_type FROM information_schema.schema_privileges WHERE table_schema = $1 AND grantee = $2",
+			d.Get("schema"), role,
+		)
+		if err != nil {
+			return err
+		}
+		d.Set("privileges", stringSliceToSet(privileges[""]))
+		return nil
+
+	case "sequence":
+		objectsQuery = "SELECT sequence_name FROM information_schema.sequences WHERE sequence_schema = $1"
+
+	default:
+		objectsQuery = "SELECT table_name FROM information_schema.tables WHERE table_schema = $1 AND table_type = 'BASE TABLE'"
+	}
+
+	// The privileges on the sequences are in table_privileges too.
+	privileges, err := queryPrivilegesByObject(txn,
+		"SELECT table_name, privilege_type FROM information_schema.table_privileges WHERE table_schema = $1 AND grantee = $2",
+		d.Get("schema"), role,
+	)
+	if err != nil {
+		return err
+	}
+	objectNames, err := queryObjectNames(txn, objectsQuery, d.Get("schema"))
+	if err != nil {
+		return err
+	}
+
+	setObjectsPrivileges(d, objectNames, privileges)
+	return nil
+}
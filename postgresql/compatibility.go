@@ -0,0 +1,190 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Compatibility modes, for the databases speaking the PostgreSQL protocol
+// whose catalogs or SQL dialect differ from PostgreSQL.
+const (
+	compatibilityRedshift    = "redshift"
+	compatibilityCockroachDB = "cockroachdb"
+	compatibilityGreenplum   = "greenplum"
+)
+
+var compatibilityModes = []string{compatibilityRedshift, compatibilityCockroachDB, compatibilityGreenplum}
+
+// compatibilityFeatures overrides, for each compatibility mode, the features
+// determined from the server version (e.g.: Redshift reports PostgreSQL 8.0.2).
+var compatibilityFeatures = map[string]map[featureName]bool{
+	compatibilityRedshift: {
+		featurePrivileges:             true,
+		featureSchemaCreateIfNotExist: true,
+	},
+	// CockroachDB reports PostgreSQL 13.0.0.
+	compatibilityCockroachDB: {
+		featureDBAllowConnections: false,
+		featureDBIsTemplate:       false,
+		featureForceDropDatabase:  false,
+		featureReplication:        false,
+		featureRLS:                false,
+		featureSCRAMPassword:      false,
+		featurePublication:        false,
+		featureSubscription:       false,
+		featureAlterSystem:        false,
+		featureTransform:          false,
+	},
+	// Greenplum reports the PostgreSQL version it's based on (e.g.: 9.4 for Greenplum 6).
+	compatibilityGreenplum: {
+		featureAlterSystem:  false,
+		featurePublication:  false,
+		featureSubscription: false,
+	},
+}
+
+// compatibilityGrantObjectTypes are the object types of postgresql_grant supported
+// by each compatibility mode.
+var compatibilityGrantObjectTypes = map[string][]string{
+	compatibilityRedshift:    {"database", "schema", "table", "function"},
+	compatibilityCockroachDB: {"database", "schema", "table", "sequence"},
+}
+
+// compatibilityFeature returns whether the compatibility mode supports the feature,
+// and false as second value if it doesn't override it.
+func compatibilityFeature(mode string, name featureName) (bool, bool) {
+	supported, ok := compatibilityFeatures[mode][name]
+	return supported, ok
+}
+
+// detectCompatibilityMode returns the compatibility mode of the server
+// from its version string, empty for PostgreSQL.
+func detectCompatibilityMode(db QueryAble) (string, error) {
+	var version string
+	if err := db.QueryRow(`SELECT VERSION()`).Scan(&version); err != nil {
+		return "", fmt.Errorf("could not detect the server type: %w", err)
+	}
+
+	switch {
+	// CockroachDB CCL v23.1.11 (x86_64-pc-linux-gnu, built 2023/09/27 01:53:43, go1.19.10)
+	case strings.HasPrefix(version, "CockroachDB"):
+		return compatibilityCockroachDB, nil
+	// PostgreSQL 8.0.2 on i686-pc-linux-gnu, compiled by GCC gcc (GCC) 3.4.2 20041017 (Red Hat 3.4.2-6.fc3), Redshift 1.0.54899
+	case strings.Contains(version, "Redshift"):
+		return compatibilityRedshift, nil
+	// PostgreSQL 9.4.26 (Greenplum Database 6.20.0 build commit:...) on x86_64-unknown-linux-gnu, ...
+	case strings.Contains(version, "Greenplum Database"):
+		return compatibilityGreenplum, nil
+	}
+	return "", nil
+}
+
+// isRedshift returns whether the provider is connected to Redshift.
+func (db *DBConnection) isRedshift() bool {
+	return db.compatibilityMode == compatibilityRedshift
+}
+
+// isCockroachDB returns whether the provider is connected to CockroachDB.
+func (db *DBConnection) isCockroachDB() bool {
+	return db.compatibilityMode == compatibilityCockroachDB
+}
+
+// isGreenplum returns whether the provider is connected to Greenplum.
+func (db *DBConnection) isGreenplum() bool {
+	return db.compatibilityMode == compatibilityGreenplum
+}
+
+// withRolesGranted calls fn with the roles temporarily granted to the connected user,
+// so it can alter the objects they own.
+// On Redshift, users can't be members of other users (only of groups), so the membership
+// is not emulated: fn is called directly and the connected user must own the objects
+// or be a superuser. CockroachDB and Greenplum support role membership like PostgreSQL,
+// the roles are granted as usual.
+func (db *DBConnection) withRolesGranted(txn *sql.Tx, roles []string, fn func() error) error {
+	if db.isRedshift() {
+		return fn()
+	}
+	return withRolesGranted(txn, roles, fn)
+}
+
+// lockRole is pgLockRole for the compatibility modes:
+// Redshift and CockroachDB don't support advisory locks.
+func (db *DBConnection) lockRole(txn *sql.Tx, role string) error {
+	if db.isRedshift() || db.isCockroachDB() {
+		return nil
+	}
+	return pgLockRole(txn, role)
+}
+
+// errNotSupportedInCompatibilityMode returns the error of a feature not supported
+// by the compatibility mode of the provider.
+func (db *DBConnection) errNotSupportedInCompatibilityMode(feature string) error {
+	return fmt.Errorf("%s is not supported with compatibility_mode %s", feature, db.compatibilityMode)
+}
+
+// queryPrivilegesByObject runs query, which returns (object name, privilege) rows,
+// and returns the privileges by object. It's used by the compatibility modes
+// which have privileges views instead of aclexplode.
+func queryPrivilegesByObject(txn *sql.Tx, query string, args ...interface{}) (map[string][]string, error) {
+	rows, err := txn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not read privileges: %w", err)
+	}
+	defer rows.Close()
+
+	privileges := map[string][]string{}
+	for rows.Next() {
+		var objName, privilege string
+		if err := rows.Scan(&objName, &privilege); err != nil {
+			return nil, fmt.Errorf("could not scan privileges: %w", err)
+		}
+		privileges[objName] = append(privileges[objName], privilege)
+	}
+	return privileges, rows.Err()
+}
+
+// queryObjectNames runs query, which returns the names of the objects
+// of a schema whose privileges are managed by postgresql_grant.
+func queryObjectNames(txn *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := txn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var objectNames []string
+	for rows.Next() {
+		var objName string
+		if err := rows.Scan(&objName); err != nil {
+			return nil, err
+		}
+		objectNames = append(objectNames, objName)
+	}
+	return objectNames, rows.Err()
+}
+
+// setObjectsPrivileges sets the privileges of the grant as readRolePrivileges does:
+// every object must have the same privileges as saved in the state, otherwise
+// the privileges of the first one which doesn't are set to force an update.
+func setObjectsPrivileges(d *schema.ResourceData, objectNames []string, privileges map[string][]string) {
+	objects := d.Get("objects").(*schema.Set)
+	for _, objName := range objectNames {
+		if objects.Len() > 0 && !objects.Contains(objName) {
+			continue
+		}
+
+		privilegesSet := stringSliceToSet(privileges[objName])
+		if !privilegesSet.Equal(d.Get("privileges").(*schema.Set)) {
+			log.Printf(
+				"[DEBUG] %s %s has not the expected privileges %v for role %s",
+				strings.ToTitle(d.Get("object_type").(string)), objName, privileges[objName], d.Get("role"),
+			)
+			d.Set("privileges", privilegesSet)
+			break
+		}
+	}
+}
@@ -2,16 +2,24 @@ package postgresql
 
 import (
 	"context"
+	"crypto/x509"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"log"
 	"net/url"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/blang/semver"
-	_ "github.com/lib/pq" //PostgreSQL db
+	"github.com/lib/pq" //PostgreSQL db
 	"gocloud.dev/postgres"
 	_ "gocloud.dev/postgres/awspostgres"
 	_ "gocloud.dev/postgres/gcppostgres"
@@ -34,11 +42,25 @@ const (
 	featurePrivilegesOnSchemas
 	featureForceDropDatabase
 	featurePid
+	featurePublication
+	featurePublicationTruncate
+	featurePublicationViaRoot
+	featureSubscription
+	featureSequence
+	featureAlterSystem
+	featureStatistics
+	featureTransform
+	featurePartition
+	featureDetachPartitionConcurrently
+	featureRestrictivePolicy
+	featureSCRAMPassword
+	featureEnumAddValueInTransaction
 )
 
 var (
 	dbRegistryLock sync.Mutex
 	dbRegistry     map[string]*DBConnection = make(map[string]*DBConnection, 1)
+	dbOpeningLocks                          = map[string]*sync.Mutex{}
 
 	// Mapping of feature flags to versions
 	featureSupported = map[featureName]semver.Range{
@@ -86,6 +108,45 @@ var (
 		// Column procpid was replaced by pid in pg_stat_activity
 		// for Postgresql >= 9.2 and above
 		featurePid: semver.MustParseRange(">=9.2.0"),
+
+		// CREATE PUBLICATION support
+		featurePublication: semver.MustParseRange(">=10.0.0"),
+
+		// Publication of TRUNCATE operations
+		featurePublicationTruncate: semver.MustParseRange(">=11.0.0"),
+
+		// Publication parameter publish_via_partition_root
+		featurePublicationViaRoot: semver.MustParseRange(">=13.0.0"),
+
+		// CREATE SUBSCRIPTION support
+		featureSubscription: semver.MustParseRange(">=10.0.0"),
+
+		// pg_sequences view and CREATE SEQUENCE AS data_type
+		featureSequence: semver.MustParseRange(">=10.0.0"),
+
+		// ALTER SYSTEM with pg_file_settings and pg_settings.pending_restart
+		featureAlterSystem: semver.MustParseRange(">=9.5.0"),
+
+		// CREATE STATISTICS support
+		featureStatistics: semver.MustParseRange(">=10.0.0"),
+
+		// CREATE TRANSFORM support
+		featureTransform: semver.MustParseRange(">=9.5.0"),
+
+		// Declarative partitioning (PARTITION BY, ATTACH PARTITION)
+		featurePartition: semver.MustParseRange(">=10.0.0"),
+
+		// DETACH PARTITION CONCURRENTLY
+		featureDetachPartitionConcurrently: semver.MustParseRange(">=14.0.0"),
+
+		// CREATE POLICY AS RESTRICTIVE (pg_policy.polpermissive)
+		featureRestrictivePolicy: semver.MustParseRange(">=10.0.0"),
+
+		// password_encryption = 'scram-sha-256'
+		featureSCRAMPassword: semver.MustParseRange(">=10.0.0"),
+
+		// ALTER TYPE ... ADD VALUE inside a transaction block
+		featureEnumAddValueInTransaction: semver.MustParseRange(">=12.0.0"),
 	}
 )
 
@@ -97,6 +158,21 @@ type DBConnection struct {
 	// version is the version number of the database as determined by parsing the
 	// output of `SELECT VERSION()`.x
 	version semver.Version
+
+	// superuser is true if the connected user is a superuser, as detected when connecting,
+	// unless superuser is set to false in the provider configuration.
+	superuser bool
+	// managedSuperuserRole is the superuser-like role of a managed service (e.g. rds_superuser)
+	// the connected user is member of, if any.
+	managedSuperuserRole string
+
+	// compatibilityMode is the compatibility_mode of the provider,
+	// or the one detected from the server when it's not set.
+	compatibilityMode string
+
+	// resetAt is when the idle connections of the pool were last closed after a
+	// read-only error (see Client.resetPool), guarded by dbRegistryLock.
+	resetAt time.Time
 }
 
 // featureSupported returns true if a given feature is supported or not. This is
@@ -108,24 +184,79 @@ func (db *DBConnection) featureSupported(name featureName) bool {
 		// panic'ing because this is a provider-only bug
 		panic(fmt.Sprintf("unknown feature flag %v", name))
 	}
+	if supported, ok := compatibilityFeature(db.compatibilityMode, name); ok {
+		return supported
+	}
 
 	return fn(db.version)
 }
 
-// isSuperuser returns true if connected user is a Postgres SUPERUSER
-func (db *DBConnection) isSuperuser() (bool, error) {
+// managedSuperuserRoles are the roles given by the managed services to their
+// administrator users in place of the SUPERUSER attribute.
+var managedSuperuserRoles = []string{"rds_superuser", "cloudsqlsuperuser", "azure_pg_admin"}
+
+// detectSuperuser returns whether the role the statements of the provider run as is a
+// Postgres SUPERUSER, and the managed superuser-like role it is member of, if any.
+// This is the assumed role if set (the privileges are checked against it after SET ROLE,
+// whatever the login role), the connected user otherwise. The assumed role is given
+// explicitly as it's only set in the transactions with transaction_pooling.
+func detectSuperuser(db *sql.DB, compatibilityMode string, assumeRole string) (bool, string, error) {
 	var superuser bool
+	var managedRole sql.NullString
 
-	if err := db.QueryRow("SELECT rolsuper FROM pg_roles WHERE rolname = CURRENT_USER").Scan(&superuser); err != nil {
-		return false, fmt.Errorf("could not check if current user is superuser: %w", err)
+	if compatibilityMode == compatibilityRedshift {
+		// Redshift has no pg_roles
+		if err := db.QueryRow(
+			"SELECT usesuper FROM pg_catalog.pg_user WHERE usename = COALESCE(NULLIF($1, ''), CURRENT_USER)", assumeRole,
+		).Scan(&superuser); err != nil {
+			return false, "", fmt.Errorf("could not check if current user is superuser: %w", err)
+		}
+		return superuser, "", nil
+	}
+
+	err := db.QueryRow(
+		"SELECT u.rolsuper, "+
+			"(SELECT r.rolname FROM pg_catalog.pg_roles r WHERE r.rolname = ANY($1) AND pg_catalog.pg_has_role(u.oid, r.oid, 'MEMBER') LIMIT 1) "+
+			"FROM pg_catalog.pg_roles u WHERE u.rolname = COALESCE(NULLIF($2, ''), CURRENT_USER)",
+		pq.Array(managedSuperuserRoles), assumeRole,
+	).Scan(&superuser, &managedRole)
+	if err != nil {
+		return false, "", fmt.Errorf("could not check if current user is superuser: %w", err)
 	}
 
-	return superuser, nil
+	return superuser, managedRole.String, nil
+}
+
+// isSuperuser returns true if connected user is a Postgres SUPERUSER
+func (db *DBConnection) isSuperuser() bool {
+	return db.superuser
+}
+
+// requireSuperuser returns an explicit error if the connected user is not a superuser,
+// instead of the permission denied error operation would fail with.
+func (db *DBConnection) requireSuperuser(operation string) error {
+	if db.superuser {
+		return nil
+	}
+
+	currentUser := db.client.config.getDatabaseUsername()
+	if db.managedSuperuserRole != "" {
+		return fmt.Errorf(
+			"%s requires a SUPERUSER: connected user %s is only member of %s",
+			operation, currentUser, db.managedSuperuserRole,
+		)
+	}
+	return fmt.Errorf("%s requires a SUPERUSER: connected user %s is not one", operation, currentUser)
 }
 
 type ClientCertificateConfig struct {
 	CertificatePath string
 	KeyPath         string
+
+	// Certificate and Key are the PEM encoded contents of the certificate
+	// and of its private key, used instead of the paths if set.
+	Certificate string
+	Key         string
 }
 
 // Config - provider config
@@ -142,9 +273,85 @@ type Config struct {
 	Timeout           int
 	ConnectTimeoutSec int
 	MaxConns          int
+	MaxIdleConns      int
+	ConnMaxLifetime   time.Duration
+	MaxRetries        int
+	RetryBackoff      time.Duration
+	WaitForReady      time.Duration
+	PasswordCommand   string
 	ExpectedVersion   semver.Version
 	SSLClientCert     *ClientCertificateConfig
 	SSLRootCertPath   string
+
+	// SSLPassword decrypts the private key of the SSL client certificate, if encrypted.
+	SSLPassword string
+
+	// SSLRootCert is the PEM encoded content of the SSL server root certificate,
+	// used instead of SSLRootCertPath if set.
+	SSLRootCert string
+
+	// StatementTimeout and LockTimeout (in milliseconds) are set on all the
+	// sessions of the provider, zero means no timeout.
+	StatementTimeout int
+	LockTimeout      int
+
+	// AWSRDSIAMAuth replaces Password by an RDS auth token, generated with
+	// the credentials of the AWSRDSIAMProfile AWS profile (or the default ones).
+	AWSRDSIAMAuth    bool
+	AWSRDSIAMProfile string
+
+	// GCPIAMAuth replaces Password by an OAuth2 access token of the
+	// Google application default credentials, for Cloud SQL IAM database authentication.
+	GCPIAMAuth bool
+
+	// AzureIdentityAuth replaces Password by an Azure AD access token, of the
+	// service principal if AzureClientSecret is set, of the managed identity otherwise.
+	AzureIdentityAuth bool
+	AzureTenantID     string
+	AzureClientID     string
+	AzureClientSecret string
+
+	// PasswordSecretARN and PasswordSSMParameter replace Password by the one stored in an
+	// AWS Secrets Manager secret or SSM parameter, read when connecting.
+	PasswordSecretARN    string
+	PasswordSSMParameter string
+
+	// SSHTunnel is shared by all the clients of the provider, so the connections
+	// to all the databases go through the same SSH connection.
+	SSHTunnel *SSHTunnel
+
+	// Proxy is the URL of the SOCKS5 or HTTP proxy to connect through.
+	Proxy *url.URL
+
+	// Vault replaces Username and Password by the credentials of a Vault database
+	// secrets engine, generated when connecting. It's shared by all the clients.
+	Vault *VaultConfig
+
+	// Kerberos configures the GSSAPI authentication, used if the server requests it.
+	Kerberos *KerberosConfig
+
+	// Hosts are tried in order instead of Host and Port, until one of them
+	// matches TargetSessionAttrs (e.g.: to connect to the primary of an HA pair).
+	Hosts []HostPort
+	// TargetSessionAttrs is any (if empty), read-write, read-only, primary or standby, as in libpq.
+	TargetSessionAttrs string
+
+	// CompatibilityMode adapts the provider to a database compatible with PostgreSQL
+	// (e.g.: redshift), empty to detect it when connecting.
+	CompatibilityMode string
+
+	// AssumeRole is the role the sessions of the provider switch to after connecting
+	// (as SET ROLE), so the objects are created and owned by this role.
+	AssumeRole string
+
+	// TransactionPooling avoids the session-level constructs, so the provider can
+	// connect through a pooler in transaction mode (e.g.: PgBouncer pool_mode = transaction),
+	// where the consecutive transactions may run on different server connections.
+	TransactionPooling bool
+
+	// postgresOnlyOptions are the provider arguments set which are only supported
+	// with the postgres scheme.
+	postgresOnlyOptions []string
 }
 
 // Client struct holding connection string
@@ -153,6 +360,10 @@ type Client struct {
 	config Config
 
 	databaseName string
+
+	// ctx is the context of the operation using the client,
+	// its transactions and statements are cancelled when it's done.
+	ctx context.Context
 }
 
 // NewClient returns client config for the specified database.
@@ -160,7 +371,28 @@ func (c *Config) NewClient(database string) *Client {
 	return &Client{
 		config:       *c,
 		databaseName: database,
+		ctx:          context.Background(),
+	}
+}
+
+// withContext returns a copy of the client whose transactions and statements are cancelled
+// when ctx is done (e.g.: when the timeout of the operation is reached).
+func (c *Client) withContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+// withAssumedRole returns a client on the same database assuming role
+// instead of the assume_role of the provider, the client itself if role is empty.
+// Its connections are in a separate pool, as the role is set when connecting.
+func (c *Client) withAssumedRole(role string) *Client {
+	if role == "" || role == c.config.AssumeRole {
+		return c
 	}
+	config := c.config
+	config.AssumeRole = role
+	return config.NewClient(c.databaseName).withContext(c.ctx)
 }
 
 // featureSupported returns true if a given feature is supported or not.  This
@@ -172,10 +404,19 @@ func (c *Config) featureSupported(name featureName) bool {
 		// panic'ing because this is a provider-only bug
 		panic(fmt.Sprintf("unknown feature flag %v", name))
 	}
+	if supported, ok := compatibilityFeature(c.CompatibilityMode, name); ok {
+		return supported
+	}
 
 	return fn(c.ExpectedVersion)
 }
 
+// expectedVersionSet returns true if expected_version has been set by the user,
+// in which case the features are gated on this version instead of the fingerprinted one.
+func (c *Config) expectedVersionSet() bool {
+	return !c.ExpectedVersion.Equals(semver.MustParse(defaultExpectedPostgreSQLVersion))
+}
+
 func (c *Config) connParams() []string {
 	params := map[string]string{}
 
@@ -183,29 +424,171 @@ func (c *Config) connParams() []string {
 	// (TLS is provided by gocloud directly)
 	if c.Scheme == "postgres" {
 		params["sslmode"] = c.SSLMode
+		// Postgres doesn't support SSL on Unix domain sockets.
+		if c.SSLMode == "" && c.isUnixSocket() {
+			params["sslmode"] = "disable"
+		}
 		params["connect_timeout"] = strconv.Itoa(c.ConnectTimeoutSec)
 	}
 
+	if c.TransactionPooling {
+		// Send the parameters with the query instead of preparing an unnamed statement
+		// in a separate round trip, which could run on another server connection.
+		params["binary_parameters"] = "yes"
+	} else {
+		// The server settings are sent in the startup packet by lib/pq,
+		// so they apply to all the sessions.
+		// The poolers reject them, they are set in each transaction instead (see startTransaction).
+		if c.StatementTimeout > 0 {
+			params["statement_timeout"] = strconv.Itoa(c.StatementTimeout)
+		}
+		if c.LockTimeout > 0 {
+			params["lock_timeout"] = strconv.Itoa(c.LockTimeout)
+		}
+		if c.AssumeRole != "" {
+			params["role"] = c.AssumeRole
+		}
+	}
+
 	if c.featureSupported(featureFallbackApplicationName) {
-		params["fallback_application_name"] = c.ApplicationName
+		params["application_name"] = c.ApplicationName
 	}
-	if c.SSLClientCert != nil {
-		params["sslcert"] = c.SSLClientCert.CertificatePath
-		params["sslkey"] = c.SSLClientCert.KeyPath
+	// target_session_attrs is not supported by lib/pq, it's checked by pqConnector
+	// and only set in the connection string to identify the connection.
+	if c.TargetSessionAttrs != "" && c.TargetSessionAttrs != "any" {
+		params["target_session_attrs"] = c.TargetSessionAttrs
+	}
+	if c.Kerberos != nil {
+		if c.Kerberos.ServiceName != "" {
+			params["krbsrvname"] = c.Kerberos.ServiceName
+		}
+		if c.Kerberos.SPN != "" {
+			params["krbspn"] = c.Kerberos.SPN
+		}
 	}
+	if c.SSLClientCert != nil && c.SSLClientCert.Certificate != "" {
+		// lib/pq reads either all or none of the certificates inline,
+		// see resolveInlineCertificates.
+		params["sslinline"] = "true"
+		params["sslcert"] = c.SSLClientCert.Certificate
+		params["sslkey"] = c.SSLClientCert.Key
+		if c.SSLRootCert != "" {
+			params["sslrootcert"] = c.SSLRootCert
+		}
+	} else {
+		if c.SSLClientCert != nil {
+			params["sslcert"] = c.SSLClientCert.CertificatePath
+			params["sslkey"] = c.SSLClientCert.KeyPath
+		}
 
-	if c.SSLRootCertPath != "" {
-		params["sslrootcert"] = c.SSLRootCertPath
+		if c.SSLRootCertPath != "" {
+			params["sslrootcert"] = c.SSLRootCertPath
+		}
 	}
 
 	paramsArray := []string{}
 	for key, value := range params {
 		paramsArray = append(paramsArray, fmt.Sprintf("%s=%s", key, url.QueryEscape(value)))
 	}
+	// The connection string identifies the connection pool in dbRegistry,
+	// so it must not depend on the iteration order of the map.
+	sort.Strings(paramsArray)
 
 	return paramsArray
 }
 
+// resolveInlineCertificates makes the SSL certificates of the config either all inline
+// or all files, as lib/pq doesn't support to mix both.
+// The client certificate is also made inline if its key has to be decrypted.
+// It's resolved on a copy, as it's shared with the other copies of the config.
+func (c *Config) resolveInlineCertificates() error {
+	if c.SSLClientCert != nil {
+		clientCert := *c.SSLClientCert
+		c.SSLClientCert = &clientCert
+	}
+
+	if c.SSLPassword != "" && c.SSLClientCert != nil {
+		if c.SSLClientCert.Certificate == "" {
+			if err := c.SSLClientCert.readFiles(); err != nil {
+				return err
+			}
+		}
+		key, err := decryptPrivateKey(c.SSLClientCert.Key, c.SSLPassword)
+		if err != nil {
+			return err
+		}
+		c.SSLClientCert.Key = key
+	}
+
+	switch {
+	case c.SSLClientCert != nil && c.SSLClientCert.Certificate != "":
+		if c.SSLRootCertPath != "" {
+			rootCert, err := os.ReadFile(c.SSLRootCertPath)
+			if err != nil {
+				return fmt.Errorf("could not read SSL root certificate: %w", err)
+			}
+			c.SSLRootCert = string(rootCert)
+			c.SSLRootCertPath = ""
+		}
+
+	case c.SSLRootCert != "" && c.SSLClientCert != nil:
+		if err := c.SSLClientCert.readFiles(); err != nil {
+			return err
+		}
+
+	case c.SSLRootCert != "":
+		// lib/pq requires a client certificate with inline certificates, so the
+		// root certificate (which is not a secret) is written to a file instead.
+		path, err := sslRootCertFile(c.SSLRootCert)
+		if err != nil {
+			return err
+		}
+		c.SSLRootCertPath = path
+		c.SSLRootCert = ""
+	}
+
+	return nil
+}
+
+// readFiles reads the certificate and key files into Certificate and Key.
+func (c *ClientCertificateConfig) readFiles() error {
+	cert, err := os.ReadFile(c.CertificatePath)
+	if err != nil {
+		return fmt.Errorf("could not read SSL client certificate: %w", err)
+	}
+	key, err := os.ReadFile(c.KeyPath)
+	if err != nil {
+		return fmt.Errorf("could not read SSL client certificate key: %w", err)
+	}
+	c.Certificate = string(cert)
+	c.Key = string(key)
+	return nil
+}
+
+// decryptPrivateKey decrypts the PEM encoded private key with password, as lib/pq
+// doesn't support sslpassword. Only the legacy OpenSSL encryption (Proc-Type: 4,ENCRYPTED)
+// is supported, the key is returned as is if it's not encrypted.
+func decryptPrivateKey(key, password string) (string, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return "", fmt.Errorf("could not decode SSL client certificate key")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		return "", fmt.Errorf("encrypted PKCS#8 SSL client certificate keys are not supported, convert it with: openssl rsa -aes256 -in <key> -out <new key>")
+	}
+	//nolint:staticcheck // The legacy PEM encryption is insecure, but it's the one used by the existing keys.
+	if !x509.IsEncryptedPEMBlock(block) {
+		return key, nil
+	}
+
+	//nolint:staticcheck
+	der, err := x509.DecryptPEMBlock(block, []byte(password))
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt SSL client certificate key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der})), nil
+}
+
 func (c *Config) connStr(database string) string {
 	host := c.Host
 
@@ -215,6 +598,38 @@ func (c *Config) connStr(database string) string {
 		host = strings.ReplaceAll(host, ":", "/")
 	}
 
+	if len(c.Hosts) > 0 {
+		// Multiple hosts are not supported by lib/pq, they are tried by pqConnector,
+		// this connection string (in the libpq format) is only used to identify the connection.
+		hosts := make([]string, len(c.Hosts))
+		for i, host := range c.Hosts {
+			hosts[i] = host.String()
+		}
+		return fmt.Sprintf(
+			"%s://%s:%s@%s/%s?%s",
+			c.Scheme,
+			url.QueryEscape(c.Username),
+			url.QueryEscape(c.Password),
+			strings.Join(hosts, ","),
+			database,
+			strings.Join(c.connParams(), "&"),
+		)
+	}
+
+	if c.isUnixSocket() {
+		// lib/pq only reads a socket directory from the host parameter.
+		return fmt.Sprintf(
+			"%s://%s:%s@/%s?host=%s&port=%d&%s",
+			c.Scheme,
+			url.QueryEscape(c.Username),
+			url.QueryEscape(c.Password),
+			database,
+			url.QueryEscape(host),
+			c.Port,
+			strings.Join(c.connParams(), "&"),
+		)
+	}
+
 	connStr := fmt.Sprintf(
 		"%s://%s:%s@%s:%d/%s?%s",
 		c.Scheme,
@@ -229,6 +644,89 @@ func (c *Config) connStr(database string) string {
 	return connStr
 }
 
+// validate checks the consistency of the configuration.
+// It's done before connecting rather than when the provider is configured, as the
+// provider arguments may not be known yet during the plan (e.g.: the host of a database
+// created in the same apply), Terraform then configures the provider with empty values.
+func (c *Config) validate() error {
+	if c.Scheme != "postgres" && len(c.postgresOnlyOptions) > 0 {
+		return fmt.Errorf("%s is only supported with the postgres scheme", strings.Join(c.postgresOnlyOptions, ", "))
+	}
+
+	if c.SSLClientCert != nil {
+		if c.SSLClientCert.CertificatePath != "" && c.SSLClientCert.KeyPath == "" {
+			return fmt.Errorf("sslkey must be set with sslcert")
+		}
+		if c.SSLClientCert.Certificate != "" && c.SSLClientCert.Key == "" {
+			return fmt.Errorf("sslkey_pem must be set with sslcert_pem")
+		}
+	}
+
+	if c.isUnixSocket() && (c.SSHTunnel != nil || c.Proxy != nil) {
+		return fmt.Errorf("ssh_tunnel and proxy_url are not supported with a Unix domain socket host")
+	}
+
+	return nil
+}
+
+// isUnixSocket returns whether Host is the directory of a Unix domain socket
+// (e.g.: /var/run/postgresql) rather than a TCP address.
+func (c *Config) isUnixSocket() bool {
+	return c.Scheme == "postgres" && strings.HasPrefix(c.Host, "/")
+}
+
+// authTokenGenerator returns the function generating the token used as password
+// when an IAM authentication, a password command or an AWS password secret is enabled,
+// or nil if the password is used.
+func (c *Config) authTokenGenerator() func() (string, error) {
+	switch {
+	case c.PasswordCommand != "":
+		return func() (string, error) {
+			return runPasswordCommand(c.PasswordCommand)
+		}
+	case c.AWSRDSIAMAuth:
+		return func() (string, error) {
+			return getRDSAuthToken(c.AWSRDSIAMProfile, c.Username, c.Host, c.Port)
+		}
+	case c.GCPIAMAuth:
+		return getGCPAuthToken
+	case c.AzureIdentityAuth:
+		return func() (string, error) {
+			return getAzureAuthToken(c.AzureTenantID, c.AzureClientID, c.AzureClientSecret)
+		}
+	case c.PasswordSecretARN != "":
+		// Read again like the tokens, so a rotated password is used by the new connections.
+		return func() (string, error) {
+			return getSecretsManagerPassword(c.PasswordSecretARN)
+		}
+	case c.PasswordSSMParameter != "":
+		return func() (string, error) {
+			return getSSMParameterPassword(c.PasswordSSMParameter)
+		}
+	default:
+		return nil
+	}
+}
+
+// dialer returns the dialer opening the connections to the database,
+// or nil if they are opened directly.
+func (c *Config) dialer() pq.Dialer {
+	switch {
+	case c.SSHTunnel != nil:
+		return c.SSHTunnel
+	case c.Proxy != nil:
+		return proxyDialer{url: c.Proxy}
+	default:
+		return nil
+	}
+}
+
+// checksTargetSessionAttrs returns whether several hosts are configured or the server
+// must match target_session_attrs, which must then be handled by pqConnector.
+func (c *Config) checksTargetSessionAttrs() bool {
+	return len(c.Hosts) > 0 || (c.TargetSessionAttrs != "" && c.TargetSessionAttrs != "any")
+}
+
 func (c *Config) getDatabaseUsername() string {
 	if c.DatabaseUsername != "" {
 		return c.DatabaseUsername
@@ -240,52 +738,431 @@ func (c *Config) getDatabaseUsername() string {
 // Callers must return their database resources. Use of QueryRow() or Exec() is encouraged.
 // Query() must have their rows.Close()'ed.
 func (c *Client) Connect() (*DBConnection, error) {
+	dsn, openingLock, err := c.prepareConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	// The pool of a DSN is opened once. As it can take a while (e.g.: waiting for the server
+	// to be ready), only the clients connecting to the same DSN wait for it.
+	openingLock.Lock()
+	defer openingLock.Unlock()
+
+	addUsedPool(c.ctx, dsn)
+
+	dbRegistryLock.Lock()
+	conn, found := dbRegistry[dsn]
+	dbRegistryLock.Unlock()
+	if !found {
+		if conn, err = c.openPool(dsn); err != nil {
+			return nil, err
+		}
+		dbRegistryLock.Lock()
+		dbRegistry[dsn] = conn
+		dbRegistryLock.Unlock()
+	}
+
+	if conn.client != c {
+		// With transaction_pooling, the role isn't part of the connection string, so the pool is
+		// shared by the clients assuming another role: the connection is bound to this client,
+		// whose role is set in the transactions (see startTransaction).
+		shared := *conn
+		shared.client = c
+		return &shared, nil
+	}
+	return conn, nil
+}
+
+// prepareConnection completes the config of the client to connect and returns the DSN
+// of its pool in dbRegistry, with the lock of its opening.
+func (c *Client) prepareConnection() (string, *sync.Mutex, error) {
 	dbRegistryLock.Lock()
 	defer dbRegistryLock.Unlock()
 
+	if err := c.config.validate(); err != nil {
+		return "", nil, fmt.Errorf("invalid provider configuration: %w", err)
+	}
+	// The certificates are resolved on each connection, on the config of the client (a copy
+	// per operation), so the files created or renewed during the apply are read.
+	if err := c.config.resolveInlineCertificates(); err != nil {
+		return "", nil, err
+	}
+	if c.config.Vault != nil {
+		creds, err := c.config.Vault.credentials()
+		if err != nil {
+			return "", nil, err
+		}
+		// Only set once, as the config is read by the resources once connected.
+		if c.config.Username != creds.Username || c.config.Password != creds.Password {
+			c.config.Username, c.config.Password = creds.Username, creds.Password
+		}
+	}
+
 	dsn := c.config.connStr(c.databaseName)
-	conn, found := dbRegistry[dsn]
+	openingLock, found := dbOpeningLocks[dsn]
 	if !found {
+		openingLock = &sync.Mutex{}
+		dbOpeningLocks[dsn] = openingLock
+	}
+	return dsn, openingLock, nil
+}
 
-		var db *sql.DB
-		var err error
-		if c.config.Scheme == "postgres" {
-			db, err = sql.Open("postgres", dsn)
-		} else {
-			db, err = postgres.Open(context.Background(), dsn)
+// openPool opens the pool of the client to dsn and detects the server it's connected to.
+func (c *Client) openPool(dsn string) (*DBConnection, error) {
+	var db *sql.DB
+	var err error
+	generateToken := c.config.authTokenGenerator()
+	switch {
+	case c.config.Scheme == "postgres" && (generateToken != nil || c.config.dialer() != nil || c.config.checksTargetSessionAttrs()):
+		db = sql.OpenDB(newPQConnector(c.config, c.databaseName, generateToken))
+	case c.config.Scheme == "postgres":
+		db, err = sql.Open("postgres", dsn)
+	case generateToken != nil:
+		// GoCloud opens the connections itself, so the token can't be renewed.
+		config := c.config
+		config.Password, err = generateToken()
+		if err == nil {
+			db, err = postgres.Open(context.Background(), config.connStr(c.databaseName))
 		}
-		if err != nil {
+	default:
+		db, err = postgres.Open(context.Background(), dsn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to PostgreSQL server %s (scheme: %s): %w", c.config.Host, c.config.Scheme, err)
+	}
+
+	// The pool is shared by all the resources using this database, so a few idle
+	// connections are kept to be reused instead of opening one per statement.
+	// As the database might be managed by terraform, the idle connections are closed before
+	// it's dropped, renamed or used as template (see closeIdleConnections).
+	db.SetMaxIdleConns(c.config.MaxIdleConns)
+	db.SetConnMaxIdleTime(connMaxIdleTime)
+	db.SetMaxOpenConns(c.config.MaxConns)
+	db.SetConnMaxLifetime(c.config.ConnMaxLifetime)
+
+	if c.config.WaitForReady > 0 {
+		if err := waitForReady(c.ctx, db, c.config.WaitForReady); err != nil {
+			db.Close()
 			return nil, fmt.Errorf("Error connecting to PostgreSQL server %s (scheme: %s): %w", c.config.Host, c.config.Scheme, err)
 		}
+	}
 
-		// We don't want to retain connection
-		// So when we connect on a specific database which might be managed by terraform,
-		// we don't keep opened connection in case of the db has to be dopped in the plan.
-		db.SetMaxIdleConns(0)
-		db.SetMaxOpenConns(c.config.MaxConns)
-
-		defaultVersion, _ := semver.Parse(defaultExpectedPostgreSQLVersion)
-		version := &c.config.ExpectedVersion
-		if defaultVersion.Equals(c.config.ExpectedVersion) {
-			// Version hint not set by user, need to fingerprint
-			version, err = fingerprintCapabilities(db)
-			if err != nil {
-				db.Close()
-				return nil, fmt.Errorf("error detecting capabilities: %w", err)
-			}
+	compatibilityMode := c.config.CompatibilityMode
+	if compatibilityMode == "" {
+		if compatibilityMode, err = detectCompatibilityMode(db); err != nil {
+			log.Printf("[WARN] %v, assuming PostgreSQL", err)
+		} else if compatibilityMode != "" {
+			log.Printf("[INFO] %s detected, using compatibility_mode %s", c.config.Host, compatibilityMode)
 		}
+	}
 
-		conn = &DBConnection{
-			db,
-			c,
-			*version,
+	version := &c.config.ExpectedVersion
+	if !c.config.expectedVersionSet() {
+		// Version hint not set by user, need to fingerprint
+		version, err = fingerprintCapabilities(db)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("error detecting capabilities: %w", err)
 		}
-		dbRegistry[dsn] = conn
+	} else if err := checkServerVersion(db, c.config.ExpectedVersion); err != nil {
+		db.Close()
+		return nil, err
 	}
 
+	conn := &DBConnection{
+		DB:                db,
+		client:            c,
+		version:           *version,
+		superuser:         c.config.Superuser,
+		compatibilityMode: compatibilityMode,
+	}
+	if c.config.Superuser {
+		// Check if the connected user is really a superuser so the operations
+		// requiring it fail with an explicit error instead of permission denied.
+		superuser, managedRole, err := detectSuperuser(db, compatibilityMode, c.config.AssumeRole)
+		if err != nil {
+			log.Printf("[WARN] %v, assuming it is", err)
+		} else if !superuser {
+			log.Printf("[WARN] connected user %s is not a SUPERUSER, the operations requiring it are disabled", c.config.getDatabaseUsername())
+			conn.superuser = false
+			conn.managedSuperuserRole = managedRole
+		}
+	}
 	return conn, nil
 }
 
+// closeIdleConnections closes the idle connections of the pools of the client on database, if any,
+// so they don't prevent dropping, renaming or copying (as template) the database.
+// The pools are not closed, as other resources may be using them at the same moment:
+// the connections they are using stay open and the statement fails with
+// "database is being accessed by other users" if it still runs.
+func (c *Client) closeIdleConnections(database string) {
+	dbRegistryLock.Lock()
+	defer dbRegistryLock.Unlock()
+
+	dsn := c.config.connStr(database)
+	for key, conn := range dbRegistry {
+		// The pools of the resources assuming another role are released too.
+		config := conn.client.config
+		config.AssumeRole = c.config.AssumeRole
+		if key != dsn && config.connStr(conn.client.databaseName) != dsn {
+			continue
+		}
+
+		// Lowering the number of idle connections closes the ones above it.
+		conn.SetMaxIdleConns(0)
+		conn.SetMaxIdleConns(conn.client.config.MaxIdleConns)
+	}
+}
+
+// shutdownFuncs release the resources which outlive the clients (e.g.: Vault leases),
+// they are called by Shutdown.
+var (
+	shutdownLock  sync.Mutex
+	shutdownFuncs []func()
+)
+
+// onShutdown registers fn to be called when the provider stops.
+func onShutdown(fn func()) {
+	shutdownLock.Lock()
+	defer shutdownLock.Unlock()
+	shutdownFuncs = append(shutdownFuncs, fn)
+}
+
+// Shutdown releases the resources of the provider, in the reverse order of their creation.
+// It's called by main once the plugin server stops.
+func Shutdown() {
+	// The functions are called without the lock, as they may take their own locks.
+	shutdownLock.Lock()
+	funcs := shutdownFuncs
+	shutdownFuncs = nil
+	shutdownLock.Unlock()
+
+	for i := len(funcs) - 1; i >= 0; i-- {
+		funcs[i]()
+	}
+}
+
+// sslRootCertFiles are the files written by resolveInlineCertificates, by certificate,
+// so all the clients of the provider (one per database) share the same file.
+var (
+	sslRootCertFilesLock sync.Mutex
+	sslRootCertFiles     = map[string]string{}
+)
+
+// sslRootCertFile returns the path of a file containing rootCert, written on the first call.
+// The file is removed when the provider stops.
+func sslRootCertFile(rootCert string) (string, error) {
+	sslRootCertFilesLock.Lock()
+	defer sslRootCertFilesLock.Unlock()
+
+	if path, ok := sslRootCertFiles[rootCert]; ok {
+		return path, nil
+	}
+
+	file, err := os.CreateTemp("", "postgresql-sslrootcert-*.pem")
+	if err != nil {
+		return "", fmt.Errorf("could not create SSL root certificate file: %w", err)
+	}
+	defer file.Close()
+
+	path := file.Name()
+	if _, err := file.WriteString(rootCert); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("could not write SSL root certificate file: %w", err)
+	}
+	sslRootCertFiles[rootCert] = path
+
+	onShutdown(func() {
+		sslRootCertFilesLock.Lock()
+		defer sslRootCertFilesLock.Unlock()
+
+		delete(sslRootCertFiles, rootCert)
+		if err := os.Remove(path); err != nil {
+			log.Printf("[WARN] could not remove SSL root certificate file %s: %v", path, err)
+		}
+	})
+	return path, nil
+}
+
+// connMaxIdleTime is the time after which the idle connections are closed,
+// so the pools of the databases which are no longer used are released during long applies.
+const connMaxIdleTime = 30 * time.Second
+
+// authTokenRefreshInterval is the age after which a new auth token is generated.
+// The RDS tokens are only valid for 15 minutes (the Cloud SQL and Azure AD ones for about an hour),
+// they are renewed a bit earlier to not use a token about to expire.
+const authTokenRefreshInterval = 10 * time.Minute
+
+// authToken provides the token used as password by the IAM authentications.
+// As the tokens expire, a new one is generated when needed so the connections
+// opened during a long apply can still authenticate.
+type authToken struct {
+	generate func() (string, error)
+
+	mu          sync.Mutex
+	token       string
+	generatedAt time.Time
+}
+
+// get returns the current auth token, generating a new one if it is too old.
+func (t *authToken) get() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Since(t.generatedAt) < authTokenRefreshInterval {
+		return t.token, nil
+	}
+
+	token, err := t.generate()
+	if err != nil {
+		return "", fmt.Errorf("could not generate auth token: %w", err)
+	}
+	t.token = token
+	t.generatedAt = time.Now()
+
+	return token, nil
+}
+
+// invalidate forces the generation of a new token, e.g. if it's been rejected by the server.
+func (t *authToken) invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.token = ""
+}
+
+// isAuthenticationError returns whether err is an authentication failure,
+// e.g. because the auth token used as password has expired.
+func isAuthenticationError(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && (pqErr.Code == "28P01" || pqErr.Code == "28000")
+}
+
+// pqConnector opens the connections of the postgres scheme which can't be opened with
+// a static DSN: with an auth token as password, or through an SSH tunnel or a proxy.
+type pqConnector struct {
+	config   Config
+	database string
+
+	// authToken is nil if the password of the config is used.
+	authToken *authToken
+}
+
+func newPQConnector(config Config, database string, generateToken func() (string, error)) *pqConnector {
+	connector := &pqConnector{
+		config:   config,
+		database: database,
+	}
+	if generateToken != nil {
+		connector.authToken = &authToken{generate: generateToken}
+	}
+
+	return connector
+}
+
+// Connect implements driver.Connector.
+func (c *pqConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connectHosts(ctx)
+	// The token may expire earlier than authTokenRefreshInterval (e.g.: the output of a
+	// password command), so a new one is generated if it's rejected.
+	if err != nil && c.authToken != nil && isAuthenticationError(err) {
+		log.Printf("[DEBUG] authentication failed, generating a new auth token: %v", err)
+		c.authToken.invalidate()
+		conn, err = c.connectHosts(ctx)
+	}
+	return conn, err
+}
+
+func (c *pqConnector) connectHosts(ctx context.Context) (driver.Conn, error) {
+	config := c.config
+	if c.authToken != nil {
+		token, err := c.authToken.get()
+		if err != nil {
+			return nil, err
+		}
+		config.Password = token
+	}
+
+	if !config.checksTargetSessionAttrs() {
+		return c.connect(ctx, config)
+	}
+
+	targetSessionAttrs := config.TargetSessionAttrs
+	hosts := config.Hosts
+	if len(hosts) == 0 {
+		hosts = []HostPort{{Host: config.Host, Port: config.Port}}
+	}
+	config.Hosts = nil
+	config.TargetSessionAttrs = ""
+
+	// As libpq, the hosts are tried in order until one of them matches target_session_attrs.
+	errs := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		config.Host = host.Host
+		config.Port = host.Port
+
+		conn, err := c.connect(ctx, config)
+		if err == nil {
+			if err = checkTargetSessionAttrs(ctx, conn, targetSessionAttrs); err == nil {
+				return conn, nil
+			}
+			conn.Close()
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", host, err))
+	}
+
+	return nil, fmt.Errorf("could not connect to any of the hosts: %s", strings.Join(errs, "; "))
+}
+
+func (c *pqConnector) connect(ctx context.Context, config Config) (driver.Conn, error) {
+	if dialer := config.dialer(); dialer != nil {
+		return pq.DialOpen(dialer, config.connStr(c.database))
+	}
+
+	connector, err := pq.NewConnector(config.connStr(c.database))
+	if err != nil {
+		return nil, err
+	}
+
+	return connector.Connect(ctx)
+}
+
+// Driver implements driver.Connector.
+func (c *pqConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// checkServerVersion returns an error if the server is older than the expected version,
+// as the plan has been validated against the features of this version.
+// Servers without server_version_num (e.g. some PostgreSQL compatible databases) are not checked.
+func checkServerVersion(db *sql.DB, expected semver.Version) error {
+	var versionNum int
+	if err := db.QueryRow(`SHOW server_version_num`).Scan(&versionNum); err != nil {
+		log.Printf("[WARN] could not check the server version against expected_version %s: %v", expected, err)
+		return nil
+	}
+
+	version := parseServerVersionNum(versionNum)
+	if version.Major < expected.Major || (version.Major == expected.Major && version.Minor < expected.Minor) {
+		return fmt.Errorf(
+			"PostgreSQL server version %s is older than expected_version %s: set expected_version to the version of the server",
+			version, expected,
+		)
+	}
+	return nil
+}
+
+// parseServerVersionNum converts a server_version_num (e.g. 90624 or 140005)
+// to a version (9.6.24 or 14.5.0), the versions >= 10 having only two numbers.
+func parseServerVersionNum(versionNum int) semver.Version {
+	major := versionNum / 10000
+	if major >= 10 {
+		return semver.Version{Major: uint64(major), Minor: uint64(versionNum % 10000)}
+	}
+	return semver.Version{Major: uint64(major), Minor: uint64(versionNum / 100 % 100), Patch: uint64(versionNum % 100)}
+}
+
 // fingerprintCapabilities queries PostgreSQL to populate a local catalog of
 // capabilities.  This is only run once per Client.
 func fingerprintCapabilities(db *sql.DB) (*semver.Version, error) {
@@ -295,6 +1172,15 @@ func fingerprintCapabilities(db *sql.DB) (*semver.Version, error) {
 		return nil, fmt.Errorf("error PostgreSQL version: %w", err)
 	}
 
+	// CockroachDB CCL v23.1.11 (x86_64-pc-linux-gnu, ...) doesn't include the
+	// PostgreSQL version it's compatible with, which is its server_version.
+	if strings.HasPrefix(pgVersion, "CockroachDB") {
+		if err := db.QueryRow(`SHOW server_version`).Scan(&pgVersion); err != nil {
+			return nil, fmt.Errorf("error PostgreSQL version: %w", err)
+		}
+		pgVersion = "PostgreSQL " + pgVersion
+	}
+
 	// PostgreSQL 9.2.21 on x86_64-apple-darwin16.5.0, compiled by Apple LLVM version 8.1.0 (clang-802.0.42), 64-bit
 	// PostgreSQL 9.6.7, compiled by Visual C++ build 1800, 64-bit
 	fields := strings.FieldsFunc(pgVersion, func(c rune) bool {
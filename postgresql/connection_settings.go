@@ -0,0 +1,120 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// connectionSettings are the connection parameters read from a connection URI
+// or a connection service file. Only the parameters which have a provider
+// attribute are supported.
+type connectionSettings struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Database string
+	SSLMode  string
+
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+	SSLPassword string
+
+	TargetSessionAttrs string
+
+	// ConnectTimeoutSec is -1 if not set.
+	ConnectTimeoutSec int
+}
+
+func newConnectionSettings() *connectionSettings {
+	return &connectionSettings{ConnectTimeoutSec: -1}
+}
+
+// setParam sets a libpq connection parameter, the errors never contain
+// the value of the parameter as it may be a password.
+func (s *connectionSettings) setParam(key, value string) error {
+	var err error
+	switch key {
+	case "host":
+		s.Host = value
+	case "port":
+		if s.Port, err = strconv.Atoi(value); err != nil || s.Port < 1 || s.Port > 65535 {
+			return fmt.Errorf("invalid port %q", value)
+		}
+	case "dbname":
+		s.Database = value
+	case "user":
+		s.Username = value
+	case "password":
+		s.Password = value
+	case "sslmode":
+		s.SSLMode = value
+	case "sslrootcert":
+		s.SSLRootCert = value
+	case "sslcert":
+		s.SSLCert = value
+	case "sslkey":
+		s.SSLKey = value
+	case "sslpassword":
+		s.SSLPassword = value
+	case "target_session_attrs":
+		switch value {
+		case "any", "read-write", "read-only", "primary", "standby":
+			s.TargetSessionAttrs = value
+		default:
+			return fmt.Errorf("invalid target_session_attrs %q", value)
+		}
+	case "connect_timeout":
+		if s.ConnectTimeoutSec, err = strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid connect_timeout %q", value)
+		}
+	default:
+		return fmt.Errorf("unsupported parameter %q", key)
+	}
+	return nil
+}
+
+func (s *connectionSettings) validate() error {
+	if (s.SSLCert == "") != (s.SSLKey == "") {
+		return fmt.Errorf("sslcert and sslkey must be both set")
+	}
+	return nil
+}
+
+// applyTo overrides the connection settings of config with the ones which are set.
+func (s *connectionSettings) applyTo(config *Config) {
+	if s.Host != "" {
+		config.Host = s.Host
+	}
+	if s.Port != 0 {
+		config.Port = s.Port
+	}
+	if s.Username != "" {
+		config.Username = s.Username
+	}
+	if s.Password != "" {
+		config.Password = s.Password
+	}
+	if s.SSLMode != "" {
+		config.SSLMode = s.SSLMode
+	}
+	if s.SSLRootCert != "" {
+		config.SSLRootCertPath = s.SSLRootCert
+	}
+	if s.SSLCert != "" {
+		config.SSLClientCert = &ClientCertificateConfig{
+			CertificatePath: s.SSLCert,
+			KeyPath:         s.SSLKey,
+		}
+	}
+	if s.SSLPassword != "" {
+		config.SSLPassword = s.SSLPassword
+	}
+	if s.TargetSessionAttrs != "" {
+		config.TargetSessionAttrs = s.TargetSessionAttrs
+	}
+	if s.ConnectTimeoutSec >= 0 {
+		config.ConnectTimeoutSec = s.ConnectTimeoutSec
+	}
+}
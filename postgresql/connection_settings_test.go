@@ -0,0 +1,25 @@
+package postgresql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConnectionSettingsApplyTo(t *testing.T) {
+	config := &Config{Host: "localhost", Port: 5432, Username: "postgres", Password: "postgres", SSLMode: "require", ConnectTimeoutSec: 180}
+	uri := &connectionSettings{Host: "db.example.com", Username: "tf_user", SSLCert: "/etc/ssl/cert.pem", SSLKey: "/etc/ssl/key.pem", ConnectTimeoutSec: -1}
+	uri.applyTo(config)
+
+	want := &Config{
+		Host:              "db.example.com",
+		Port:              5432,
+		Username:          "tf_user",
+		Password:          "postgres",
+		SSLMode:           "require",
+		ConnectTimeoutSec: 180,
+		SSLClientCert:     &ClientCertificateConfig{CertificatePath: "/etc/ssl/cert.pem", KeyPath: "/etc/ssl/key.pem"},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("applyTo returned %+v, want %+v", config, want)
+	}
+}
@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// parseConnectionURI parses a libpq connection URI:
+// postgres://[user[:password]@][host][:port][/database][?param=value&...]
+// The errors never contain the URI as it may contain a password.
+func parseConnectionURI(rawURI string) (*connectionSettings, error) {
+	u, err := url.Parse(rawURI)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse connection URI")
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return nil, fmt.Errorf("unsupported connection URI scheme %q, must be postgres or postgresql", u.Scheme)
+	}
+
+	settings := newConnectionSettings()
+	settings.Host = u.Hostname()
+	if len(u.Path) > 1 {
+		settings.Database = u.Path[1:]
+	}
+	if u.User != nil {
+		settings.Username = u.User.Username()
+		settings.Password, _ = u.User.Password()
+	}
+
+	if port := u.Port(); port != "" {
+		if err := settings.setParam("port", port); err != nil {
+			return nil, fmt.Errorf("%w in connection URI", err)
+		}
+	}
+	for key, values := range u.Query() {
+		if err := settings.setParam(key, values[len(values)-1]); err != nil {
+			return nil, fmt.Errorf("%w in connection URI", err)
+		}
+	}
+
+	if settings.Host == "" {
+		return nil, fmt.Errorf("no host in connection URI")
+	}
+	if err := settings.validate(); err != nil {
+		return nil, fmt.Errorf("%w in connection URI", err)
+	}
+
+	return settings, nil
+}
+
+// redactConnectionURI masks the password of a connection URI so it can be logged.
+func redactConnectionURI(rawURI string) string {
+	u, err := url.Parse(rawURI)
+	if err != nil {
+		return "<invalid connection URI>"
+	}
+
+	query := u.Query()
+	for _, param := range []string{"password", "sslpassword"} {
+		if query.Get(param) != "" {
+			query.Set(param, "xxxxx")
+			u.RawQuery = query.Encode()
+		}
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// The pid column is given as a parameter, as it was named procpid before PostgreSQL 9.2.
+	// The background processes, which have no database or no role, are excluded.
+	activeConnectionQuery = `
+	SELECT datname, usename, COALESCE(application_name, ''), count(*)
+	FROM pg_catalog.pg_stat_activity
+	WHERE %[1]s <> pg_catalog.pg_backend_pid() AND datname IS NOT NULL AND usename IS NOT NULL
+	`
+	activeConnectionDatabaseKeyword        = "datname"
+	activeConnectionRoleKeyword            = "usename"
+	activeConnectionApplicationNameKeyword = "application_name"
+)
+
+func dataSourcePostgreSQLActiveConnections() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLActiveConnectionsRead),
+		Schema: map[string]*schema.Schema{
+			"databases": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The database(s) of the connections to count. Counts the connections to all databases by default",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The role(s) of the connections to count. Counts the connections of all roles by default",
+			},
+			"application_names": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The application name(s) of the connections to count. Counts the connections of all applications by default",
+			},
+			"max_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validation.IntAtLeast(-1),
+				Description:  "The maximum number of connections, the data source fails if there are more connections. -1 means no limit",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of connections",
+			},
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"application_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The number of connections by database, role and application name",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLActiveConnectionsRead(db *DBConnection, d *schema.ResourceData) error {
+	pid := "procpid"
+	if db.featureSupported(featurePid) {
+		pid = "pid"
+	}
+
+	query := fmt.Sprintf(activeConnectionQuery, pid)
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, activeConnectionDatabaseKeyword, d.Get("databases").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, activeConnectionRoleKeyword, d.Get("roles").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, activeConnectionApplicationNameKeyword, d.Get("application_names").([]interface{}))
+	query = fmt.Sprintf("%s GROUP BY 1, 2, 3 ORDER BY 1, 2, 3", query)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	total := 0
+	connections := make([]interface{}, 0)
+	for rows.Next() {
+		var database, role, applicationName string
+		var count int
+
+		if err = rows.Scan(&database, &role, &applicationName, &count); err != nil {
+			return fmt.Errorf("could not scan active connections output: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["database"] = database
+		result["role"] = role
+		result["application_name"] = applicationName
+		result["count"] = count
+		connections = append(connections, result)
+		total += count
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if maxCount := d.Get("max_count").(int); maxCount >= 0 && total > maxCount {
+		return fmt.Errorf("there are %d active connections, more than the maximum of %d", total, maxCount)
+	}
+
+	d.Set("total_count", total)
+	d.Set("connections", connections)
+	d.SetId(generateDataSourceActiveConnectionsID(d))
+
+	return nil
+}
+
+func generateDataSourceActiveConnectionsID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		"active_connections",
+		generatePatternArrayString(d.Get("databases").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("roles").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("application_names").([]interface{}), queryArrayKeywordAny),
+	}, "_")
+}
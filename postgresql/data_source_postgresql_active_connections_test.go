@@ -0,0 +1,60 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceActiveConnections(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+
+	// Keep an open connection to the test database during the test.
+	db, err := sql.Open("postgres", config.connStr(dbName))
+	if err != nil {
+		t.Fatalf("could not open connection pool for db %s: %v", dbName, err)
+	}
+	defer db.Close()
+	db.SetMaxIdleConns(1)
+	if err := db.Ping(); err != nil {
+		t.Fatalf("could not connect to db %s: %v", dbName, err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_active_connections" "test" {
+					databases = ["%s"]
+					max_count = 1
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_active_connections.test", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_active_connections.test", "connections.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_active_connections.test", "connections.0.database", dbName),
+					resource.TestCheckResourceAttr("data.postgresql_active_connections.test", "connections.0.role", config.Username),
+					resource.TestCheckResourceAttr("data.postgresql_active_connections.test", "connections.0.count", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_active_connections" "test" {
+					databases = ["%s"]
+					max_count = 0
+				}`, dbName),
+				ExpectError: regexp.MustCompile("there are 1 active connections, more than the maximum of 0"),
+			},
+		},
+	})
+}
@@ -0,0 +1,171 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	availableExtensionQuery = `
+	SELECT name, default_version, installed_version
+	FROM pg_catalog.pg_available_extensions
+	`
+	availableExtensionPatternMatchingTarget = "name"
+)
+
+func dataSourcePostgreSQLAvailableExtensions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLAvailableExtensionsRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The PostgreSQL database in which the installed versions of the extensions are retrieved. Defaults to the database of the provider",
+			},
+			"required": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The extension(s) which must be available on the server, the data source fails if one of them is missing",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against extension names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against extension names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against extension names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against extension names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"extensions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"installed_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL extensions available on the server retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLAvailableExtensionsRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureExtension) {
+		return fmt.Errorf(
+			"postgresql_available_extensions data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	query := availableExtensionQuery
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyOptionalPatternMatchingToQuery(query, availableExtensionPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY name", query)
+
+	database := getDatabase(d, db.client.databaseName)
+	conn, err := connectToDatabase(db.client, database)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	available := map[string]bool{}
+	extensions := make([]interface{}, 0)
+	for rows.Next() {
+		var name string
+		var defaultVersion, installedVersion sql.NullString
+
+		if err = rows.Scan(&name, &defaultVersion, &installedVersion); err != nil {
+			return fmt.Errorf("could not scan available extension output: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["default_version"] = defaultVersion.String
+		result["installed_version"] = installedVersion.String
+		extensions = append(extensions, result)
+		available[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if missing := findMissingExtensions(d.Get("required").([]interface{}), available); len(missing) > 0 {
+		return fmt.Errorf(
+			"required extension(s) %s not available on the PostgreSQL server, they need to be installed on the host first",
+			strings.Join(missing, ", "),
+		)
+	}
+
+	d.Set("extensions", extensions)
+	d.Set("database", database)
+	d.SetId(generateDataSourceAvailableExtensionsID(d, database))
+
+	return nil
+}
+
+// findMissingExtensions returns the sorted names of the required extensions which are not available.
+func findMissingExtensions(required []interface{}, available map[string]bool) []string {
+	missing := []string{}
+	for _, name := range required {
+		if !available[name.(string)] {
+			missing = append(missing, name.(string))
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+func generateDataSourceAvailableExtensionsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		"available_extensions",
+		databaseName,
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,56 @@
+package postgresql
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFindMissingExtensions(t *testing.T) {
+	available := map[string]bool{"hstore": true, "plpgsql": true}
+
+	missing := findMissingExtensions([]interface{}{"postgis", "hstore", "citext"}, available)
+	if expected := []string{"citext", "postgis"}; !reflect.DeepEqual(missing, expected) {
+		t.Fatalf("expected %v, got %v", expected, missing)
+	}
+
+	if missing := findMissingExtensions([]interface{}{"plpgsql"}, available); len(missing) != 0 {
+		t.Fatalf("expected no missing extension, got %v", missing)
+	}
+}
+
+func TestAccPostgresqlDataSourceAvailableExtensions(t *testing.T) {
+	skipIfNotAcc(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureExtension)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "postgresql_available_extensions" "test" {
+					required          = ["plpgsql"]
+					like_any_patterns = ["plpgsql"]
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_available_extensions.test", "extensions.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_available_extensions.test", "extensions.0.name", "plpgsql"),
+					resource.TestCheckResourceAttrSet("data.postgresql_available_extensions.test", "extensions.0.default_version"),
+					resource.TestCheckResourceAttrSet("data.postgresql_available_extensions.test", "extensions.0.installed_version"),
+				),
+			},
+			{
+				Config: `
+				data "postgresql_available_extensions" "test" {
+					required = ["plpgsql", "not_an_extension"]
+				}`,
+				ExpectError: regexp.MustCompile("required extension\\(s\\) not_an_extension not available"),
+			},
+		},
+	})
+}
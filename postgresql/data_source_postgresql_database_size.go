@@ -0,0 +1,156 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// The size of a table includes its indexes and TOAST data.
+	// Partitioned tables have no storage of their own, their partitions are listed instead.
+	tableSizeQuery = `
+	SELECT n.nspname, c.relname, pg_catalog.pg_total_relation_size(c.oid)
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'm')
+	AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	AND n.nspname NOT LIKE 'pg_toast%'
+	`
+	tableSizeSchemaKeyword = "n.nspname"
+)
+
+func dataSourcePostgreSQLDatabaseSize() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLDatabaseSizeRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database whose size will be queried",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) whose sizes will be queried. Queries all schemas in the database by default",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total size of the database in bytes",
+			},
+			"schema_sizes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The sizes in bytes of the schemas of the database",
+			},
+			"table_sizes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The sizes in bytes of the tables of the database, including their indexes and TOAST data",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLDatabaseSizeRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+
+	var size int64
+	if err := db.QueryRow("SELECT pg_catalog.pg_database_size($1)", database).Scan(&size); err != nil {
+		return fmt.Errorf("could not read size of database %s: %w", database, err)
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := tableSizeQuery
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, tableSizeSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = fmt.Sprintf("%s ORDER BY n.nspname, c.relname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tableSizes := make([]interface{}, 0)
+	schemaSizes := make([]interface{}, 0)
+	var schemaSize map[string]interface{}
+	for rows.Next() {
+		var objectName, schemaName string
+		var tableSize int64
+
+		if err = rows.Scan(&schemaName, &objectName, &tableSize); err != nil {
+			return fmt.Errorf("could not scan table size output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["object_name"] = objectName
+		result["schema_name"] = schemaName
+		result["size"] = int(tableSize)
+		tableSizes = append(tableSizes, result)
+
+		// The tables are ordered by schema, so the schema sizes can be summed up as we go.
+		if schemaSize == nil || schemaSize["schema_name"] != schemaName {
+			schemaSize = map[string]interface{}{"schema_name": schemaName, "size": 0}
+			schemaSizes = append(schemaSizes, schemaSize)
+		}
+		schemaSize["size"] = schemaSize["size"].(int) + int(tableSize)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("size", int(size))
+	d.Set("schema_sizes", schemaSizes)
+	d.Set("table_sizes", tableSizes)
+	d.SetId(generateDataSourceDatabaseSizeID(d, database))
+
+	return nil
+}
+
+func generateDataSourceDatabaseSizeID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		"database_size",
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+	}, "_")
+}
@@ -0,0 +1,48 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceDatabaseSize(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer PRIMARY KEY, label text)")
+	dbExecute(t, config.connStr(dbName), "INSERT INTO test_schema.orders SELECT i, md5(i::text) FROM generate_series(1, 1000) AS i")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.customers (id integer)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_database_size" "test" {
+					database = "%s"
+					schemas  = ["test_schema"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.postgresql_database_size.test", "size"),
+					resource.TestCheckResourceAttr("data.postgresql_database_size.test", "schema_sizes.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_database_size.test", "schema_sizes.0.schema_name", "test_schema"),
+					resource.TestCheckResourceAttr("data.postgresql_database_size.test", "table_sizes.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_database_size.test", "table_sizes.0.object_name", "customers"),
+					resource.TestCheckResourceAttr("data.postgresql_database_size.test", "table_sizes.0.size", "0"),
+					resource.TestCheckResourceAttr("data.postgresql_database_size.test", "table_sizes.1.object_name", "orders"),
+					resource.TestCheckResourceAttrPair(
+						"data.postgresql_database_size.test", "schema_sizes.0.size",
+						"data.postgresql_database_size.test", "table_sizes.1.size",
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var databaseQueries = map[string]string{
+	"query_include_template_databases": `
+	SELECT d.datname, pg_catalog.pg_get_userbyid(d.datdba), pg_catalog.pg_encoding_to_char(d.encoding), d.datconnlimit
+	FROM pg_catalog.pg_database d
+	`,
+	"query_exclude_template_databases": `
+	SELECT d.datname, pg_catalog.pg_get_userbyid(d.datdba), pg_catalog.pg_encoding_to_char(d.encoding), d.datconnlimit
+	FROM pg_catalog.pg_database d
+	WHERE NOT d.datistemplate
+	`,
+}
+
+const databasePatternMatchingTarget = "d.datname"
+
+func dataSourcePostgreSQLDatabases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLDatabasesRead),
+		Schema: map[string]*schema.Schema{
+			"include_template_databases": {
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+				Description: "Determines whether to include template databases (e.g.: template0 and template1)",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against database names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against database names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against database names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against database names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"databases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"encoding": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_limit": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL databases retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLDatabasesRead(db *DBConnection, d *schema.ResourceData) error {
+	var query string
+	var queryConcatKeyword string
+	if d.Get("include_template_databases").(bool) {
+		query = databaseQueries["query_include_template_databases"]
+		queryConcatKeyword = queryConcatKeywordWhere
+	} else {
+		query = databaseQueries["query_exclude_template_databases"]
+		queryConcatKeyword = queryConcatKeywordAnd
+	}
+
+	query = applyOptionalPatternMatchingToQuery(query, databasePatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY d.datname", query)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	databases := make([]interface{}, 0)
+	for rows.Next() {
+		var name, owner, encoding string
+		var connectionLimit int
+
+		if err = rows.Scan(&name, &owner, &encoding, &connectionLimit); err != nil {
+			return fmt.Errorf("could not scan database output: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["owner"] = owner
+		result["encoding"] = encoding
+		result["connection_limit"] = connectionLimit
+		databases = append(databases, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("databases", databases)
+	d.SetId(generateDataSourceDatabasesID(d))
+
+	return nil
+}
+
+func generateDataSourceDatabasesID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		"databases", strconv.FormatBool(d.Get("include_template_databases").(bool)),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,49 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceDatabases(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_databases" "test" {
+					like_any_patterns = ["%s"]
+				}
+
+				data "postgresql_databases" "templates" {
+					include_template_databases = true
+					like_any_patterns          = ["template%%"]
+				}
+
+				data "postgresql_databases" "no_templates" {
+					like_any_patterns = ["template%%"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_databases.test", "databases.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_databases.test", "databases.0.name", dbName),
+					resource.TestCheckResourceAttr("data.postgresql_databases.test", "databases.0.owner", config.Username),
+					resource.TestCheckResourceAttrSet("data.postgresql_databases.test", "databases.0.encoding"),
+					resource.TestCheckResourceAttr("data.postgresql_databases.test", "databases.0.connection_limit", "-1"),
+					resource.TestCheckResourceAttr("data.postgresql_databases.templates", "databases.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_databases.no_templates", "databases.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,192 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	// The entries of pg_default_acl are exploded to one row per grantee and privilege,
+	// the filters are applied on these rows before they are grouped back per grantee.
+	defaultPrivilegesQuery = `
+	SELECT pg_catalog.pg_get_userbyid(acl.defaclrole), COALESCE(n.nspname, ''),
+		CASE acl.defaclobjtype WHEN 'r' THEN 'table' WHEN 'S' THEN 'sequence' WHEN 'f' THEN 'function' WHEN 'T' THEN 'type' ELSE 'schema' END,
+		CASE WHEN acl.grantee = 0 THEN 'public' ELSE pg_catalog.pg_get_userbyid(acl.grantee) END,
+		array_agg(acl.privilege_type ORDER BY acl.privilege_type), bool_and(acl.is_grantable)
+	FROM (
+		SELECT defaclrole, defaclnamespace, defaclobjtype, (pg_catalog.aclexplode(defaclacl)).*
+		FROM pg_catalog.pg_default_acl
+	) acl
+	LEFT JOIN pg_catalog.pg_namespace n ON n.oid = acl.defaclnamespace
+	`
+	defaultPrivilegesOwnerKeyword      = "pg_catalog.pg_get_userbyid(acl.defaclrole)"
+	defaultPrivilegesSchemaKeyword     = "COALESCE(n.nspname, '')"
+	defaultPrivilegesObjectTypeKeyword = "acl.defaclobjtype"
+	defaultPrivilegesRoleKeyword       = "CASE WHEN acl.grantee = 0 THEN 'public' ELSE pg_catalog.pg_get_userbyid(acl.grantee) END"
+)
+
+func dataSourcePostgreSQLDefaultPrivileges() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLDefaultPrivilegesRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for default privileges",
+			},
+			"owners": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The owner role(s) of the default privileges to query. Queries the default privileges of all owners by default",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) of the default privileges to query, an empty string matching the default privileges of the whole database. Queries all schemas by default",
+			},
+			"object_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"table",
+						"sequence",
+						"function",
+						"type",
+						"schema",
+					}, false),
+				},
+				MinItems:    0,
+				Description: "The PostgreSQL object type(s) of the default privileges to query (table, sequence, function, type or schema). Queries all object types by default",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The role(s) to which the default privileges are granted ('public' for all roles). Queries the default privileges granted to all roles by default",
+			},
+			"default_privileges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"privileges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"with_grant_option": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL default privileges retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLDefaultPrivilegesRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_default_privileges data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// pg_default_acl stores the object types as a single character.
+	objectTypeCodes := []interface{}{}
+	for _, objectType := range d.Get("object_types").([]interface{}) {
+		objectTypeCodes = append(objectTypeCodes, objectTypes[objectType.(string)])
+	}
+
+	query := defaultPrivilegesQuery
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, defaultPrivilegesOwnerKeyword, d.Get("owners").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, defaultPrivilegesSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, defaultPrivilegesObjectTypeKeyword, objectTypeCodes)
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, defaultPrivilegesRoleKeyword, d.Get("roles").([]interface{}))
+	query = fmt.Sprintf("%s GROUP BY 1, 2, 3, 4 ORDER BY 1, 2, 3, 4", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	defaultPrivileges := make([]interface{}, 0)
+	for rows.Next() {
+		var owner, schemaName, objectType, role string
+		var privileges []string
+		var withGrantOption bool
+
+		if err = rows.Scan(&owner, &schemaName, &objectType, &role, pq.Array(&privileges), &withGrantOption); err != nil {
+			return fmt.Errorf("could not scan default privileges output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["owner"] = owner
+		result["schema"] = schemaName
+		result["object_type"] = objectType
+		result["role"] = role
+		result["privileges"] = privileges
+		result["with_grant_option"] = withGrantOption
+		defaultPrivileges = append(defaultPrivileges, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("default_privileges", defaultPrivileges)
+	d.SetId(generateDataSourceDefaultPrivilegesID(d, database))
+
+	return nil
+}
+
+func generateDataSourceDefaultPrivilegesID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		"default_privileges",
+		databaseName,
+		generatePatternArrayString(d.Get("owners").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("object_types").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("roles").([]interface{}), queryArrayKeywordAny),
+	}, "_")
+}
@@ -0,0 +1,62 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceDefaultPrivileges(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf(
+		"ALTER DEFAULT PRIVILEGES FOR ROLE %[1]s IN SCHEMA test_schema GRANT SELECT, INSERT ON TABLES TO %[2]s", config.Username, roleName,
+	))
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf(
+		"ALTER DEFAULT PRIVILEGES FOR ROLE %[1]s IN SCHEMA test_schema GRANT USAGE ON SEQUENCES TO %[2]s WITH GRANT OPTION", config.Username, roleName,
+	))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_default_privileges" "all" {
+					database = "%[1]s"
+					roles    = ["%[2]s"]
+				}
+
+				data "postgresql_default_privileges" "tables" {
+					database     = "%[1]s"
+					schemas      = ["test_schema"]
+					object_types = ["table"]
+				}`, dbName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.all", "default_privileges.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.all", "default_privileges.0.object_type", "sequence"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.all", "default_privileges.0.privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.all", "default_privileges.0.privileges.0", "USAGE"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.all", "default_privileges.0.with_grant_option", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.owner", config.Username),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.schema", "test_schema"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.role", roleName),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.privileges.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.privileges.0", "INSERT"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.privileges.1", "SELECT"),
+					resource.TestCheckResourceAttr("data.postgresql_default_privileges.tables", "default_privileges.0.with_grant_option", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	extensionQuery = `
+	SELECT e.extname, e.extversion, n.nspname
+	FROM pg_catalog.pg_extension e
+	JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+	`
+	extensionPatternMatchingTarget = "e.extname"
+	extensionSchemaKeyword         = "n.nspname"
+)
+
+func dataSourcePostgreSQLExtensions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLExtensionsRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for installed extensions",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) which will be queried for installed extensions. Queries all schemas in the database by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against extension names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against extension names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against extension names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against extension names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"extensions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL extensions installed in the database retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLExtensionsRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureExtension) {
+		return fmt.Errorf(
+			"postgresql_extensions data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := extensionQuery
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, extensionSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, extensionPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY e.extname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	extensions := make([]interface{}, 0)
+	for rows.Next() {
+		var name, version, schema string
+
+		if err = rows.Scan(&name, &version, &schema); err != nil {
+			return fmt.Errorf("could not scan extension output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["version"] = version
+		result["schema"] = schema
+		extensions = append(extensions, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("extensions", extensions)
+	d.SetId(generateDataSourceExtensionsID(d, database))
+
+	return nil
+}
+
+func generateDataSourceExtensionsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceExtensions(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE EXTENSION hstore SCHEMA test_schema")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureExtension)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_extensions" "all" {
+					database = "%[1]s"
+				}
+
+				data "postgresql_extensions" "test_schema" {
+					database = "%[1]s"
+					schemas  = ["test_schema"]
+				}
+
+				data "postgresql_extensions" "no_match" {
+					database          = "%[1]s"
+					like_any_patterns = ["postgis%%"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.postgresql_extensions.all", "extensions.*", map[string]string{"name": "plpgsql", "schema": "pg_catalog"}),
+					resource.TestCheckResourceAttr("data.postgresql_extensions.test_schema", "extensions.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_extensions.test_schema", "extensions.0.name", "hstore"),
+					resource.TestCheckResourceAttrSet("data.postgresql_extensions.test_schema", "extensions.0.version"),
+					resource.TestCheckResourceAttr("data.postgresql_extensions.no_match", "extensions.#", "0"),
+				),
+			},
+		},
+	})
+}
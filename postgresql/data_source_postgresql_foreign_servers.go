@@ -0,0 +1,164 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	foreignServerQuery = `
+	SELECT s.srvname, w.fdwname, s.srvtype, s.srvversion, pg_catalog.pg_get_userbyid(s.srvowner),
+		COALESCE(s.srvoptions, '{}')
+	FROM pg_catalog.pg_foreign_server s
+	JOIN pg_catalog.pg_foreign_data_wrapper w ON w.oid = s.srvfdw
+	`
+	foreignServerPatternMatchingTarget = "s.srvname"
+	foreignServerFDWKeyword            = "w.fdwname"
+)
+
+func dataSourcePostgreSQLForeignServers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLForeignServersRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for foreign server names",
+			},
+			"foreign_data_wrappers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The foreign-data wrapper(s) of the foreign servers to query. Queries the servers of all foreign-data wrappers by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against foreign server names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against foreign server names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against foreign server names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against foreign server names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"foreign_servers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"foreign_data_wrapper": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"options": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+				Description: "The list of PostgreSQL foreign servers retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLForeignServersRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := foreignServerQuery
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, foreignServerFDWKeyword, d.Get("foreign_data_wrappers").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, foreignServerPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY s.srvname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	foreignServers := make([]interface{}, 0)
+	for rows.Next() {
+		var name, fdwName, owner string
+		var serverType, version sql.NullString
+		var options []string
+
+		if err = rows.Scan(&name, &fdwName, &serverType, &version, &owner, pq.Array(&options)); err != nil {
+			return fmt.Errorf("could not scan foreign server output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["foreign_data_wrapper"] = fdwName
+		result["type"] = serverType.String
+		result["version"] = version.String
+		result["owner"] = owner
+		result["options"] = parseFDWOptions(options)
+		foreignServers = append(foreignServers, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("foreign_servers", foreignServers)
+	d.SetId(generateDataSourceForeignServersID(d, database))
+
+	return nil
+}
+
+func generateDataSourceForeignServersID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("foreign_data_wrappers").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
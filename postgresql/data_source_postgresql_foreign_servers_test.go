@@ -0,0 +1,57 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceForeignServers(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE EXTENSION postgres_fdw")
+	dbExecute(t, config.connStr(dbName), "CREATE SERVER orders_server FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'orders.local', port '5432', dbname 'orders')")
+	dbExecute(t, config.connStr(dbName), "CREATE SERVER customers_server TYPE 'postgresql' VERSION '13' FOREIGN DATA WRAPPER postgres_fdw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureExtension)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_foreign_servers" "all" {
+					database              = "%[1]s"
+					foreign_data_wrappers = ["postgres_fdw"]
+				}
+
+				data "postgresql_foreign_servers" "orders" {
+					database          = "%[1]s"
+					like_any_patterns = ["orders%%"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.all", "foreign_servers.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.all", "foreign_servers.0.name", "customers_server"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.all", "foreign_servers.0.type", "postgresql"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.all", "foreign_servers.0.version", "13"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.all", "foreign_servers.0.options.%", "0"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.0.name", "orders_server"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.0.foreign_data_wrapper", "postgres_fdw"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.0.owner", config.Username),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.0.options.%", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.0.options.host", "orders.local"),
+					resource.TestCheckResourceAttr("data.postgresql_foreign_servers.orders", "foreign_servers.0.options.dbname", "orders"),
+				),
+			},
+		},
+	})
+}
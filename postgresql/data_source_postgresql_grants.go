@@ -0,0 +1,185 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// grantsQueries returns, per object type, the privileges of a role ($1) on the objects of the type,
+// filtered by database or schema ($2). The default privileges apply when the ACL of an object is NULL.
+var grantsQueries = map[string]string{
+	"database": `
+	SELECT d.datname, a.privilege_type, a.is_grantable
+	FROM pg_catalog.pg_database d,
+	LATERAL pg_catalog.aclexplode(COALESCE(d.datacl, pg_catalog.acldefault('d', d.datdba))) a
+	WHERE a.grantee = $1 AND d.datname = $2
+	`,
+	"schema": `
+	SELECT n.nspname, a.privilege_type, a.is_grantable
+	FROM pg_catalog.pg_namespace n,
+	LATERAL pg_catalog.aclexplode(COALESCE(n.nspacl, pg_catalog.acldefault('n', n.nspowner))) a
+	WHERE a.grantee = $1 AND ($2::text = '' OR n.nspname = $2::text)
+	`,
+	"table": `
+	SELECT c.relname, a.privilege_type, a.is_grantable
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace,
+	LATERAL pg_catalog.aclexplode(COALESCE(c.relacl, pg_catalog.acldefault('r', c.relowner))) a
+	WHERE a.grantee = $1 AND n.nspname = $2 AND c.relkind IN ('r', 'p', 'v', 'm', 'f')
+	`,
+	"sequence": `
+	SELECT c.relname, a.privilege_type, a.is_grantable
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace,
+	LATERAL pg_catalog.aclexplode(COALESCE(c.relacl, pg_catalog.acldefault('s', c.relowner))) a
+	WHERE a.grantee = $1 AND n.nspname = $2 AND c.relkind = 'S'
+	`,
+	"function": `
+	SELECT p.proname || '(' || pg_catalog.pg_get_function_identity_arguments(p.oid) || ')', a.privilege_type, a.is_grantable
+	FROM pg_catalog.pg_proc p
+	JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace,
+	LATERAL pg_catalog.aclexplode(COALESCE(p.proacl, pg_catalog.acldefault('f', p.proowner))) a
+	WHERE a.grantee = $1 AND n.nspname = $2
+	`,
+}
+
+var grantsObjectTypes = []string{"database", "schema", "table", "sequence", "function"}
+
+func dataSourcePostgreSQLGrants() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLGrantsRead),
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role (or 'public') whose privileges are retrieved",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The database which will be queried for privileges",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The schema of the objects, required for the table, sequence and function object types",
+			},
+			"object_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(grantsObjectTypes, false),
+				Description:  "The PostgreSQL object type to retrieve the privileges on (one of: " + strings.Join(grantsObjectTypes, ", ") + ")",
+			},
+			"grants": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"privileges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"grantable_privileges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+				Description: "The objects on which the role has privileges, with these privileges",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLGrantsRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+	objectType := d.Get("object_type").(string)
+	role := d.Get("role").(string)
+
+	query, filter, err := getGrantsQuery(objectType, database, d.Get("schema").(string))
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	roleOID, err := getRoleOID(txn, role)
+	if err != nil {
+		return err
+	}
+
+	rows, err := txn.Query(query+" ORDER BY 1, 2", roleOID, filter)
+	if err != nil {
+		return fmt.Errorf("could not read privileges of role %s: %w", role, err)
+	}
+	defer rows.Close()
+
+	// The rows are ordered by object, so the privileges of an object are consecutive.
+	grants := make([]interface{}, 0)
+	var current map[string]interface{}
+	for rows.Next() {
+		var objectName, privilege string
+		var grantable bool
+
+		if err = rows.Scan(&objectName, &privilege, &grantable); err != nil {
+			return fmt.Errorf("could not scan privileges output for database: %w", err)
+		}
+
+		if current == nil || current["object_name"] != objectName {
+			current = map[string]interface{}{
+				"object_name":          objectName,
+				"privileges":           []string{},
+				"grantable_privileges": []string{},
+			}
+			grants = append(grants, current)
+		}
+
+		current["privileges"] = append(current["privileges"].([]string), privilege)
+		if grantable {
+			current["grantable_privileges"] = append(current["grantable_privileges"].([]string), privilege)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("grants", grants)
+	d.SetId(strings.Join([]string{role, database, d.Get("schema").(string), objectType}, "_"))
+
+	return nil
+}
+
+// getGrantsQuery returns the query to read the privileges on the object type with the value of its filter:
+// the database for the database type, otherwise the schema (which is optional for the schema type).
+func getGrantsQuery(objectType, database, schemaName string) (string, string, error) {
+	query, ok := grantsQueries[objectType]
+	if !ok {
+		return "", "", fmt.Errorf("unknown object type %s", objectType)
+	}
+
+	switch objectType {
+	case "database":
+		return query, database, nil
+	case "schema":
+		return query, schemaName, nil
+	}
+
+	if schemaName == "" {
+		return "", "", fmt.Errorf("parameter 'schema' is required for object type %s", objectType)
+	}
+
+	return query, schemaName, nil
+}
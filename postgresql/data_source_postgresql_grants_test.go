@@ -0,0 +1,107 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGetGrantsQuery(t *testing.T) {
+	cases := map[string]struct {
+		objectType     string
+		schema         string
+		expectedFilter string
+		expectedError  bool
+	}{
+		"database": {
+			objectType:     "database",
+			schema:         "public",
+			expectedFilter: "test_db",
+		},
+		"all schemas": {
+			objectType:     "schema",
+			expectedFilter: "",
+		},
+		"table": {
+			objectType:     "table",
+			schema:         "public",
+			expectedFilter: "public",
+		},
+		"table without schema": {
+			objectType:    "table",
+			expectedError: true,
+		},
+		"unknown type": {
+			objectType:    "foreign_server",
+			schema:        "public",
+			expectedError: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, filter, err := getGrantsQuery(c.objectType, "test_db", c.schema)
+			if c.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if filter != c.expectedFilter {
+				t.Fatalf("expected filter %q, got %q", c.expectedFilter, filter)
+			}
+		})
+	}
+}
+
+func TestAccPostgresqlDataSourceGrants(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer)")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.customers (id integer)")
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT SELECT, INSERT ON test_schema.orders TO %s", roleName))
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT SELECT ON test_schema.customers TO %s WITH GRANT OPTION", roleName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_grants" "tables" {
+					role        = "%[2]s"
+					database    = "%[1]s"
+					schema      = "test_schema"
+					object_type = "table"
+				}
+
+				data "postgresql_grants" "schemas" {
+					role        = "%[2]s"
+					database    = "%[1]s"
+					object_type = "schema"
+				}`, dbName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.0.object_name", "customers"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.0.privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.0.grantable_privileges.0", "SELECT"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.1.object_name", "orders"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.1.privileges.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.1.privileges.0", "INSERT"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.1.privileges.1", "SELECT"),
+					resource.TestCheckResourceAttr("data.postgresql_grants.tables", "grants.1.grantable_privileges.#", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.postgresql_grants.schemas", "grants.*", map[string]string{"object_name": "test_schema"}),
+				),
+			},
+		},
+	})
+}
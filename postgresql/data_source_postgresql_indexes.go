@@ -0,0 +1,180 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// The indexes of the system schemas are excluded.
+	indexQuery = `
+	SELECT ic.relname, n.nspname, tc.relname, am.amname, i.indisunique, i.indisprimary,
+		pg_catalog.pg_get_indexdef(i.indexrelid)
+	FROM pg_catalog.pg_index i
+	JOIN pg_catalog.pg_class ic ON ic.oid = i.indexrelid
+	JOIN pg_catalog.pg_class tc ON tc.oid = i.indrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = ic.relnamespace
+	JOIN pg_catalog.pg_am am ON am.oid = ic.relam
+	WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+	`
+	indexPatternMatchingTarget = "ic.relname"
+	indexSchemaKeyword         = "n.nspname"
+	indexTableKeyword          = "tc.relname"
+)
+
+func dataSourcePostgreSQLIndexes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLIndexesRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for index names",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) which will be queried for index names. Queries all schemas in the database by default",
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The name(s) of the tables whose indexes will be queried. Queries the indexes of all tables by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against index names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against index names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against index names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against index names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"indexes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"table_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"unique": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"primary": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"definition": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL indexes retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLIndexesRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := indexQuery
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, indexSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, indexTableKeyword, d.Get("tables").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, indexPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY n.nspname, tc.relname, ic.relname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	indexes := make([]interface{}, 0)
+	for rows.Next() {
+		var objectName, schemaName, tableName, method, definition string
+		var unique, primary bool
+
+		if err = rows.Scan(&objectName, &schemaName, &tableName, &method, &unique, &primary, &definition); err != nil {
+			return fmt.Errorf("could not scan index output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["object_name"] = objectName
+		result["schema_name"] = schemaName
+		result["table_name"] = tableName
+		result["method"] = method
+		result["unique"] = unique
+		result["primary"] = primary
+		result["definition"] = definition
+		indexes = append(indexes, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("indexes", indexes)
+	d.SetId(generateDataSourceIndexesID(d, database))
+
+	return nil
+}
+
+func generateDataSourceIndexesID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("tables").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,60 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceIndexes(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.users (id integer PRIMARY KEY, email text, tags text[])")
+	dbExecute(t, config.connStr(dbName), "CREATE UNIQUE INDEX users_email_idx ON test_schema.users (lower(email))")
+	dbExecute(t, config.connStr(dbName), "CREATE INDEX users_tags_idx ON test_schema.users USING gin (tags)")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE dev_schema.events (id integer PRIMARY KEY)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_indexes" "all" {
+					database = "%[1]s"
+				}
+
+				data "postgresql_indexes" "users" {
+					database = "%[1]s"
+					schemas  = ["test_schema"]
+					tables   = ["users"]
+				}
+
+				data "postgresql_indexes" "like_idx" {
+					database          = "%[1]s"
+					like_any_patterns = ["%%_idx"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_indexes.all", "indexes.#", "4"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.#", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.0.object_name", "users_email_idx"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.0.table_name", "users"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.0.method", "btree"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.0.unique", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.0.primary", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.1.object_name", "users_pkey"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.1.primary", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.2.object_name", "users_tags_idx"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.users", "indexes.2.method", "gin"),
+					resource.TestCheckResourceAttr("data.postgresql_indexes.like_idx", "indexes.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,207 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	// The permissive column is given as a parameter, as restrictive policies only exist since PostgreSQL 10.
+	policyQuery = `
+	SELECT pol.polname, n.nspname, c.relname,
+		CASE pol.polcmd WHEN 'r' THEN 'SELECT' WHEN 'a' THEN 'INSERT' WHEN 'w' THEN 'UPDATE' WHEN 'd' THEN 'DELETE' ELSE 'ALL' END,
+		%s,
+		ARRAY(
+			SELECT CASE WHEN r.oid = 0 THEN 'public' ELSE pg_catalog.pg_get_userbyid(r.oid) END
+			FROM pg_catalog.unnest(pol.polroles) AS r(oid)
+			ORDER BY 1
+		),
+		pg_catalog.pg_get_expr(pol.polqual, pol.polrelid),
+		pg_catalog.pg_get_expr(pol.polwithcheck, pol.polrelid)
+	FROM pg_catalog.pg_policy pol
+	JOIN pg_catalog.pg_class c ON c.oid = pol.polrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	`
+	policyPatternMatchingTarget = "pol.polname"
+	policySchemaKeyword         = "n.nspname"
+	policyTableKeyword          = "c.relname"
+)
+
+func dataSourcePostgreSQLPolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLPoliciesRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for policy names",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) of the tables which will be queried for policy names. Queries all schemas in the database by default",
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The name(s) of the tables whose policies will be queried. Queries the policies of all tables by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against policy names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against policy names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against policy names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against policy names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"table_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"permissive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"using": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"with_check": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL row-level security policies retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLPoliciesRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureRLS) {
+		return fmt.Errorf(
+			"postgresql_policies data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	permissiveColumn := "true"
+	if db.featureSupported(featureRestrictivePolicy) {
+		permissiveColumn = "pol.polpermissive"
+	}
+
+	query := fmt.Sprintf(policyQuery, permissiveColumn)
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, policySchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, policyTableKeyword, d.Get("tables").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, policyPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY n.nspname, c.relname, pol.polname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	policies := make([]interface{}, 0)
+	for rows.Next() {
+		var objectName, schemaName, tableName, command string
+		var permissive bool
+		var roles []string
+		var using, withCheck sql.NullString
+
+		if err = rows.Scan(&objectName, &schemaName, &tableName, &command, &permissive, pq.Array(&roles), &using, &withCheck); err != nil {
+			return fmt.Errorf("could not scan policy output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["object_name"] = objectName
+		result["schema_name"] = schemaName
+		result["table_name"] = tableName
+		result["command"] = command
+		result["permissive"] = permissive
+		result["roles"] = roles
+		result["using"] = using.String
+		result["with_check"] = withCheck.String
+		policies = append(policies, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("policies", policies)
+	d.SetId(generateDataSourcePoliciesID(d, database))
+
+	return nil
+}
+
+func generateDataSourcePoliciesID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("tables").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourcePolicies(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer, tenant_id integer)")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.customers (id integer, tenant_id integer)")
+	dbExecute(t, config.connStr(dbName), "ALTER TABLE test_schema.orders ENABLE ROW LEVEL SECURITY")
+	dbExecute(t, config.connStr(dbName), "ALTER TABLE test_schema.customers ENABLE ROW LEVEL SECURITY")
+	dbExecute(t, config.connStr(dbName), "CREATE POLICY tenant_isolation ON test_schema.orders USING (tenant_id = 1)")
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("CREATE POLICY tenant_insert ON test_schema.customers FOR INSERT TO %s WITH CHECK (tenant_id = 1)", roleName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureRLS)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_policies" "all" {
+					database = "%[1]s"
+				}
+
+				data "postgresql_policies" "orders" {
+					database = "%[1]s"
+					schemas  = ["test_schema"]
+					tables   = ["orders"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.0.object_name", "tenant_insert"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.0.command", "INSERT"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.0.roles.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.0.roles.0", roleName),
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.0.using", ""),
+					resource.TestCheckResourceAttr("data.postgresql_policies.all", "policies.0.with_check", "(tenant_id = 1)"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.object_name", "tenant_isolation"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.schema_name", "test_schema"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.table_name", "orders"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.command", "ALL"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.permissive", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.roles.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.roles.0", "public"),
+					resource.TestCheckResourceAttr("data.postgresql_policies.orders", "policies.0.using", "(tenant_id = 1)"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	publicationQuery = `
+	SELECT p.pubname, pg_catalog.pg_get_userbyid(p.pubowner), p.puballtables,
+		ARRAY(
+			SELECT pt.schemaname || '.' || pt.tablename FROM pg_catalog.pg_publication_tables pt
+			WHERE pt.pubname = p.pubname ORDER BY 1
+		)
+	FROM pg_catalog.pg_publication p
+	`
+	publicationPatternMatchingTarget = "p.pubname"
+)
+
+func dataSourcePostgreSQLPublications() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLPublicationsRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for publications",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against publication names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against publication names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against publication names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against publication names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"publications": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"all_tables": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"tables": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+				Description: "The list of PostgreSQL publications retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLPublicationsRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePublication) {
+		return fmt.Errorf(
+			"postgresql_publications data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := publicationQuery
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyOptionalPatternMatchingToQuery(query, publicationPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY p.pubname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	publications := make([]interface{}, 0)
+	for rows.Next() {
+		var name, owner string
+		var allTables bool
+		var tables []string
+
+		if err = rows.Scan(&name, &owner, &allTables, pq.Array(&tables)); err != nil {
+			return fmt.Errorf("could not scan publication output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["owner"] = owner
+		result["all_tables"] = allTables
+		result["tables"] = tables
+		publications = append(publications, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("publications", publications)
+	d.SetId(generateDataSourcePublicationsID(d, database))
+
+	return nil
+}
+
+func generateDataSourcePublicationsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
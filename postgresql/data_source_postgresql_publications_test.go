@@ -0,0 +1,51 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourcePublications(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer PRIMARY KEY)")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.customers (id integer PRIMARY KEY)")
+	dbExecute(t, config.connStr(dbName), "CREATE PUBLICATION test_pub FOR TABLE test_schema.orders, test_schema.customers")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePublication)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_publications" "test" {
+					database = "%[1]s"
+				}
+
+				data "postgresql_publications" "no_match" {
+					database          = "%[1]s"
+					like_any_patterns = ["other%%"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_publications.test", "publications.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_publications.test", "publications.0.name", "test_pub"),
+					resource.TestCheckResourceAttr("data.postgresql_publications.test", "publications.0.all_tables", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_publications.test", "publications.0.tables.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_publications.test", "publications.0.tables.0", "test_schema.customers"),
+					resource.TestCheckResourceAttr("data.postgresql_publications.test", "publications.0.tables.1", "test_schema.orders"),
+					resource.TestCheckResourceAttr("data.postgresql_publications.no_match", "publications.#", "0"),
+				),
+			},
+		},
+	})
+}
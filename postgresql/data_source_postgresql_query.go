@@ -0,0 +1,145 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	queryDatabaseAttr         = "database"
+	queryQueryAttr            = "query"
+	queryArgsAttr             = "args"
+	queryStatementTimeoutAttr = "statement_timeout"
+	queryColumnsAttr          = "columns"
+	queryRowsAttr             = "rows"
+)
+
+func dataSourcePostgreSQLQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLQueryRead),
+		Schema: map[string]*schema.Schema{
+			queryDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The PostgreSQL database in which the query is run. Defaults to the provider database",
+			},
+			queryQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SELECT query to run, in a read-only transaction",
+			},
+			queryArgsAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The values of the parameters ($1, $2, ...) of the query",
+			},
+			queryStatementTimeoutAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The statement_timeout of the query in milliseconds, 0 disables the timeout",
+			},
+			queryColumnsAttr: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the columns returned by the query",
+			},
+			queryRowsAttr: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+				Description: "The rows returned by the query, as maps of the column names to the values in their text representation (NULL values are omitted)",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLQueryRead(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// The transaction is read-only so the query cannot modify anything, it is rolled back anyway.
+	if _, err := txn.Exec("SET TRANSACTION READ ONLY"); err != nil {
+		return fmt.Errorf("could not set the transaction read-only: %w", err)
+	}
+
+	statementTimeout := d.Get(queryStatementTimeoutAttr).(int)
+	if _, err := txn.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", statementTimeout)); err != nil {
+		return fmt.Errorf("could not set statement_timeout: %w", err)
+	}
+
+	args := []interface{}{}
+	for _, arg := range d.Get(queryArgsAttr).([]interface{}) {
+		args = append(args, arg.(string))
+	}
+
+	rows, err := txn.Query(d.Get(queryQueryAttr).(string), args...)
+	if err != nil {
+		return fmt.Errorf("could not run query: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("could not read query columns: %w", err)
+	}
+
+	result := make([]interface{}, 0)
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("could not scan query output: %w", err)
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if values[i].Valid {
+				row[column] = values[i].String
+			}
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("could not read query output: %w", err)
+	}
+
+	d.Set(queryDatabaseAttr, database)
+	d.Set(queryColumnsAttr, columns)
+	d.Set(queryRowsAttr, result)
+	d.SetId(generateDataSourceQueryID(d, database))
+
+	return nil
+}
+
+func generateDataSourceQueryID(d *schema.ResourceData, databaseName string) string {
+	h := fnv.New64a()
+	h.Write([]byte(d.Get(queryQueryAttr).(string)))
+	for _, arg := range d.Get(queryArgsAttr).([]interface{}) {
+		h.Write([]byte{0})
+		h.Write([]byte(arg.(string)))
+	}
+
+	return strings.Join([]string{databaseName, fmt.Sprintf("%x", h.Sum64())}, "_")
+}
@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceQuery(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE flags (name text PRIMARY KEY, enabled boolean, description text)")
+	dbExecute(t, config.connStr(dbName), "INSERT INTO flags VALUES ('new_ui', true, NULL), ('beta', false, 'Beta features')")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_query" "flags" {
+					database = "%[1]s"
+					query    = "SELECT name, enabled, description FROM flags WHERE name LIKE $1 ORDER BY name"
+					args     = ["%%"]
+				}
+
+				data "postgresql_query" "exists" {
+					database = "%[1]s"
+					query    = "SELECT to_regclass('public.flags') IS NOT NULL AS exists"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_query.flags", "columns.#", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_query.flags", "columns.1", "enabled"),
+					resource.TestCheckResourceAttr("data.postgresql_query.flags", "rows.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_query.flags", "rows.0.name", "beta"),
+					resource.TestCheckResourceAttr("data.postgresql_query.flags", "rows.0.enabled", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_query.flags", "rows.0.description", "Beta features"),
+					resource.TestCheckNoResourceAttr("data.postgresql_query.flags", "rows.1.description"),
+					resource.TestCheckResourceAttr("data.postgresql_query.exists", "rows.0.exists", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_query" "write" {
+					database = "%s"
+					query    = "DELETE FROM flags RETURNING name"
+				}`, dbName),
+				ExpectError: regexp.MustCompile("read-only transaction"),
+			},
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_query" "timeout" {
+					database          = "%s"
+					query             = "SELECT pg_sleep(2)"
+					statement_timeout = 100
+				}`, dbName),
+				ExpectError: regexp.MustCompile("statement timeout"),
+			},
+		},
+	})
+}
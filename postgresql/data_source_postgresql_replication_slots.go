@@ -0,0 +1,164 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	replicationSlotQuery = `
+	SELECT slot_name, slot_type, plugin, database, active, restart_lsn::text
+	FROM pg_catalog.pg_replication_slots
+	`
+	replicationSlotPatternMatchingTarget = "slot_name"
+	replicationSlotDatabaseKeyword       = "database"
+	replicationSlotTypeKeyword           = "slot_type"
+)
+
+func dataSourcePostgreSQLReplicationSlots() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLReplicationSlotsRead),
+		Schema: map[string]*schema.Schema{
+			"databases": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The database(s) of the logical replication slots to retrieve. Retrieves the slots of all the databases by default",
+			},
+			"slot_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The type(s) of the replication slots to retrieve ('logical' or 'physical'). Retrieves all types by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against slot names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against slot names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against slot names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against slot names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"replication_slots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slot_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"plugin": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"database": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"restart_lsn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL replication slots retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLReplicationSlotsRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureReplication) {
+		return fmt.Errorf(
+			"postgresql_replication_slots data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	query := replicationSlotQuery
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, replicationSlotDatabaseKeyword, d.Get("databases").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, replicationSlotTypeKeyword, d.Get("slot_types").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, replicationSlotPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY slot_name", query)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	slots := make([]interface{}, 0)
+	for rows.Next() {
+		var name, slotType string
+		var plugin, database, restartLSN sql.NullString
+		var active bool
+
+		if err = rows.Scan(&name, &slotType, &plugin, &database, &active, &restartLSN); err != nil {
+			return fmt.Errorf("could not scan replication slot output: %w", err)
+		}
+
+		// The plugin and database are NULL for physical slots, and restart_lsn is NULL for a slot never used.
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["slot_type"] = slotType
+		result["plugin"] = plugin.String
+		result["database"] = database.String
+		result["active"] = active
+		result["restart_lsn"] = restartLSN.String
+		slots = append(slots, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("replication_slots", slots)
+	d.SetId(generateDataSourceReplicationSlotsID(d))
+
+	return nil
+}
+
+func generateDataSourceReplicationSlotsID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		"replication_slots",
+		generatePatternArrayString(d.Get("databases").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("slot_types").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
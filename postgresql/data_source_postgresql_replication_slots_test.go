@@ -0,0 +1,50 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceReplicationSlots(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, false, false)
+	defer teardown()
+
+	config := getTestConfig(t)
+	slotName := fmt.Sprintf("tf_tests_slot_%s", dbSuffix)
+	dbExecute(t, config.connStr("postgres"), "SELECT pg_create_physical_replication_slot($1)", slotName)
+	defer dbExecute(t, config.connStr("postgres"), "SELECT pg_drop_replication_slot($1)", slotName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureReplication)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_replication_slots" "test" {
+					like_any_patterns = ["%s"]
+				}
+
+				data "postgresql_replication_slots" "logical" {
+					slot_types        = ["logical"]
+					like_any_patterns = ["%[1]s"]
+				}`, slotName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_replication_slots.test", "replication_slots.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_replication_slots.test", "replication_slots.0.name", slotName),
+					resource.TestCheckResourceAttr("data.postgresql_replication_slots.test", "replication_slots.0.slot_type", "physical"),
+					resource.TestCheckResourceAttr("data.postgresql_replication_slots.test", "replication_slots.0.plugin", ""),
+					resource.TestCheckResourceAttr("data.postgresql_replication_slots.test", "replication_slots.0.active", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_replication_slots.logical", "replication_slots.#", "0"),
+				),
+			},
+		},
+	})
+}
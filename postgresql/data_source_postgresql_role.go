@@ -0,0 +1,81 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+func dataSourcePostgreSQLRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLRoleRead),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role",
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If the role exists",
+			},
+			"login": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If the role can log in",
+			},
+			"superuser": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If the role is a superuser",
+			},
+			"valid_until": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time after which the password of the role is no longer valid, or 'infinity'",
+			},
+			"member_of": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The roles this role is directly a member of",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	roleName := d.Get("name").(string)
+
+	var login, superuser bool
+	var validUntil string
+	var memberOf []string
+	exists := true
+
+	query := `SELECT r.rolcanlogin, r.rolsuper, COALESCE(r.rolvaliduntil::TEXT, 'infinity'), ` +
+		`ARRAY(` +
+		`SELECT b.rolname FROM pg_catalog.pg_auth_members m ` +
+		`JOIN pg_catalog.pg_roles b ON b.oid = m.roleid ` +
+		`WHERE m.member = r.oid ORDER BY b.rolname` +
+		`) ` +
+		`FROM pg_catalog.pg_roles r WHERE r.rolname = $1`
+	err := db.QueryRow(query, roleName).Scan(&login, &superuser, &validUntil, pq.Array(&memberOf))
+	switch {
+	case err == sql.ErrNoRows:
+		exists = false
+	case err != nil:
+		return fmt.Errorf("Error reading role %s: %w", roleName, err)
+	}
+
+	d.Set("exists", exists)
+	d.Set("login", login)
+	d.Set("superuser", superuser)
+	d.Set("valid_until", validUntil)
+	d.Set("member_of", memberOf)
+	d.SetId(roleName)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceRole(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, false, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	_, roleName := getTestDBNames(dbSuffix)
+	groupName := fmt.Sprintf("%s_group", roleName)
+
+	dbExecute(t, config.connStr("postgres"), fmt.Sprintf("CREATE ROLE %s", groupName))
+	defer dbExecute(t, config.connStr("postgres"), fmt.Sprintf("DROP ROLE IF EXISTS %s", groupName))
+	dbExecute(t, config.connStr("postgres"), fmt.Sprintf("GRANT %s TO %s", groupName, roleName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_role" "test" {
+					name = "%s"
+				}
+
+				data "postgresql_role" "missing" {
+					name = "%[1]s_missing"
+				}`, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_role.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_role.test", "login", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_role.test", "superuser", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_role.test", "valid_until", "infinity"),
+					resource.TestCheckResourceAttr("data.postgresql_role.test", "member_of.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_role.test", "member_of.0", groupName),
+					resource.TestCheckResourceAttr("data.postgresql_role.missing", "exists", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,157 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+var roleQueries = map[string]string{
+	"query_include_system_roles": `
+	SELECT r.rolname, r.rolsuper, r.rolcanlogin,
+		ARRAY(
+			SELECT b.rolname FROM pg_catalog.pg_auth_members m
+			JOIN pg_catalog.pg_roles b ON b.oid = m.roleid
+			WHERE m.member = r.oid ORDER BY b.rolname
+		)
+	FROM pg_catalog.pg_roles r
+	`,
+	"query_exclude_system_roles": `
+	SELECT r.rolname, r.rolsuper, r.rolcanlogin,
+		ARRAY(
+			SELECT b.rolname FROM pg_catalog.pg_auth_members m
+			JOIN pg_catalog.pg_roles b ON b.oid = m.roleid
+			WHERE m.member = r.oid ORDER BY b.rolname
+		)
+	FROM pg_catalog.pg_roles r
+	WHERE r.rolname NOT LIKE 'pg\_%'
+	`,
+}
+
+const rolePatternMatchingTarget = "r.rolname"
+
+func dataSourcePostgreSQLRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLRolesRead),
+		Schema: map[string]*schema.Schema{
+			"include_system_roles": {
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+				Description: "Determines whether to include system roles (pg_ prefix)",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against role names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against role names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against role names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against role names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"roles": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"superuser": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"login": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"member_of": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+				Description: "The list of PostgreSQL roles retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLRolesRead(db *DBConnection, d *schema.ResourceData) error {
+	var query string
+	var queryConcatKeyword string
+	if d.Get("include_system_roles").(bool) {
+		query = roleQueries["query_include_system_roles"]
+		queryConcatKeyword = queryConcatKeywordWhere
+	} else {
+		query = roleQueries["query_exclude_system_roles"]
+		queryConcatKeyword = queryConcatKeywordAnd
+	}
+
+	query = applyOptionalPatternMatchingToQuery(query, rolePatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY r.rolname", query)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	roles := make([]interface{}, 0)
+	for rows.Next() {
+		var name string
+		var superuser, login bool
+		var memberOf []string
+
+		if err = rows.Scan(&name, &superuser, &login, pq.Array(&memberOf)); err != nil {
+			return fmt.Errorf("could not scan role output: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["superuser"] = superuser
+		result["login"] = login
+		result["member_of"] = memberOf
+		roles = append(roles, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("roles", roles)
+	d.SetId(generateDataSourceRolesID(d))
+
+	return nil
+}
+
+func generateDataSourceRolesID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		"roles", strconv.FormatBool(d.Get("include_system_roles").(bool)),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
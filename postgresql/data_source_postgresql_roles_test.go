@@ -0,0 +1,55 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceRoles(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, false, true)
+	defer teardown()
+
+	config := getTestConfig(t)
+	_, roleName := getTestDBNames(dbSuffix)
+	memberName := fmt.Sprintf("%s_member", roleName)
+
+	dbExecute(t, config.connStr("postgres"), fmt.Sprintf("CREATE ROLE %s LOGIN IN ROLE %s", memberName, roleName))
+	defer dbExecute(t, config.connStr("postgres"), fmt.Sprintf("DROP ROLE IF EXISTS %s", memberName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_roles" "test" {
+					like_any_patterns = ["%[1]s%%"]
+				}
+
+				data "postgresql_roles" "member" {
+					regex_pattern = "_member$"
+				}
+
+				data "postgresql_roles" "system" {
+					include_system_roles = true
+					like_any_patterns    = ["pg\\_%%"]
+				}`, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.0.name", roleName),
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.1.name", memberName),
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.1.login", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.1.superuser", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.1.member_of.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_roles.test", "roles.1.member_of.0", roleName),
+					resource.TestCheckTypeSetElemNestedAttrs("data.postgresql_roles.member", "roles.*", map[string]string{"name": memberName}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.postgresql_roles.system", "roles.*", map[string]string{"name": "pg_monitor"}),
+				),
+			},
+		},
+	})
+}
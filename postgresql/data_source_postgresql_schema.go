@@ -0,0 +1,68 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgreSQLSchema() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLSchemaRead),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the schema",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The database where the schema is located",
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If the schema exists in the database",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The owner of the schema, empty if it does not exist",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLSchemaRead(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	schemaName := d.Get("name").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var owner string
+	exists := true
+	err = txn.QueryRow(
+		"SELECT pg_catalog.pg_get_userbyid(nspowner) FROM pg_catalog.pg_namespace WHERE nspname = $1", schemaName,
+	).Scan(&owner)
+	switch {
+	case err == sql.ErrNoRows:
+		exists = false
+	case err != nil:
+		return fmt.Errorf("Error reading schema %s: %w", schemaName, err)
+	}
+
+	d.Set("database", database)
+	d.Set("exists", exists)
+	d.Set("owner", owner)
+	d.SetId(strings.Join([]string{database, schemaName}, "."))
+
+	return nil
+}
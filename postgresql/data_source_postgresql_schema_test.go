@@ -0,0 +1,44 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceSchema(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("CREATE SCHEMA owned_schema AUTHORIZATION %s", roleName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_schema" "test" {
+					name     = "owned_schema"
+					database = "%[1]s"
+				}
+
+				data "postgresql_schema" "missing" {
+					name     = "missing_schema"
+					database = "%[1]s"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_schema.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_schema.test", "owner", roleName),
+					resource.TestCheckResourceAttr("data.postgresql_schema.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_schema.missing", "owner", ""),
+				),
+			},
+		},
+	})
+}
@@ -10,11 +10,11 @@ import (
 
 var schemaQueries = map[string]string{
 	"query_include_system_schemas": `
-	SELECT schema_name
+	SELECT schema_name, schema_owner
 	FROM information_schema.schemata
 	`,
 	"query_exclude_system_schemas": `
-	SELECT schema_name
+	SELECT schema_name, schema_owner
 	FROM information_schema.schemata
 	WHERE schema_name NOT LIKE 'pg_%'
 	AND schema_name <> 'information_schema'
@@ -25,7 +25,7 @@ const schemaPatternMatchingTarget = "schema_name"
 
 func dataSourcePostgreSQLDatabaseSchemas() *schema.Resource {
 	return &schema.Resource{
-		Read: PGResourceFunc(dataSourcePostgreSQLSchemasRead),
+		ReadContext: PGReadFunc(dataSourcePostgreSQLSchemasRead),
 		Schema: map[string]*schema.Schema{
 			"database": {
 				Type:        schema.TypeString,
@@ -72,6 +72,12 @@ func dataSourcePostgreSQLDatabaseSchemas() *schema.Resource {
 				Set:         schema.HashString,
 				Description: "The list of PostgreSQL schemas retrieved by this data source",
 			},
+			"schema_owners": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The owners of the PostgreSQL schemas retrieved by this data source, keyed by schema name",
+			},
 		},
 	}
 }
@@ -106,16 +112,19 @@ func dataSourcePostgreSQLSchemasRead(db *DBConnection, d *schema.ResourceData) e
 	defer rows.Close()
 
 	schemas := []string{}
+	owners := map[string]interface{}{}
 	for rows.Next() {
-		var schema string
+		var schema, owner string
 
-		if err = rows.Scan(&schema); err != nil {
+		if err = rows.Scan(&schema, &owner); err != nil {
 			return fmt.Errorf("could not scan schema name for database: %w", err)
 		}
 		schemas = append(schemas, schema)
+		owners[schema] = owner
 	}
 
 	d.Set("schemas", stringSliceToSet(schemas))
+	d.Set("schema_owners", owners)
 	d.SetId(generateDataSourceSchemasID(d, database))
 
 	return nil
@@ -34,6 +34,8 @@ func TestAccPostgresqlDataSourceSchemas(t *testing.T) {
 				Config: testAccPostgresqlDataSourceSchemasDatabaseConfig,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.postgresql_schemas.system_false", "schemas.#", "8"),
+					resource.TestCheckResourceAttr("data.postgresql_schemas.system_false", "schema_owners.%", "8"),
+					resource.TestCheckResourceAttrSet("data.postgresql_schemas.system_false", "schema_owners.test_schema1"),
 					resource.TestCheckResourceAttr("data.postgresql_schemas.no_match", "schemas.#", "0"),
 					resource.TestCheckResourceAttr("data.postgresql_schemas.system_false_like_exp", "schemas.#", "2"),
 					resource.TestCheckTypeSetElemAttr("data.postgresql_schemas.system_false_like_exp", "schemas.*", "test_exp"),
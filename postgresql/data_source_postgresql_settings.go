@@ -0,0 +1,170 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// The pending restart column is given as a parameter, as pending_restart only exists since PostgreSQL 9.5.
+	settingQuery = `
+	SELECT name, setting, unit, source, %s
+	FROM pg_catalog.pg_settings
+	`
+	settingPatternMatchingTarget = "name"
+	settingNameKeyword           = "name"
+)
+
+func dataSourcePostgreSQLSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLSettingsRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The PostgreSQL database whose settings are retrieved, as the settings can be overridden per database. Defaults to the database of the provider",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The name(s) of the settings to retrieve. Retrieves all the settings by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against setting names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against setting names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against setting names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against setting names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"settings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"unit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pending_restart": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL settings retrieved by this data source",
+			},
+			"values": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The values of the PostgreSQL settings retrieved by this data source, keyed by setting name",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLSettingsRead(db *DBConnection, d *schema.ResourceData) error {
+	pendingRestartColumn := "false"
+	if db.featureSupported(featureAlterSystem) {
+		pendingRestartColumn = "pending_restart"
+	}
+
+	query := fmt.Sprintf(settingQuery, pendingRestartColumn)
+	queryConcatKeyword := queryConcatKeywordWhere
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, settingNameKeyword, d.Get("names").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, settingPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY name", query)
+
+	database := getDatabase(d, db.client.databaseName)
+	conn, err := connectToDatabase(db.client, database)
+	if err != nil {
+		return err
+	}
+
+	rows, err := conn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	settings := make([]interface{}, 0)
+	values := make(map[string]interface{})
+	for rows.Next() {
+		var name, value, source string
+		var unit sql.NullString
+		var pendingRestart bool
+
+		if err = rows.Scan(&name, &value, &unit, &source, &pendingRestart); err != nil {
+			return fmt.Errorf("could not scan setting output: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["value"] = value
+		result["unit"] = unit.String
+		result["source"] = source
+		result["pending_restart"] = pendingRestart
+		settings = append(settings, result)
+		values[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("settings", settings)
+	d.Set("values", values)
+	d.Set("database", database)
+	d.SetId(generateDataSourceSettingsID(d, database))
+
+	return nil
+}
+
+func generateDataSourceSettingsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		"settings",
+		databaseName,
+		generatePatternArrayString(d.Get("names").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,38 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceSettings(t *testing.T) {
+	skipIfNotAcc(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "postgresql_settings" "names" {
+					names = ["max_connections", "shared_buffers"]
+				}
+
+				data "postgresql_settings" "log_patterns" {
+					like_any_patterns = ["log\\_connections", "log\\_disconnections"]
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_settings.names", "settings.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_settings.names", "settings.0.name", "max_connections"),
+					resource.TestCheckResourceAttr("data.postgresql_settings.names", "settings.0.unit", ""),
+					resource.TestCheckResourceAttr("data.postgresql_settings.names", "settings.1.name", "shared_buffers"),
+					resource.TestCheckResourceAttr("data.postgresql_settings.names", "settings.1.unit", "8kB"),
+					resource.TestCheckResourceAttrSet("data.postgresql_settings.names", "settings.1.source"),
+					resource.TestCheckResourceAttrSet("data.postgresql_settings.names", "values.max_connections"),
+					resource.TestCheckResourceAttr("data.postgresql_settings.log_patterns", "values.%", "2"),
+				),
+			},
+		},
+	})
+}
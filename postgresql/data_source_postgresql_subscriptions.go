@@ -0,0 +1,157 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	// subconninfo is deliberately not selected: it may contain a password and it is only readable by superusers.
+	subscriptionQuery = `
+	SELECT s.subname, pg_catalog.pg_get_userbyid(s.subowner), s.subenabled, s.subpublication, s.subslotname
+	FROM pg_catalog.pg_subscription s
+	WHERE s.subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = pg_catalog.current_database())
+	`
+	subscriptionPatternMatchingTarget = "s.subname"
+)
+
+func dataSourcePostgreSQLSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLSubscriptionsRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for subscriptions",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against subscription names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against subscription names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against subscription names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against subscription names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"subscriptions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"publications": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"slot_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL subscriptions retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLSubscriptionsRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSubscription) {
+		return fmt.Errorf(
+			"postgresql_subscriptions data source is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := subscriptionQuery
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyOptionalPatternMatchingToQuery(query, subscriptionPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY s.subname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]interface{}, 0)
+	for rows.Next() {
+		var name, owner string
+		var enabled bool
+		var publications []string
+		var slotName sql.NullString
+
+		if err = rows.Scan(&name, &owner, &enabled, pq.Array(&publications), &slotName); err != nil {
+			return fmt.Errorf("could not scan subscription output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["owner"] = owner
+		result["enabled"] = enabled
+		result["publications"] = publications
+		result["slot_name"] = slotName.String
+		subscriptions = append(subscriptions, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("subscriptions", subscriptions)
+	d.SetId(generateDataSourceSubscriptionsID(d, database))
+
+	return nil
+}
+
+func generateDataSourceSubscriptionsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceSubscriptions(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	pubDBSuffix, pubTeardown := setupTestDatabase(t, true, false)
+	defer pubTeardown()
+	subDBSuffix, subTeardown := setupTestDatabase(t, true, false)
+	defer subTeardown()
+
+	pubDBName, _ := getTestDBNames(pubDBSuffix)
+	subDBName, _ := getTestDBNames(subDBSuffix)
+
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(pubDBName), "CREATE PUBLICATION test_publication")
+
+	// The subscription is created without connecting to the publisher, so it is disabled and has no slot.
+	connInfo := fmt.Sprintf(
+		"host=%s port=%d dbname=%s user=%s password=%s",
+		config.Host, config.Port, pubDBName, config.Username, config.Password,
+	)
+	dbExecute(t, config.connStr(subDBName), fmt.Sprintf(
+		"CREATE SUBSCRIPTION test_subscription CONNECTION '%s' PUBLICATION test_publication WITH (connect = false, slot_name = NONE)",
+		pqQuoteLiteral(connInfo),
+	))
+	defer dbExecute(t, config.connStr(subDBName), "DROP SUBSCRIPTION test_subscription")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureSubscription)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_subscriptions" "test" {
+					database = "%s"
+				}
+
+				data "postgresql_subscriptions" "other_database" {
+					database = "%s"
+				}`, subDBName, pubDBName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.test", "subscriptions.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.test", "subscriptions.0.name", "test_subscription"),
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.test", "subscriptions.0.enabled", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.test", "subscriptions.0.slot_name", ""),
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.test", "subscriptions.0.publications.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.test", "subscriptions.0.publications.0", "test_publication"),
+					resource.TestCheckResourceAttr("data.postgresql_subscriptions.other_database", "subscriptions.#", "0"),
+				),
+			},
+		},
+	})
+}
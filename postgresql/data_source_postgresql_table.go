@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePostgreSQLTable() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLTableRead),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the table",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				Description: "The schema where the table is located",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The database where the table is located",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The owner of the table",
+			},
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"not_null": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The columns of the table, in their order",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLTableRead(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	tableSchema := d.Get("schema").(string)
+	tableName := d.Get("name").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var tableOID int
+	var owner string
+	query := `SELECT c.oid, pg_catalog.pg_get_userbyid(c.relowner) ` +
+		`FROM pg_catalog.pg_class c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p', 'v', 'm', 'f')`
+	err = txn.QueryRow(query, tableSchema, tableName).Scan(&tableOID, &owner)
+	switch {
+	case err == sql.ErrNoRows:
+		return fmt.Errorf("table %s.%s not found in database %s", tableSchema, tableName, database)
+	case err != nil:
+		return fmt.Errorf("Error reading table %s.%s: %w", tableSchema, tableName, err)
+	}
+
+	query = `SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod), a.attnotnull, ` +
+		`COALESCE(pg_catalog.pg_get_expr(ad.adbin, ad.adrelid), '') ` +
+		`FROM pg_catalog.pg_attribute a ` +
+		`LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum ` +
+		`WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped ` +
+		`ORDER BY a.attnum`
+	rows, err := txn.Query(query, tableOID)
+	if err != nil {
+		return fmt.Errorf("Error reading columns of table %s.%s: %w", tableSchema, tableName, err)
+	}
+	defer rows.Close()
+
+	columns := make([]interface{}, 0)
+	for rows.Next() {
+		var name, columnType, defaultValue string
+		var notNull bool
+
+		if err = rows.Scan(&name, &columnType, &notNull, &defaultValue); err != nil {
+			return fmt.Errorf("could not scan column of table %s.%s: %w", tableSchema, tableName, err)
+		}
+
+		columns = append(columns, map[string]interface{}{
+			"name":     name,
+			"type":     columnType,
+			"not_null": notNull,
+			"default":  defaultValue,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("database", database)
+	d.Set("owner", owner)
+	d.Set("columns", columns)
+	d.SetId(strings.Join([]string{database, tableSchema, tableName}, "."))
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceTable(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.users (id integer PRIMARY KEY, email varchar(255) NOT NULL, active boolean DEFAULT true)")
+	dbExecute(t, config.connStr(dbName), "ALTER TABLE test_schema.users DROP COLUMN active")
+	dbExecute(t, config.connStr(dbName), "ALTER TABLE test_schema.users ADD COLUMN created_at timestamptz DEFAULT now()")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_table" "test" {
+					database = "%s"
+					schema   = "test_schema"
+					name     = "users"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.postgresql_table.test", "owner"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.#", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.0.name", "id"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.0.type", "integer"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.0.not_null", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.1.name", "email"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.1.type", "character varying(255)"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.1.default", ""),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.2.name", "created_at"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.2.not_null", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_table.test", "columns.2.default", "now()"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_table" "test" {
+					database = "%s"
+					schema   = "test_schema"
+					name     = "missing"
+				}`, dbName),
+				ExpectError: regexp.MustCompile("table test_schema.missing not found"),
+			},
+		},
+	})
+}
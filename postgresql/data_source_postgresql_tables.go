@@ -8,9 +8,14 @@ import (
 )
 
 const (
+	// The row security column is given as a parameter, as relrowsecurity only exists since PostgreSQL 9.5.
 	tableQuery = `
-	SELECT table_name, table_schema, table_type
+	SELECT table_name, table_schema, table_type, pg_catalog.pg_get_userbyid(c.relowner),
+		CASE c.relkind WHEN 'p' THEN 'partitioned' WHEN 'f' THEN 'foreign' WHEN 'v' THEN 'view' ELSE 'table' END,
+		%s
 	FROM information_schema.tables
+	JOIN pg_catalog.pg_namespace n ON n.nspname = table_schema
+	JOIN pg_catalog.pg_class c ON c.relnamespace = n.oid AND c.relname = table_name
 	`
 	tablePatternMatchingTarget = "table_name"
 	tableSchemaKeyword         = "table_schema"
@@ -19,7 +24,7 @@ const (
 
 func dataSourcePostgreSQLDatabaseTables() *schema.Resource {
 	return &schema.Resource{
-		Read: PGResourceFunc(dataSourcePostgreSQLTablesRead),
+		ReadContext: PGReadFunc(dataSourcePostgreSQLTablesRead),
 		Schema: map[string]*schema.Schema{
 			"database": {
 				Type:        schema.TypeString,
@@ -84,6 +89,18 @@ func dataSourcePostgreSQLDatabaseTables() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"row_security": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
 					},
 				},
 				Description: "The list of PostgreSQL tables retrieved by this data source. Note that this returns a set, so duplicate table names across different schemas will be consolidated.",
@@ -101,7 +118,12 @@ func dataSourcePostgreSQLTablesRead(db *DBConnection, d *schema.ResourceData) er
 	}
 	defer deferredRollback(txn)
 
-	query := tableQuery
+	rowSecurityColumn := "false"
+	if db.featureSupported(featureRLS) {
+		rowSecurityColumn = "c.relrowsecurity"
+	}
+
+	query := fmt.Sprintf(tableQuery, rowSecurityColumn)
 	queryConcatKeyword := queryConcatKeywordWhere
 
 	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, tableSchemaKeyword, d.Get("schemas").([]interface{}))
@@ -119,8 +141,11 @@ func dataSourcePostgreSQLTablesRead(db *DBConnection, d *schema.ResourceData) er
 		var object_name string
 		var schema_name string
 		var table_type string
+		var owner string
+		var kind string
+		var row_security bool
 
-		if err = rows.Scan(&object_name, &schema_name, &table_type); err != nil {
+		if err = rows.Scan(&object_name, &schema_name, &table_type, &owner, &kind, &row_security); err != nil {
 			return fmt.Errorf("could not scan table output for database: %w", err)
 		}
 
@@ -128,6 +153,9 @@ func dataSourcePostgreSQLTablesRead(db *DBConnection, d *schema.ResourceData) er
 		result["object_name"] = object_name
 		result["schema_name"] = schema_name
 		result["table_type"] = table_type
+		result["owner"] = owner
+		result["kind"] = kind
+		result["row_security"] = row_security
 		tables = append(tables, result)
 	}
 
@@ -36,6 +36,9 @@ func TestAccPostgresqlDataSourceTables(t *testing.T) {
 					resource.TestCheckResourceAttr("data.postgresql_tables.test_schema", "tables.0.object_name", "test_table"),
 					resource.TestCheckResourceAttr("data.postgresql_tables.test_schema", "tables.0.schema_name", "test_schema"),
 					resource.TestCheckResourceAttr("data.postgresql_tables.test_schema", "tables.0.table_type", "BASE TABLE"),
+					resource.TestCheckResourceAttr("data.postgresql_tables.test_schema", "tables.0.kind", "table"),
+					resource.TestCheckResourceAttr("data.postgresql_tables.test_schema", "tables.0.row_security", "false"),
+					resource.TestCheckResourceAttrSet("data.postgresql_tables.test_schema", "tables.0.owner"),
 					resource.TestCheckResourceAttr("data.postgresql_tables.test_schemas1and2", "tables.#", "3"),
 					resource.TestCheckResourceAttr("data.postgresql_tables.test_schemas1and2_type_base", "tables.#", "3"),
 					resource.TestCheckResourceAttr("data.postgresql_tables.test_schemas1and2_type_other", "tables.#", "0"),
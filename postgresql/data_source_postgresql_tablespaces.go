@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var tablespaceQueries = map[string]string{
+	"query_include_system_tablespaces": `
+	SELECT t.spcname, pg_catalog.pg_get_userbyid(t.spcowner), pg_catalog.pg_tablespace_location(t.oid)
+	FROM pg_catalog.pg_tablespace t
+	`,
+	"query_exclude_system_tablespaces": `
+	SELECT t.spcname, pg_catalog.pg_get_userbyid(t.spcowner), pg_catalog.pg_tablespace_location(t.oid)
+	FROM pg_catalog.pg_tablespace t
+	WHERE t.spcname NOT IN ('pg_default', 'pg_global')
+	`,
+}
+
+const tablespacePatternMatchingTarget = "t.spcname"
+
+func dataSourcePostgreSQLTablespaces() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLTablespacesRead),
+		Schema: map[string]*schema.Schema{
+			"include_system_tablespaces": {
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+				Description: "Determines whether to include the system tablespaces (pg_default and pg_global)",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against tablespace names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against tablespace names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against tablespace names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against tablespace names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"tablespaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL tablespaces retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLTablespacesRead(db *DBConnection, d *schema.ResourceData) error {
+	var query string
+	var queryConcatKeyword string
+	if d.Get("include_system_tablespaces").(bool) {
+		query = tablespaceQueries["query_include_system_tablespaces"]
+		queryConcatKeyword = queryConcatKeywordWhere
+	} else {
+		query = tablespaceQueries["query_exclude_system_tablespaces"]
+		queryConcatKeyword = queryConcatKeywordAnd
+	}
+
+	query = applyOptionalPatternMatchingToQuery(query, tablespacePatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY t.spcname", query)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tablespaces := make([]interface{}, 0)
+	for rows.Next() {
+		var name, owner, location string
+
+		if err = rows.Scan(&name, &owner, &location); err != nil {
+			return fmt.Errorf("could not scan tablespace output: %w", err)
+		}
+
+		// The location of the system tablespaces is empty, as they are in the data directory.
+		result := make(map[string]interface{})
+		result["name"] = name
+		result["owner"] = owner
+		result["location"] = location
+		tablespaces = append(tablespaces, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("tablespaces", tablespaces)
+	d.SetId(generateDataSourceTablespacesID(d))
+
+	return nil
+}
+
+func generateDataSourceTablespacesID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		"tablespaces", strconv.FormatBool(d.Get("include_system_tablespaces").(bool)),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,37 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceTablespaces(t *testing.T) {
+	skipIfNotAcc(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "postgresql_tablespaces" "system" {
+					include_system_tablespaces = true
+					like_any_patterns          = ["pg\\_%"]
+				}
+
+				data "postgresql_tablespaces" "no_system" {
+					like_any_patterns = ["pg\\_%"]
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_tablespaces.system", "tablespaces.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_tablespaces.system", "tablespaces.0.name", "pg_default"),
+					resource.TestCheckResourceAttr("data.postgresql_tablespaces.system", "tablespaces.0.location", ""),
+					resource.TestCheckResourceAttrSet("data.postgresql_tablespaces.system", "tablespaces.0.owner"),
+					resource.TestCheckResourceAttr("data.postgresql_tablespaces.system", "tablespaces.1.name", "pg_global"),
+					resource.TestCheckResourceAttr("data.postgresql_tablespaces.no_system", "tablespaces.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// The internal triggers (e.g.: implementing the foreign keys) are excluded.
+	triggerQuery = `
+	SELECT t.tgname, n.nspname, c.relname, t.tgenabled <> 'D', pn.nspname, p.proname
+	FROM pg_catalog.pg_trigger t
+	JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_proc p ON p.oid = t.tgfoid
+	JOIN pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace
+	WHERE NOT t.tgisinternal
+	`
+	triggerPatternMatchingTarget = "t.tgname"
+	triggerSchemaKeyword         = "n.nspname"
+	triggerTableKeyword          = "c.relname"
+)
+
+func dataSourcePostgreSQLTriggers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLTriggersRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for trigger names",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) of the tables which will be queried for trigger names. Queries all schemas in the database by default",
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The name(s) of the tables whose triggers will be queried. Queries the triggers of all tables by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against trigger names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against trigger names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against trigger names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against trigger names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"triggers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"table_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"function_schema": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"function": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL triggers retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLTriggersRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := triggerQuery
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, triggerSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, triggerTableKeyword, d.Get("tables").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, triggerPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY n.nspname, c.relname, t.tgname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	triggers := make([]interface{}, 0)
+	for rows.Next() {
+		var objectName, schemaName, tableName, functionSchema, function string
+		var enabled bool
+
+		if err = rows.Scan(&objectName, &schemaName, &tableName, &enabled, &functionSchema, &function); err != nil {
+			return fmt.Errorf("could not scan trigger output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["object_name"] = objectName
+		result["schema_name"] = schemaName
+		result["table_name"] = tableName
+		result["enabled"] = enabled
+		result["function_schema"] = functionSchema
+		result["function"] = function
+		triggers = append(triggers, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("triggers", triggers)
+	d.SetId(generateDataSourceTriggersID(d, database))
+
+	return nil
+}
+
+func generateDataSourceTriggersID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("tables").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,54 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceTriggers(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE FUNCTION public.audit() RETURNS trigger LANGUAGE plpgsql AS 'BEGIN RETURN NEW; END'")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.customers (id integer PRIMARY KEY)")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer, customer_id integer REFERENCES test_schema.customers)")
+	dbExecute(t, config.connStr(dbName), "CREATE TRIGGER orders_audit AFTER INSERT ON test_schema.orders FOR EACH ROW EXECUTE PROCEDURE public.audit()")
+	dbExecute(t, config.connStr(dbName), "CREATE TRIGGER customers_audit AFTER INSERT ON test_schema.customers FOR EACH ROW EXECUTE PROCEDURE public.audit()")
+	dbExecute(t, config.connStr(dbName), "ALTER TABLE test_schema.customers DISABLE TRIGGER customers_audit")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_triggers" "all" {
+					database = "%[1]s"
+				}
+
+				data "postgresql_triggers" "orders" {
+					database = "%[1]s"
+					tables   = ["orders"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_triggers.all", "triggers.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.all", "triggers.0.object_name", "customers_audit"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.all", "triggers.0.enabled", "false"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.0.object_name", "orders_audit"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.0.schema_name", "test_schema"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.0.table_name", "orders"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.0.enabled", "true"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.0.function_schema", "public"),
+					resource.TestCheckResourceAttr("data.postgresql_triggers.orders", "triggers.0.function", "audit"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,218 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	// Only enums, domains, ranges and standalone composite types (CREATE TYPE ... AS) are returned,
+	// the row types of the tables and the system types are excluded.
+	typeQuery = `
+	SELECT t.typname, n.nspname,
+		CASE t.typtype WHEN 'e' THEN 'enum' WHEN 'd' THEN 'domain' WHEN 'r' THEN 'range' ELSE 'composite' END,
+		pg_catalog.pg_get_userbyid(t.typowner),
+		ARRAY(SELECT e.enumlabel FROM pg_catalog.pg_enum e WHERE e.enumtypid = t.oid ORDER BY e.enumsortorder),
+		CASE t.typtype
+			WHEN 'd' THEN pg_catalog.format_type(t.typbasetype, t.typtypmod)
+			WHEN 'r' THEN pg_catalog.format_type(r.rngsubtype, NULL)
+			ELSE ''
+		END,
+		ARRAY(
+			SELECT a.attname FROM pg_catalog.pg_attribute a
+			WHERE a.attrelid = t.typrelid AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum
+		),
+		ARRAY(
+			SELECT pg_catalog.format_type(a.atttypid, a.atttypmod) FROM pg_catalog.pg_attribute a
+			WHERE a.attrelid = t.typrelid AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum
+		)
+	FROM pg_catalog.pg_type t
+	JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+	LEFT JOIN pg_catalog.pg_class c ON c.oid = t.typrelid
+	LEFT JOIN pg_catalog.pg_range r ON r.rngtypid = t.oid
+	WHERE (t.typtype IN ('e', 'd', 'r') OR (t.typtype = 'c' AND c.relkind = 'c'))
+	AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	`
+	typePatternMatchingTarget = "t.typname"
+	typeSchemaKeyword         = "n.nspname"
+	typeKindKeyword           = "CASE t.typtype WHEN 'e' THEN 'enum' WHEN 'd' THEN 'domain' WHEN 'r' THEN 'range' ELSE 'composite' END"
+)
+
+func dataSourcePostgreSQLTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLTypesRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for type names",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) which will be queried for type names. Queries all schemas in the database by default",
+			},
+			"kinds": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The kinds of types which will be queried ('enum', 'composite', 'domain' or 'range'). Includes all of them by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against type names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against type names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against type names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against type names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"base_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"attributes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+				Description: "The list of PostgreSQL user-defined types retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLTypesRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := typeQuery
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, typeSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, typeKindKeyword, d.Get("kinds").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, typePatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY n.nspname, t.typname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	types := make([]interface{}, 0)
+	for rows.Next() {
+		var objectName, schemaName, kind, owner, baseType string
+		var values, attributeNames, attributeTypes []string
+
+		if err = rows.Scan(&objectName, &schemaName, &kind, &owner, pq.Array(&values), &baseType, pq.Array(&attributeNames), pq.Array(&attributeTypes)); err != nil {
+			return fmt.Errorf("could not scan type output for database: %w", err)
+		}
+
+		attributes := make([]interface{}, 0, len(attributeNames))
+		for i := range attributeNames {
+			attributes = append(attributes, map[string]interface{}{
+				"name": attributeNames[i],
+				"type": attributeTypes[i],
+			})
+		}
+
+		result := make(map[string]interface{})
+		result["object_name"] = objectName
+		result["schema_name"] = schemaName
+		result["kind"] = kind
+		result["owner"] = owner
+		result["values"] = values
+		result["base_type"] = baseType
+		result["attributes"] = attributes
+		types = append(types, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("types", types)
+	d.SetId(generateDataSourceTypesID(d, database))
+
+	return nil
+}
+
+func generateDataSourceTypesID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("kinds").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
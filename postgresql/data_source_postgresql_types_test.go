@@ -0,0 +1,61 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceTypes(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TYPE test_schema.status AS ENUM ('new', 'paid', 'shipped')")
+	dbExecute(t, config.connStr(dbName), "CREATE TYPE test_schema.address AS (street text, zip varchar(10))")
+	dbExecute(t, config.connStr(dbName), "CREATE DOMAIN test_schema.positive_int AS integer CHECK (VALUE > 0)")
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_types" "all" {
+					database = "%[1]s"
+					schemas  = ["test_schema"]
+				}
+
+				data "postgresql_types" "enums" {
+					database = "%[1]s"
+					kinds    = ["enum"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.#", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.object_name", "address"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.kind", "composite"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.attributes.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.attributes.0.name", "street"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.attributes.0.type", "text"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.attributes.1.name", "zip"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.0.attributes.1.type", "character varying(10)"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.1.object_name", "positive_int"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.1.kind", "domain"),
+					resource.TestCheckResourceAttr("data.postgresql_types.all", "types.1.base_type", "integer"),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.0.object_name", "status"),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.0.schema_name", "test_schema"),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.0.owner", config.Username),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.0.values.#", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.0.values.0", "new"),
+					resource.TestCheckResourceAttr("data.postgresql_types.enums", "types.0.values.2", "shipped"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,167 @@
+package postgresql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// The system views are excluded, as pg_catalog and information_schema contain many of them.
+	viewQuery = `
+	SELECT c.relname, n.nspname, CASE c.relkind WHEN 'm' THEN 'materialized_view' ELSE 'view' END,
+		pg_catalog.pg_get_userbyid(c.relowner), md5(pg_catalog.pg_get_viewdef(c.oid))
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('v', 'm')
+	AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	`
+	viewPatternMatchingTarget = "c.relname"
+	viewSchemaKeyword         = "n.nspname"
+	viewKindKeyword           = "CASE c.relkind WHEN 'm' THEN 'materialized_view' ELSE 'view' END"
+)
+
+func dataSourcePostgreSQLViews() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: PGReadFunc(dataSourcePostgreSQLViewsRead),
+		Schema: map[string]*schema.Schema{
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The PostgreSQL database which will be queried for view names",
+			},
+			"schemas": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The PostgreSQL schema(s) which will be queried for view names. Queries all schemas in the database by default",
+			},
+			"kinds": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "The kinds of views which will be queried ('view' or 'materialized_view'). Includes both by default",
+			},
+			"like_any_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against view names in the query using the PostgreSQL LIKE ANY operator",
+			},
+			"like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against view names in the query using the PostgreSQL LIKE ALL operator",
+			},
+			"not_like_all_patterns": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    0,
+				Description: "Expression(s) which will be pattern matched against view names in the query using the PostgreSQL NOT LIKE ALL operator",
+			},
+			"regex_pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression which will be pattern matched against view names in the query using the PostgreSQL ~ (regular expression match) operator",
+			},
+			"views": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schema_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"definition_hash": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "The list of PostgreSQL views retrieved by this data source",
+			},
+		},
+	}
+}
+
+func dataSourcePostgreSQLViewsRead(db *DBConnection, d *schema.ResourceData) error {
+	database := d.Get("database").(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	query := viewQuery
+	queryConcatKeyword := queryConcatKeywordAnd
+
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, viewSchemaKeyword, d.Get("schemas").([]interface{}))
+	query = applyEqualsAnyFilteringToQuery(query, &queryConcatKeyword, viewKindKeyword, d.Get("kinds").([]interface{}))
+	query = applyOptionalPatternMatchingToQuery(query, viewPatternMatchingTarget, &queryConcatKeyword, d)
+	query = fmt.Sprintf("%s ORDER BY n.nspname, c.relname", query)
+
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	views := make([]interface{}, 0)
+	for rows.Next() {
+		var objectName, schemaName, kind, owner, definitionHash string
+
+		if err = rows.Scan(&objectName, &schemaName, &kind, &owner, &definitionHash); err != nil {
+			return fmt.Errorf("could not scan view output for database: %w", err)
+		}
+
+		result := make(map[string]interface{})
+		result["object_name"] = objectName
+		result["schema_name"] = schemaName
+		result["kind"] = kind
+		result["owner"] = owner
+		result["definition_hash"] = definitionHash
+		views = append(views, result)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set("views", views)
+	d.SetId(generateDataSourceViewsID(d, database))
+
+	return nil
+}
+
+func generateDataSourceViewsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		generatePatternArrayString(d.Get("schemas").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("kinds").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_any_patterns").([]interface{}), queryArrayKeywordAny),
+		generatePatternArrayString(d.Get("like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		generatePatternArrayString(d.Get("not_like_all_patterns").([]interface{}), queryArrayKeywordAll),
+		d.Get("regex_pattern").(string),
+	}, "_")
+}
@@ -0,0 +1,58 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostgresqlDataSourceViews(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_schema.orders (id integer, amount numeric)")
+	dbExecute(t, config.connStr(dbName), "CREATE VIEW test_schema.big_orders AS SELECT id FROM test_schema.orders WHERE amount > 100")
+	dbExecute(t, config.connStr(dbName), "CREATE MATERIALIZED VIEW test_schema.order_totals AS SELECT sum(amount) AS total FROM test_schema.orders")
+	dbExecute(t, config.connStr(dbName), "CREATE VIEW dev_schema.all_orders AS SELECT * FROM test_schema.orders")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				data "postgresql_views" "all" {
+					database = "%[1]s"
+				}
+
+				data "postgresql_views" "test_schema" {
+					database = "%[1]s"
+					schemas  = ["test_schema"]
+				}
+
+				data "postgresql_views" "materialized" {
+					database = "%[1]s"
+					kinds    = ["materialized_view"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.postgresql_views.all", "views.#", "3"),
+					resource.TestCheckResourceAttr("data.postgresql_views.all", "views.0.object_name", "all_orders"),
+					resource.TestCheckResourceAttr("data.postgresql_views.all", "views.0.schema_name", "dev_schema"),
+					resource.TestCheckResourceAttr("data.postgresql_views.test_schema", "views.#", "2"),
+					resource.TestCheckResourceAttr("data.postgresql_views.test_schema", "views.0.object_name", "big_orders"),
+					resource.TestCheckResourceAttr("data.postgresql_views.test_schema", "views.0.kind", "view"),
+					resource.TestCheckResourceAttrSet("data.postgresql_views.test_schema", "views.0.owner"),
+					resource.TestCheckResourceAttrSet("data.postgresql_views.test_schema", "views.0.definition_hash"),
+					resource.TestCheckResourceAttr("data.postgresql_views.materialized", "views.#", "1"),
+					resource.TestCheckResourceAttr("data.postgresql_views.materialized", "views.0.object_name", "order_totals"),
+					resource.TestCheckResourceAttr("data.postgresql_views.materialized", "views.0.kind", "materialized_view"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+// Greenplum specifics of the core resources (compatibility_mode = "greenplum").
+// Greenplum is a fork of PostgreSQL so its catalogs are mostly the same,
+// its roles also have a resource queue and a resource group limiting their
+// resources usage.
+
+const (
+	roleResourceQueueAttr = "resource_queue"
+	roleResourceGroupAttr = "resource_group"
+)
+
+// greenplumRoleAttrs are the postgresql_role attributes only supported by Greenplum.
+var greenplumRoleAttrs = []string{roleResourceQueueAttr, roleResourceGroupAttr}
+
+// checkGreenplumRoleAttrs returns an error if the role uses Greenplum attributes
+// while not connected to Greenplum.
+func checkGreenplumRoleAttrs(db *DBConnection, d *schema.ResourceData) error {
+	if db.isGreenplum() {
+		return nil
+	}
+	for _, attr := range greenplumRoleAttrs {
+		if d.Get(attr).(string) != "" && (d.IsNewResource() || d.HasChange(attr)) {
+			return fmt.Errorf("%s is only supported by Greenplum", attr)
+		}
+	}
+	return nil
+}
+
+// greenplumRoleOptions returns the options of CREATE ROLE for the Greenplum attributes.
+func greenplumRoleOptions(d *schema.ResourceData) []string {
+	var options []string
+	if queue := d.Get(roleResourceQueueAttr).(string); queue != "" {
+		options = append(options, "RESOURCE QUEUE "+pq.QuoteIdentifier(queue))
+	}
+	if group := d.Get(roleResourceGroupAttr).(string); group != "" {
+		options = append(options, "RESOURCE GROUP "+pq.QuoteIdentifier(group))
+	}
+	return options
+}
+
+// setGreenplumRoleResources updates the resource queue and group of the role.
+func setGreenplumRoleResources(txn *sql.Tx, d *schema.ResourceData) error {
+	roleName := d.Get(roleNameAttr).(string)
+
+	if d.HasChange(roleResourceQueueAttr) {
+		queue := "NONE"
+		if v := d.Get(roleResourceQueueAttr).(string); v != "" {
+			queue = pq.QuoteIdentifier(v)
+		}
+		if _, err := txn.Exec(fmt.Sprintf("ALTER ROLE %s RESOURCE QUEUE %s", pq.QuoteIdentifier(roleName), queue)); err != nil {
+			return fmt.Errorf("Error updating role RESOURCE QUEUE: %w", err)
+		}
+	}
+
+	if d.HasChange(roleResourceGroupAttr) {
+		group := "NONE"
+		if v := d.Get(roleResourceGroupAttr).(string); v != "" {
+			group = pq.QuoteIdentifier(v)
+		}
+		if _, err := txn.Exec(fmt.Sprintf("ALTER ROLE %s RESOURCE GROUP %s", pq.QuoteIdentifier(roleName), group)); err != nil {
+			return fmt.Errorf("Error updating role RESOURCE GROUP: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readGreenplumRoleResources reads the resource queue and group of the role.
+func readGreenplumRoleResources(db QueryAble, d *schema.ResourceData) error {
+	var queue, group string
+	err := db.QueryRow(
+		`SELECT COALESCE(q.rsqname, ''), COALESCE(g.rsgname, '') `+
+			`FROM pg_catalog.pg_roles r `+
+			`LEFT JOIN pg_catalog.pg_resqueue q ON q.oid = r.rolresqueue `+
+			`LEFT JOIN pg_catalog.pg_resgroup g ON g.oid = r.rolresgroup `+
+			`WHERE r.rolname = $1`,
+		d.Id(),
+	).Scan(&queue, &group)
+	if err != nil {
+		return fmt.Errorf("Error reading role resources: %w", err)
+	}
+
+	d.Set(roleResourceQueueAttr, queue)
+	d.Set(roleResourceGroupAttr, group)
+	return nil
+}
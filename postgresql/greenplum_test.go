@@ -0,0 +1,29 @@
+package postgresql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGreenplumRoleOptions(t *testing.T) {
+	var tests = []struct {
+		config map[string]interface{}
+		want   []string
+	}{
+		{map[string]interface{}{"name": "role"}, nil},
+		{map[string]interface{}{"name": "role", "resource_queue": "etl"}, []string{`RESOURCE QUEUE "etl"`}},
+		{
+			map[string]interface{}{"name": "role", "resource_queue": "etl", "resource_group": "analysts"},
+			[]string{`RESOURCE QUEUE "etl"`, `RESOURCE GROUP "analysts"`},
+		},
+	}
+
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLRole().Schema, test.config)
+		if got := greenplumRoleOptions(d); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("greenplumRoleOptions(%v) returned %v, want %v", test.config, got, test.want)
+		}
+	}
+}
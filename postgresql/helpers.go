@@ -1,38 +1,254 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/lib/pq"
 )
 
-func PGResourceFunc(fn func(*DBConnection, *schema.ResourceData) error) func(*schema.ResourceData, interface{}) error {
-	return func(d *schema.ResourceData, meta interface{}) error {
-		client := meta.(*Client)
+// PGResourceFunc returns the context-aware CRUD function calling fn, whose connection is retried
+// on the transient errors until the context (limited by the timeouts of the resource) is done.
+// fn itself is not retried, as its statements may have been applied before the error
+// (e.g.: CREATE DATABASE, which can't be rolled back, or a commit whose reply was lost).
+// The transactions and the statements of fn are cancelled with the context.
+func PGResourceFunc(fn func(*DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return PGResourceContextFunc(func(_ context.Context, db *DBConnection, d *schema.ResourceData) error {
+		return fn(db, d)
+	})
+}
+
+// PGResourceContextFunc is PGResourceFunc for the functions running long statements
+// (e.g.: CREATE INDEX), which pass the context to the statements so they are cancelled
+// when the timeout of the operation is reached.
+func PGResourceContextFunc(fn func(context.Context, *DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		err := resourceClient(d, meta).withConnection(ctx, false, func(db *DBConnection) error {
+			return fn(ctx, db, d)
+		})
+		return errorDiagnostics(err)
+	}
+}
+
+// PGReadFunc is PGResourceFunc for the Read functions of the resources and the data sources:
+// as they don't change anything, they are retried as a whole on the transient errors.
+func PGReadFunc(fn func(*DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		err := resourceClient(d, meta).withConnection(ctx, true, func(db *DBConnection) error {
+			return fn(db, d)
+		})
+		return errorDiagnostics(err)
+	}
+}
+
+// withConnection calls fn with a connection of the client cancelled when ctx is done.
+// The connection is retried on the transient errors, and fn too if it only reads.
+func (c *Client) withConnection(ctx context.Context, read bool, fn func(*DBConnection) error) error {
+	ctx = withUsedPools(ctx)
+	if read {
+		// The transactions of fn are not retried on their own, fn is.
+		client := c.withContext(context.WithValue(ctx, retriedKey{}, true))
+		return client.withRetries(ctx, func() error {
+			db, err := client.Connect()
+			if err != nil {
+				return err
+			}
+
+			return fn(db)
+		})
+	}
+
+	client := c.withContext(ctx)
+	start := time.Now()
+	var db *DBConnection
+	err := client.withRetries(ctx, func() (err error) {
+		db, err = client.Connect()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := fn(db); err != nil {
+		// The write is not retried, but the next operations connect to the new primary.
+		if isReadOnlyError(err) {
+			resetPools(ctx, start)
+		}
+		return err
+	}
+	return nil
+}
+
+// defaultLongRunningTimeout is the default timeout of the operations running long statements,
+// longer than the default 20 minutes of Terraform as they can last for a while on large databases.
+const defaultLongRunningTimeout = time.Hour
+
+// errorDiagnostics returns the diagnostics of err, with the detail and the hint
+// of the PostgreSQL error, if any.
+func errorDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
 
-		db, err := client.Connect()
+	var details []string
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if pqErr.Detail != "" {
+			details = append(details, pqErr.Detail)
+		}
+		if pqErr.Hint != "" {
+			details = append(details, "HINT: "+pqErr.Hint)
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+		Detail:   strings.Join(details, "\n"),
+	}}
+}
+
+// PGResourceReadFunc is PGReadFunc for the Read functions of the objects which
+// can be dropped outside of Terraform: the resource is removed from the state when
+// exists returns false, instead of being read.
+func PGResourceReadFunc(exists func(*DBConnection, *schema.ResourceData) (bool, error), read func(*DBConnection, *schema.ResourceData) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return PGReadFunc(func(db *DBConnection, d *schema.ResourceData) error {
+		found, err := exists(db, d)
 		if err != nil {
 			return err
 		}
+		if !found {
+			log.Printf("[WARN] %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return read(db, d)
+	})
+}
 
-		return fn(db, d)
+const assumeRoleAttr = "assume_role"
+
+// resourceClient returns the client of the provider, or the one assuming
+// the role set in the assume_role argument of the resource.
+func resourceClient(d *schema.ResourceData, meta interface{}) *Client {
+	client := meta.(*Client)
+	if role, ok := d.GetOk(assumeRoleAttr); ok {
+		client = client.withAssumedRole(role.(string))
+	}
+	return client
+}
+
+const identityOIDAttr = "oid"
+
+// oidIdentity returns the identity of the resources managing an object identified by
+// its OID, which, unlike its name, doesn't change when it's renamed.
+func oidIdentity(attributes map[string]*schema.Schema) *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			identity := map[string]*schema.Schema{
+				identityOIDAttr: {
+					Type:              schema.TypeInt,
+					RequiredForImport: true,
+					Description:       "The OID of the object",
+				},
+			}
+			for name, s := range attributes {
+				identity[name] = s
+			}
+			return identity
+		},
+	}
+}
+
+// setIdentity sets the attributes of the identity of the resource.
+func setIdentity(d *schema.ResourceData, attributes map[string]interface{}) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("could not get the identity of %s: %w", d.Id(), err)
 	}
+	for name, value := range attributes {
+		if err := identity.Set(name, value); err != nil {
+			return fmt.Errorf("could not set the identity attribute %s of %s: %w", name, d.Id(), err)
+		}
+	}
+	return nil
 }
 
-func PGResourceExistsFunc(fn func(*DBConnection, *schema.ResourceData) (bool, error)) func(*schema.ResourceData, interface{}) (bool, error) {
-	return func(d *schema.ResourceData, meta interface{}) (bool, error) {
-		client := meta.(*Client)
+// importStatePassthroughWithOID returns the importer of the resources with an oidIdentity:
+// when imported by identity, the ID of the resource is found by readID from the OID.
+func importStatePassthroughWithOID(readID func(*DBConnection, *schema.IdentityData) (string, error)) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if d.Id() != "" {
+			return []*schema.ResourceData{d}, nil
+		}
 
-		db, err := client.Connect()
+		identity, err := d.Identity()
 		if err != nil {
-			return false, err
+			return nil, fmt.Errorf("could not get the identity of the imported resource: %w", err)
 		}
+		err = resourceClient(d, meta).withConnection(ctx, true, func(db *DBConnection) error {
+			id, err := readID(db, identity)
+			if err != nil {
+				return err
+			}
+			d.SetId(id)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
 
-		return fn(db, d)
+// assumeRoleSchema is the assume_role argument of the resources owning objects,
+// which overrides the assume_role of the provider.
+func assumeRoleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The role to switch to (SET ROLE) to manage this object instead of the assume_role of the provider",
+	}
+}
+
+// requireFeature returns a CustomizeDiff function failing the plan if feature, used by
+// description, is not supported by compatibility_mode or the version set in expected_version.
+// uses tells if the resource uses the feature, it always does if nil.
+// Without expected_version the server version is not known during the plan,
+// the features are then checked when applying.
+func requireFeature(feature featureName, description string, uses func(*schema.ResourceDiff) bool) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*Client)
+		if !ok || (uses != nil && !uses(d)) {
+			return nil
+		}
+		if supported, ok := compatibilityFeature(client.config.CompatibilityMode, feature); ok {
+			if supported {
+				return nil
+			}
+			return fmt.Errorf("%s is not supported with compatibility_mode %s", description, client.config.CompatibilityMode)
+		}
+		if !client.config.expectedVersionSet() || client.config.featureSupported(feature) {
+			return nil
+		}
+		return fmt.Errorf(
+			"%s is not supported by PostgreSQL %s set in expected_version: remove it or, if the server is more recent, update expected_version",
+			description, client.config.ExpectedVersion,
+		)
+	}
+}
+
+// attributeSet returns whether attribute is set to a non zero value, for requireFeature.
+func attributeSet(attribute string) func(*schema.ResourceDiff) bool {
+	return func(d *schema.ResourceDiff) bool {
+		_, ok := d.GetOk(attribute)
+		return ok
 	}
 }
 
@@ -292,26 +508,143 @@ func setToPgIdentList(schema string, idents *schema.Set) string {
 	return strings.Join(quotedIdents, ",")
 }
 
-// startTransaction starts a new DB transaction on the specified database.
+// connectToDatabase returns a connection pool on the specified database.
 // If the database is specified and different from the one configured in the provider,
 // it will create a new connection pool if needed.
-func startTransaction(client *Client, database string) (*sql.Tx, error) {
+// This is needed for statements which cannot be executed inside a transaction block.
+func connectToDatabase(client *Client, database string) (*DBConnection, error) {
 	if database != "" && database != client.databaseName {
-		client = client.config.NewClient(database)
+		client = client.config.NewClient(database).withContext(client.ctx)
 	}
-	db, err := client.Connect()
+	return client.Connect()
+}
+
+// startTransaction starts a new DB transaction on the specified database.
+// If the database is specified and different from the one configured in the provider,
+// it will create a new connection pool if needed.
+func startTransaction(client *Client, database string) (*sql.Tx, error) {
+	db, err := connectToDatabase(client, database)
 	if err != nil {
 		return nil, err
 	}
 
-	txn, err := db.Begin()
+	// Nothing has been sent yet, so opening the connection of the transaction can be retried.
+	var txn *sql.Tx
+	err = client.withRetries(client.ctx, func() (err error) {
+		// lib/pq cancels the running statement of the transaction when the context is done.
+		txn, err = db.BeginTx(client.ctx, nil)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("could not start transaction: %w", err)
 	}
 
+	if client.config.TransactionPooling {
+		if err := setLocalSettings(txn, client.config); err != nil {
+			txn.Rollback()
+			return nil, err
+		}
+	}
+
 	return txn, nil
 }
 
+// hasLocalSettings returns whether the role or the timeouts of the config are set in
+// each transaction instead of when connecting, with transaction_pooling.
+func (c *Config) hasLocalSettings() bool {
+	return c.TransactionPooling && (c.AssumeRole != "" || c.StatementTimeout > 0 || c.LockTimeout > 0)
+}
+
+// setLocalSettings sets the timeouts and the role of the config for the transaction.
+func setLocalSettings(txn *sql.Tx, config Config) error {
+	if err := setLocalTimeouts(txn, config); err != nil {
+		return err
+	}
+	return setLocalRole(txn, config)
+}
+
+// ExecContext runs query with the role and the timeouts of the client. With transaction_pooling,
+// they are only set in transactions (see startTransaction), so the query then runs in its own one.
+// The statements which can't run in a transaction block must use execOutsideTransaction.
+func (db *DBConnection) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if !db.client.config.hasLocalSettings() {
+		return db.DB.ExecContext(ctx, query, args...)
+	}
+
+	txn, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer deferredRollback(txn)
+
+	if err := setLocalSettings(txn, db.client.config); err != nil {
+		return nil, err
+	}
+	result, err := txn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// Exec is ExecContext with the context of the client.
+func (db *DBConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(db.client.ctx, query, args...)
+}
+
+// Query is QueryContext with the context of the client.
+func (db *DBConnection) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.QueryContext(db.client.ctx, query, args...)
+}
+
+// QueryRow is QueryRowContext with the context of the client.
+func (db *DBConnection) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.QueryRowContext(db.client.ctx, query, args...)
+}
+
+// execOutsideTransaction runs query, which can't run in a transaction block (e.g.: CREATE DATABASE).
+// With transaction_pooling, each statement outside of a transaction may run on another server
+// connection, so the role and the timeouts can't be set for it: it's rejected instead of running
+// with the privileges of the connected user (who would then own the objects created) or without timeout.
+func (db *DBConnection) execOutsideTransaction(ctx context.Context, query string) error {
+	if db.client.config.hasLocalSettings() {
+		return errors.New("the statement can't run in a transaction, so assume_role, statement_timeout and lock_timeout can't be applied to it with transaction_pooling")
+	}
+	_, err := db.DB.ExecContext(ctx, query)
+	return err
+}
+
+// setLocalTimeouts sets statement_timeout and lock_timeout for the transaction,
+// as they can't be sent when connecting through a pooler in transaction mode.
+func setLocalTimeouts(txn *sql.Tx, config Config) error {
+	if config.StatementTimeout > 0 {
+		if _, err := txn.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", config.StatementTimeout)); err != nil {
+			return fmt.Errorf("could not set statement_timeout: %w", err)
+		}
+	}
+	if config.LockTimeout > 0 {
+		if _, err := txn.Exec(fmt.Sprintf("SET LOCAL lock_timeout = %d", config.LockTimeout)); err != nil {
+			return fmt.Errorf("could not set lock_timeout: %w", err)
+		}
+	}
+	return nil
+}
+
+// setLocalRole switches the transaction to the role of assume_role,
+// as it can't be sent when connecting through a pooler in transaction mode.
+func setLocalRole(txn *sql.Tx, config Config) error {
+	if config.AssumeRole == "" {
+		return nil
+	}
+	if _, err := txn.Exec(fmt.Sprintf("SET LOCAL ROLE %s", pq.QuoteIdentifier(config.AssumeRole))); err != nil {
+		return fmt.Errorf("could not set role %s: %w", config.AssumeRole, err)
+	}
+	return nil
+}
+
 func dbExists(db QueryAble, dbname string) (bool, error) {
 	err := db.QueryRow("SELECT datname FROM pg_database WHERE datname=$1", dbname).Scan(&dbname)
 	switch {
@@ -325,7 +658,12 @@ func dbExists(db QueryAble, dbname string) (bool, error) {
 }
 
 func roleExists(txn *sql.Tx, rolname string) (bool, error) {
-	err := txn.QueryRow("SELECT 1 FROM pg_roles WHERE rolname=$1", rolname).Scan(&rolname)
+	// pg_user and pg_group (the roles which can and cannot login) are used instead of
+	// pg_roles, as Redshift only has them.
+	err := txn.QueryRow(
+		"SELECT usename FROM pg_catalog.pg_user WHERE usename=$1 UNION ALL SELECT groname FROM pg_catalog.pg_group WHERE groname=$1",
+		rolname,
+	).Scan(&rolname)
 	switch {
 	case err == sql.ErrNoRows:
 		return false, nil
@@ -383,9 +721,8 @@ func getDatabase(d *schema.ResourceData, databaseName string) string {
 
 func getDatabaseOwner(db QueryAble, database string) (string, error) {
 	query := `
-SELECT rolname
+SELECT pg_catalog.pg_get_userbyid(datdba)
   FROM pg_database
-  JOIN pg_roles ON datdba = pg_roles.oid
   WHERE datname = $1
 `
 	var owner string
@@ -402,9 +739,8 @@ SELECT rolname
 
 func getSchemaOwner(db QueryAble, schemaName string) (string, error) {
 	query := `
-SELECT rolname
+SELECT pg_catalog.pg_get_userbyid(nspowner)
   FROM pg_namespace
-  JOIN pg_roles ON nspowner = pg_roles.oid
   WHERE nspname = $1
 `
 	var owner string
@@ -467,8 +803,8 @@ func getRoleOID(db QueryAble, role string) (int, error) {
 
 // Lock a role and all his members to avoid concurrent updates on some resources
 func pgLockRole(txn *sql.Tx, role string) error {
-	// Disable statement timeout for this connection otherwise the lock could fail
-	if _, err := txn.Exec("SET statement_timeout = 0"); err != nil {
+	// Disable statement timeout for this transaction otherwise the lock could fail
+	if _, err := txn.Exec("SET LOCAL statement_timeout = 0"); err != nil {
 		return fmt.Errorf("could not disable statement_timeout: %w", err)
 	}
 	if _, err := txn.Exec("SELECT pg_advisory_xact_lock(oid::bigint) FROM pg_roles WHERE rolname = $1", role); err != nil {
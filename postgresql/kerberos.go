@@ -0,0 +1,166 @@
+package postgresql
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/lib/pq"
+)
+
+// KerberosConfig configures the GSSAPI (Kerberos) authentication.
+type KerberosConfig struct {
+	// ServiceName is the Kerberos service name of the server (krbsrvname),
+	// "postgres" if empty.
+	ServiceName string
+	// SPN is the full service principal name of the server (krbspn),
+	// used instead of ServiceName/host if set.
+	SPN string
+
+	// ConfigFile defaults to KRB5_CONFIG or /etc/krb5.conf.
+	ConfigFile string
+
+	// Keytab and Principal (user[@REALM]) are used to login if Keytab is set,
+	// the credentials cache (CCache, defaults to KRB5CCNAME) is used otherwise.
+	Keytab    string
+	Principal string
+	CCache    string
+}
+
+func init() {
+	// Use the Kerberos environment (KRB5_CONFIG, KRB5CCNAME) by default,
+	// as libpq does.
+	registerKerberos(&KerberosConfig{})
+}
+
+// registerKerberos registers the GSSAPI authentication provider of lib/pq.
+// As lib/pq only has a global provider, the last registered configuration is
+// used by all the connections.
+func registerKerberos(kerberosConfig *KerberosConfig) {
+	pq.RegisterGSSProvider(func() (pq.GSS, error) {
+		return newKerberosGSS(kerberosConfig)
+	})
+}
+
+// kerberosGSS implements pq.GSS with the Kerberos SPNEGO mechanism.
+type kerberosGSS struct {
+	client *client.Client
+}
+
+func newKerberosGSS(kerberosConfig *KerberosConfig) (*kerberosGSS, error) {
+	configFile := kerberosConfig.ConfigFile
+	if configFile == "" {
+		if configFile = os.Getenv("KRB5_CONFIG"); configFile == "" {
+			configFile = "/etc/krb5.conf"
+		}
+	}
+	krb5Config, err := config.Load(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load Kerberos configuration %s: %w", configFile, err)
+	}
+
+	var krb5Client *client.Client
+	if kerberosConfig.Keytab != "" {
+		kt, err := keytab.Load(kerberosConfig.Keytab)
+		if err != nil {
+			return nil, fmt.Errorf("could not load Kerberos keytab %s: %w", kerberosConfig.Keytab, err)
+		}
+		username, realm := parseKerberosPrincipal(kerberosConfig.Principal, krb5Config.LibDefaults.DefaultRealm)
+		if username == "" {
+			return nil, errors.New("a Kerberos principal is required to login with a keytab")
+		}
+		krb5Client = client.NewWithKeytab(username, realm, kt, krb5Config, client.DisablePAFXFAST(true))
+	} else {
+		ccachePath, err := kerberosCCachePath(kerberosConfig.CCache)
+		if err != nil {
+			return nil, err
+		}
+		ccache, err := credentials.LoadCCache(ccachePath)
+		if err != nil {
+			return nil, fmt.Errorf("could not load Kerberos credentials cache %s: %w", ccachePath, err)
+		}
+		if krb5Client, err = client.NewFromCCache(ccache, krb5Config, client.DisablePAFXFAST(true)); err != nil {
+			return nil, fmt.Errorf("could not create Kerberos client: %w", err)
+		}
+	}
+
+	if err := krb5Client.Login(); err != nil {
+		return nil, fmt.Errorf("could not login with Kerberos: %w", err)
+	}
+
+	return &kerberosGSS{client: krb5Client}, nil
+}
+
+// parseKerberosPrincipal splits a user[@REALM] principal,
+// defaultRealm is used if it has no realm.
+func parseKerberosPrincipal(principal, defaultRealm string) (string, string) {
+	if i := strings.LastIndex(principal, "@"); i >= 0 {
+		return principal[:i], principal[i+1:]
+	}
+	return principal, defaultRealm
+}
+
+// kerberosCCachePath returns the path of the credentials cache,
+// ccache can be empty or prefixed by FILE: as KRB5CCNAME.
+func kerberosCCachePath(ccache string) (string, error) {
+	if ccache == "" {
+		ccache = os.Getenv("KRB5CCNAME")
+	}
+	if ccache == "" {
+		u, err := user.Current()
+		if err != nil {
+			return "", fmt.Errorf("could not get the Kerberos credentials cache of the current user: %w", err)
+		}
+		return "/tmp/krb5cc_" + u.Uid, nil
+	}
+
+	if i := strings.Index(ccache, ":"); i >= 0 {
+		if ccacheType := ccache[:i]; ccacheType != "FILE" {
+			return "", fmt.Errorf("unsupported Kerberos credentials cache type %s, only FILE is supported", ccacheType)
+		}
+		ccache = ccache[i+1:]
+	}
+	return ccache, nil
+}
+
+// GetInitToken returns the initial token for the service on host.
+func (g *kerberosGSS) GetInitToken(host string, service string) ([]byte, error) {
+	if g.client.Config.LibDefaults.DNSCanonicalizeHostname {
+		if cname, err := net.LookupCNAME(host); err == nil {
+			host = strings.TrimSuffix(cname, ".")
+		}
+	}
+	return g.GetInitTokenFromSpn(service + "/" + host)
+}
+
+// GetInitTokenFromSpn returns the initial token for the service principal name.
+func (g *kerberosGSS) GetInitTokenFromSpn(spn string) ([]byte, error) {
+	token, err := spnego.SPNEGOClient(g.client, spn).InitSecContext()
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize Kerberos security context for %s: %w", spn, err)
+	}
+	return token.Marshal()
+}
+
+// Continue checks the response of the server, the authentication is done in one step.
+func (g *kerberosGSS) Continue(inToken []byte) (bool, []byte, error) {
+	token := &spnego.SPNEGOToken{}
+	if err := token.Unmarshal(inToken); err != nil {
+		return true, nil, fmt.Errorf("could not unmarshal Kerberos response: %w", err)
+	}
+	if !token.Resp {
+		return true, nil, errors.New("expected a Kerberos response token")
+	}
+	if state := token.NegTokenResp.State(); state != spnego.NegStateAcceptCompleted {
+		return true, nil, fmt.Errorf("unexpected Kerberos negotiation state %d", state)
+	}
+	return true, nil, nil
+}
@@ -0,0 +1,47 @@
+package postgresql
+
+import (
+	"testing"
+)
+
+func TestParseKerberosPrincipal(t *testing.T) {
+	var tests = []struct {
+		principal string
+		wantUser  string
+		wantRealm string
+	}{
+		{"tf_user@EXAMPLE.COM", "tf_user", "EXAMPLE.COM"},
+		{"tf_user", "tf_user", "DEFAULT.REALM"},
+		{"", "", "DEFAULT.REALM"},
+	}
+
+	for _, test := range tests {
+		user, realm := parseKerberosPrincipal(test.principal, "DEFAULT.REALM")
+		if user != test.wantUser || realm != test.wantRealm {
+			t.Errorf("parseKerberosPrincipal(%q) returned (%q, %q), want (%q, %q)", test.principal, user, realm, test.wantUser, test.wantRealm)
+		}
+	}
+}
+
+func TestKerberosCCachePath(t *testing.T) {
+	var tests = []struct {
+		ccache  string
+		want    string
+		wantErr bool
+	}{
+		{"/tmp/krb5cc_tf", "/tmp/krb5cc_tf", false},
+		{"FILE:/tmp/krb5cc_tf", "/tmp/krb5cc_tf", false},
+		{"KEYRING:persistent:1000", "", true},
+	}
+
+	for _, test := range tests {
+		path, err := kerberosCCachePath(test.ccache)
+		if (err != nil) != test.wantErr {
+			t.Errorf("kerberosCCachePath(%q) returned error %v, want error: %t", test.ccache, err, test.wantErr)
+			continue
+		}
+		if path != test.want {
+			t.Errorf("kerberosCCachePath(%q) returned %q, want %q", test.ccache, path, test.want)
+		}
+	}
+}
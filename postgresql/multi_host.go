@@ -0,0 +1,97 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// HostPort is one of the servers to connect to when several are configured.
+type HostPort struct {
+	Host string
+	Port int
+}
+
+func (h HostPort) String() string {
+	if strings.HasPrefix(h.Host, "/") {
+		return h.Host
+	}
+	return net.JoinHostPort(h.Host, strconv.Itoa(h.Port))
+}
+
+// parseHostPort parses a host[:port] address, port is used if it has no port.
+// The address can also be the directory of a Unix domain socket.
+func parseHostPort(address string, port int) (HostPort, error) {
+	if strings.HasPrefix(address, "/") || !strings.Contains(address, ":") {
+		return HostPort{Host: address, Port: port}, nil
+	}
+	// IPv6 addresses without port
+	if strings.Count(address, ":") > 1 && !strings.HasPrefix(address, "[") {
+		return HostPort{Host: address, Port: port}, nil
+	}
+
+	host, rawPort, err := net.SplitHostPort(address)
+	if err != nil {
+		return HostPort{}, fmt.Errorf("invalid host %q: %w", address, err)
+	}
+	if port, err = strconv.Atoi(rawPort); err != nil || port < 1 || port > 65535 {
+		return HostPort{}, fmt.Errorf("invalid port in host %q", address)
+	}
+	return HostPort{Host: host, Port: port}, nil
+}
+
+// targetSessionAttrsQueries are the queries checking if a server matches the
+// target_session_attrs, as in libpq, with the value they must return.
+var targetSessionAttrsQueries = map[string]struct {
+	query string
+	want  string
+}{
+	"read-write": {"SHOW transaction_read_only", "off"},
+	"read-only":  {"SHOW transaction_read_only", "on"},
+	"primary":    {"SELECT pg_catalog.pg_is_in_recovery()::text", "false"},
+	"standby":    {"SELECT pg_catalog.pg_is_in_recovery()::text", "true"},
+}
+
+// checkTargetSessionAttrs returns an error if the server of conn doesn't match targetSessionAttrs.
+func checkTargetSessionAttrs(ctx context.Context, conn driver.Conn, targetSessionAttrs string) error {
+	check, ok := targetSessionAttrsQueries[targetSessionAttrs]
+	if !ok {
+		// any
+		return nil
+	}
+
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return fmt.Errorf("connection does not support queries")
+	}
+	rows, err := queryer.QueryContext(ctx, check.query, nil)
+	if err != nil {
+		return fmt.Errorf("could not check target_session_attrs: %w", err)
+	}
+	defer rows.Close()
+
+	values := make([]driver.Value, 1)
+	if err := rows.Next(values); err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("no rows returned")
+		}
+		return fmt.Errorf("could not check target_session_attrs: %w", err)
+	}
+
+	var value string
+	switch v := values[0].(type) {
+	case []byte:
+		value = string(v)
+	default:
+		value = fmt.Sprint(v)
+	}
+	if value != check.want {
+		return fmt.Errorf("server does not match target_session_attrs %s", targetSessionAttrs)
+	}
+
+	return nil
+}
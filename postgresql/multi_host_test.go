@@ -0,0 +1,58 @@
+package postgresql
+
+import (
+	"testing"
+)
+
+func TestParseHostPort(t *testing.T) {
+	var tests = []struct {
+		address string
+		want    HostPort
+		wantErr bool
+	}{
+		{"db1.example.com", HostPort{"db1.example.com", 5432}, false},
+		{"db1.example.com:5433", HostPort{"db1.example.com", 5433}, false},
+		{"10.0.0.1:5433", HostPort{"10.0.0.1", 5433}, false},
+		{"[fd00::1]:5433", HostPort{"fd00::1", 5433}, false},
+		{"fd00::1", HostPort{"fd00::1", 5432}, false},
+		{"/var/run/postgresql", HostPort{"/var/run/postgresql", 5432}, false},
+		{"db1.example.com:postgres", HostPort{}, true},
+	}
+
+	for _, test := range tests {
+		host, err := parseHostPort(test.address, 5432)
+		if (err != nil) != test.wantErr {
+			t.Errorf("parseHostPort(%q) returned error %v, want error: %t", test.address, err, test.wantErr)
+			continue
+		}
+		if host != test.want {
+			t.Errorf("parseHostPort(%q) returned %+v, want %+v", test.address, host, test.want)
+		}
+	}
+}
+
+func TestAccPQConnectorHosts(t *testing.T) {
+	skipIfNotAcc(t)
+
+	config := getTestConfig(t)
+	// The first host is not reachable, the connector must fall back to the second one.
+	config.Hosts = []HostPort{{Host: "127.0.0.1", Port: 1}, {Host: config.Host, Port: config.Port}}
+	config.TargetSessionAttrs = "read-write"
+
+	db, err := config.NewClient("postgres").Connect()
+	if err != nil {
+		t.Fatalf("could not connect: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("could not connect through the second host: %v", err)
+	}
+
+	config.TargetSessionAttrs = "standby"
+	db, err = config.NewClient("postgres").Connect()
+	if err == nil {
+		err = db.Ping()
+	}
+	if err == nil {
+		t.Fatalf("connected to a primary server with target_session_attrs standby")
+	}
+}
@@ -0,0 +1,34 @@
+package postgresql
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestRunPasswordCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands need a POSIX shell")
+	}
+
+	var tests = []struct {
+		command string
+		want    string
+		wantErr bool
+	}{
+		{"echo s3cr3t", "s3cr3t", false},
+		{"printf 'token with spaces'", "token with spaces", false},
+		{"echo 'not authenticated' >&2; exit 1", "", true},
+		{"true", "", true},
+	}
+
+	for _, test := range tests {
+		password, err := runPasswordCommand(test.command)
+		if (err != nil) != test.wantErr {
+			t.Errorf("runPasswordCommand(%q) returned error %v, want error: %t", test.command, err, test.wantErr)
+			continue
+		}
+		if password != test.want {
+			t.Errorf("runPasswordCommand(%q) returned %q, want %q", test.command, password, test.want)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProtoV6ProviderServer returns the server of the provider (protocol v6), which serves
+// the resources migrated to the plugin framework with frameworkProvider and the other
+// ones with the plugin SDK provider, upgraded from the protocol v5.
+func ProtoV6ProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
+	return newProtoV6ProviderServer(ctx, Provider())
+}
+
+func newProtoV6ProviderServer(ctx context.Context, sdkProvider *sdkschema.Provider) (func() tfprotov6.ProviderServer, error) {
+	sdkServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, fmt.Errorf("could not upgrade the plugin SDK provider to the protocol v6: %w", err)
+	}
+
+	// The muxed providers must have the same schema, the one of the plugin SDK is converted.
+	schemaResp, err := sdkServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("could not get the schema of the plugin SDK provider: %w", err)
+	}
+	providerSchema, err := frameworkProviderSchema(schemaResp.Provider)
+	if err != nil {
+		return nil, err
+	}
+	frameworkServer := providerserver.NewProtocol6(&frameworkProvider{sdkProvider: sdkProvider, schema: providerSchema})
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		// The plugin SDK provider is configured first, so the client is set when
+		// the framework provider is configured.
+		func() tfprotov6.ProviderServer { return sdkServer },
+		func() tfprotov6.ProviderServer { return frameworkProviderServer{frameworkServer()} },
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not mux the providers: %w", err)
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProviderServer is the server of frameworkProvider, whose configuration is
+// validated by the plugin SDK provider only, not to report its deprecations twice.
+type frameworkProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (s frameworkProviderServer) ValidateProviderConfig(_ context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	return &tfprotov6.ValidateProviderConfigResponse{PreparedConfig: req.Config}, nil
+}
+
+// frameworkProvider serves the resources migrated to the plugin framework.
+// Its arguments are the ones of the plugin SDK provider, which configures the client
+// used by the resources of both.
+type frameworkProvider struct {
+	sdkProvider *sdkschema.Provider
+	schema      schema.Schema
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "postgresql"
+	resp.Version = Version
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = p.schema
+}
+
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// If the plugin SDK provider failed to configure the client, it reported the error.
+	if client, ok := p.sdkProvider.Meta().(*Client); ok {
+		resp.ResourceData = client
+		resp.DataSourceData = client
+	}
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newPhysicalReplicationSlotResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// frameworkClient returns the client configured by the provider for the resources
+// served by the plugin framework, nil if the provider isn't configured yet.
+func frameworkClient(providerData interface{}, diags *fwdiag.Diagnostics) *Client {
+	if providerData == nil {
+		return nil
+	}
+	client, ok := providerData.(*Client)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("expected *Client, got %T", providerData))
+	}
+	return client
+}
+
+// addErrorDiagnostics adds the diagnostics of err (see errorDiagnostics) to the ones
+// of the plugin framework.
+func addErrorDiagnostics(diags *fwdiag.Diagnostics, err error) {
+	for _, d := range errorDiagnostics(err) {
+		diags.AddError(d.Summary, d.Detail)
+	}
+}
+
+// frameworkDeprecationMessage is the deprecation message of the converted arguments,
+// only their deprecation is part of the schema of the plugin SDK provider.
+const frameworkDeprecationMessage = "Deprecated, see the documentation of the provider"
+
+// frameworkProviderSchema converts the schema of the plugin SDK provider to the one of
+// the plugin framework.
+func frameworkProviderSchema(s *tfprotov6.Schema) (schema.Schema, error) {
+	attributes, blocks, err := frameworkSchemaBlock(s.Block)
+	if err != nil {
+		return schema.Schema{}, err
+	}
+
+	description, markdownDescription := frameworkDescription(s.Block.Description, s.Block.DescriptionKind)
+	return schema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         description,
+		MarkdownDescription: markdownDescription,
+		DeprecationMessage:  frameworkDeprecation(s.Block.Deprecated),
+	}, nil
+}
+
+func frameworkSchemaBlock(b *tfprotov6.SchemaBlock) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := map[string]schema.Attribute{}
+	for _, a := range b.Attributes {
+		attribute, err := frameworkSchemaAttribute(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := map[string]schema.Block{}
+	for _, nb := range b.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkSchemaBlock(nb.Block)
+		if err != nil {
+			return nil, nil, err
+		}
+		object := schema.NestedBlockObject{Attributes: nestedAttributes, Blocks: nestedBlocks}
+		description, markdownDescription := frameworkDescription(nb.Block.Description, nb.Block.DescriptionKind)
+
+		switch nb.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeList:
+			blocks[nb.TypeName] = schema.ListNestedBlock{
+				NestedObject:        object,
+				Description:         description,
+				MarkdownDescription: markdownDescription,
+				DeprecationMessage:  frameworkDeprecation(nb.Block.Deprecated),
+			}
+		case tfprotov6.SchemaNestedBlockNestingModeSet:
+			blocks[nb.TypeName] = schema.SetNestedBlock{
+				NestedObject:        object,
+				Description:         description,
+				MarkdownDescription: markdownDescription,
+				DeprecationMessage:  frameworkDeprecation(nb.Block.Deprecated),
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported nesting mode %s of provider block %s", nb.Nesting, nb.TypeName)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkSchemaAttribute(a *tfprotov6.SchemaAttribute) (schema.Attribute, error) {
+	description, markdownDescription := frameworkDescription(a.Description, a.DescriptionKind)
+	deprecationMessage := frameworkDeprecation(a.Deprecated)
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return schema.StringAttribute{
+			Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive,
+			Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Bool):
+		return schema.BoolAttribute{
+			Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive,
+			Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Number):
+		return schema.NumberAttribute{
+			Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive,
+			Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.List{}):
+		elementType, err := frameworkElementType(a.Name, a.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ListAttribute{
+			ElementType: elementType, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive,
+			Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Set{}):
+		elementType, err := frameworkElementType(a.Name, a.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SetAttribute{
+			ElementType: elementType, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive,
+			Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage,
+		}, nil
+	case a.Type.Is(tftypes.Map{}):
+		elementType, err := frameworkElementType(a.Name, a.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.MapAttribute{
+			ElementType: elementType, Required: a.Required, Optional: a.Optional, Sensitive: a.Sensitive,
+			Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s of provider argument %s", a.Type, a.Name)
+	}
+}
+
+func frameworkElementType(name string, t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	default:
+		return nil, fmt.Errorf("unsupported element type %s of provider argument %s", t, name)
+	}
+}
+
+func frameworkDescription(description string, kind tfprotov6.StringKind) (string, string) {
+	if kind == tfprotov6.StringKindMarkdown {
+		return "", description
+	}
+	return description, ""
+}
+
+func frameworkDeprecation(deprecated bool) string {
+	if deprecated {
+		return frameworkDeprecationMessage
+	}
+	return ""
+}
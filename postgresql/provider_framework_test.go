@@ -0,0 +1,59 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProtoV6ProviderServer(t *testing.T) {
+	ctx := context.Background()
+	serverFactory, err := ProtoV6ProviderServer(ctx)
+	if err != nil {
+		t.Fatalf("ProtoV6ProviderServer returned an error: %v", err)
+	}
+
+	// The mux fails if the schemas of the providers differ.
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema returned an error: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("GetProviderSchema returned an error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	// Resources of the plugin SDK and of the plugin framework
+	for _, name := range []string{"postgresql_role", "postgresql_physical_replication_slot"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("resource %s is not served", name)
+		}
+	}
+	if len(resp.Provider.Block.Attributes) != len(Provider().Schema)-len(resp.Provider.Block.BlockTypes) {
+		t.Errorf("expected the arguments of the plugin SDK provider, got %d attributes and %d blocks",
+			len(resp.Provider.Block.Attributes), len(resp.Provider.Block.BlockTypes))
+	}
+
+	// The configuration is decoded by both providers.
+	configType := resp.Provider.ValueType().(tftypes.Object)
+	nullArguments := map[string]tftypes.Value{}
+	for name, argumentType := range configType.AttributeTypes {
+		nullArguments[name] = tftypes.NewValue(argumentType, nil)
+	}
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, nullArguments))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureResp, err := serverFactory().ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatalf("ConfigureProvider returned an error: %v", err)
+	}
+	for _, d := range configureResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("ConfigureProvider returned an error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
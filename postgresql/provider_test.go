@@ -2,9 +2,13 @@ package postgresql
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -12,11 +16,24 @@ import (
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// testAccProtoV6ProviderFactories serve the resources migrated to the plugin framework,
+// with testAccProvider for the other ones.
+var testAccProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
 func init() {
 	testAccProvider = Provider()
 	testAccProviders = map[string]*schema.Provider{
 		"postgresql": testAccProvider,
 	}
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"postgresql": func() (tfprotov6.ProviderServer, error) {
+			serverFactory, err := newProtoV6ProviderServer(context.Background(), testAccProvider)
+			if err != nil {
+				return nil, err
+			}
+			return serverFactory(), nil
+		},
+	}
 }
 
 func TestProvider(t *testing.T) {
@@ -29,6 +46,33 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderResourceIdentities(t *testing.T) {
+	for name, identityAttributes := range map[string][]string{
+		"postgresql_database": {identityOIDAttr},
+		"postgresql_role":     {identityOIDAttr},
+		"postgresql_schema":   {identityOIDAttr, schemaDatabaseAttr},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := Provider().ResourcesMap[name]
+			if r.Identity == nil {
+				t.Fatalf("%s has no identity", name)
+			}
+			if err := r.Identity.InternalIdentityValidate(); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			d := schema.TestResourceDataWithIdentityRaw(t, r.SchemaMap(), r.Identity.SchemaFunc(), map[string]string{})
+			attributes := map[string]interface{}{}
+			for _, attribute := range identityAttributes {
+				attributes[attribute] = r.Identity.SchemaFunc()[attribute].ZeroValue()
+			}
+			if err := setIdentity(d, attributes); err != nil {
+				t.Fatalf("could not set the identity: %s", err)
+			}
+		})
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	var host string
 	if host = os.Getenv("PGHOST"); host == "" {
@@ -43,3 +87,118 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// unknownVariableValue is the value of the unknown arguments in a terraform.ResourceConfig.
+const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestProviderConfigureUnknownValues(t *testing.T) {
+	provider := Provider()
+	// Arguments which are not known yet during the plan (e.g.: from a resource created in the same apply)
+	// must not fail the configuration of the provider.
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"host":    unknownVariableValue,
+		"sslcert": "/path/to/cert.pem",
+		"sslkey":  unknownVariableValue,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Configure returned an error: %v", diags)
+	}
+
+	// The configuration is validated when connecting.
+	if _, err := provider.Meta().(*Client).Connect(); err == nil || err.Error() != "invalid provider configuration: sslkey must be set with sslcert" {
+		t.Errorf("Connect returned %v, want an invalid configuration error", err)
+	}
+}
+
+func TestProviderExpectedVersionFeatures(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"expected_version": "9.6",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Configure returned an error: %v", diags)
+	}
+
+	var tests = []struct {
+		resource string
+		config   map[string]interface{}
+		wantErr  bool
+	}{
+		{"postgresql_publication", map[string]interface{}{"name": "pub"}, true},
+		{"postgresql_role", map[string]interface{}{"name": "role", "password_encryption": "scram-sha-256"}, true},
+		{"postgresql_role", map[string]interface{}{"name": "role", "password_encryption": "md5"}, false},
+		{"postgresql_server_setting", map[string]interface{}{"name": "work_mem", "value": "8MB"}, false},
+	}
+
+	for _, test := range tests {
+		resource := provider.ResourcesMap[test.resource]
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), provider.Meta())
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s %v: Diff returned error %v, want error: %t", test.resource, test.config, err, test.wantErr)
+		}
+	}
+}
+
+func TestProviderCompatibilityModes(t *testing.T) {
+	var tests = []struct {
+		mode    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{"redshift", map[string]interface{}{"name": "user", "login": true, "password": "secret", "connection_limit": 5}, false},
+		{"redshift", map[string]interface{}{"name": "user", "replication": true}, true},
+		{"redshift", map[string]interface{}{"name": "user", "search_path": []interface{}{"public"}}, true},
+		{"redshift", map[string]interface{}{"name": "user", "inherit": false}, true},
+		{"redshift", map[string]interface{}{"name": "user", "password": "secret"}, true},
+		{"cockroachdb", map[string]interface{}{"name": "role", "login": true, "password": "secret", "search_path": []interface{}{"public"}}, false},
+		{"cockroachdb", map[string]interface{}{"name": "role", "superuser": true}, true},
+		{"cockroachdb", map[string]interface{}{"name": "role", "connection_limit": 5}, true},
+		{"cockroachdb", map[string]interface{}{"name": "role", "password_encryption": "scram-sha-256"}, true},
+	}
+
+	for _, test := range tests {
+		provider := Provider()
+		diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"compatibility_mode": test.mode,
+		}))
+		if diags.HasError() {
+			t.Fatalf("Configure returned an error: %v", diags)
+		}
+
+		resource := provider.ResourcesMap["postgresql_role"]
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), provider.Meta())
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s %v: Diff returned error %v, want error: %t", test.mode, test.config, err, test.wantErr)
+		}
+	}
+}
+
+func TestGetAzureAuthTokenManagedIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("resource") != azureDatabaseResource || r.URL.Query().Get("client_id") != "test-client" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "test-token", "token_type": "Bearer"}`)
+	}))
+	defer server.Close()
+
+	defer func(endpoint string) { azureManagedIdentityEndpoint = endpoint }(azureManagedIdentityEndpoint)
+	azureManagedIdentityEndpoint = server.URL
+
+	token, err := getAzureAuthToken("", "test-client", "")
+	if err != nil {
+		t.Fatalf("getAzureAuthToken returned an error: %v", err)
+	}
+	if token != "test-token" {
+		t.Errorf("getAzureAuthToken returned %q, want %q", token, "test-token")
+	}
+
+	if _, err := getAzureAuthToken("", "other-client", ""); err == nil {
+		t.Error("getAzureAuthToken should return an error for an unknown managed identity")
+	}
+}
@@ -0,0 +1,93 @@
+package postgresql
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// proxyDialer dials the connections to the database through a SOCKS5 or HTTP (CONNECT) proxy.
+// It implements pq.Dialer.
+type proxyDialer struct {
+	url *url.URL
+}
+
+// parseProxyURL parses the URL of a proxy supported by proxyDialer.
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse proxy URL: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, must be one of: socks5, socks5h, http", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("no host in proxy URL %q", rawURL)
+	}
+
+	return proxyURL, nil
+}
+
+// Dial opens a connection to address through the proxy.
+func (d proxyDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialTimeout(network, address, 0)
+}
+
+// DialTimeout opens a connection to address through the proxy.
+// The timeout only applies to the connection to the proxy.
+func (d proxyDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	forward := &net.Dialer{Timeout: timeout}
+
+	if d.url.Scheme != "http" {
+		dialer, err := proxy.FromURL(d.url, forward)
+		if err != nil {
+			return nil, err
+		}
+		return dialer.Dial(network, address)
+	}
+
+	conn, err := forward.Dial("tcp", d.url.Host)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to proxy %s: %w", d.url.Host, err)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if d.url.User != nil {
+		password, _ := d.url.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.url.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not send CONNECT request to proxy %s: %w", d.url.Host, err)
+	}
+
+	// The database doesn't send anything before the client, so nothing
+	// is buffered past the response by the reader.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not read CONNECT response from proxy %s: %w", d.url.Host, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", d.url.Host, address, resp.Status)
+	}
+
+	return conn, nil
+}
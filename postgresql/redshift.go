@@ -0,0 +1,306 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+// Redshift implementation of the core resources (compatibility_mode = "redshift").
+// Redshift is based on PostgreSQL 8.0: it has users and groups instead of roles
+// (no pg_roles, pg_auth_members or role attributes such as INHERIT), and no aclexplode,
+// the privileges are read from its svv_*_privileges views.
+
+// redshiftUnsupportedRoleAttrs are the postgresql_role attributes Redshift users don't have.
+var redshiftUnsupportedRoleAttrs = []string{
+	roleCreateRoleAttr,
+	roleReplicationAttr,
+	roleBypassRLSAttr,
+	roleSearchPathAttr,
+	roleStatementTimeoutAttr,
+	roleIdleInTransactionSessionTimeoutAttr,
+	rolePasswordEncryptionAttr,
+}
+
+// redshiftRoleCustomizeDiff fails the plan if the role uses attributes Redshift doesn't support.
+func redshiftRoleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*Client)
+	if !ok || client.config.CompatibilityMode != compatibilityRedshift {
+		return nil
+	}
+
+	for _, attr := range redshiftUnsupportedRoleAttrs {
+		if _, ok := d.GetOk(attr); ok {
+			return fmt.Errorf("%s is not supported with compatibility_mode redshift", attr)
+		}
+	}
+	if !d.Get(roleInheritAttr).(bool) {
+		return fmt.Errorf("%s = false is not supported with compatibility_mode redshift, users always inherit the privileges of their groups", roleInheritAttr)
+	}
+	if !d.Get(roleLoginAttr).(bool) && d.Get(rolePasswordAttr).(string) != "" {
+		return fmt.Errorf("a password cannot be set with %s = false with compatibility_mode redshift", roleLoginAttr)
+	}
+	return nil
+}
+
+// redshiftUserOptions returns the options of CREATE USER or ALTER USER for the
+// attributes of d, only the changed ones if onlyChanged.
+func redshiftUserOptions(d *schema.ResourceData, onlyChanged bool) []string {
+	changed := func(attrs ...string) bool {
+		return !onlyChanged || d.HasChanges(attrs...)
+	}
+	var options []string
+
+	// Redshift users can always login with their password, which is disabled otherwise.
+	// The password is also reset if the user is renamed, as its md5 hash is salted with the name.
+	if changed(rolePasswordAttr, roleLoginAttr, roleNameAttr) {
+		if password := d.Get(rolePasswordAttr).(string); password != "" && d.Get(roleLoginAttr).(bool) {
+			options = append(options, fmt.Sprintf("PASSWORD '%s'", pqQuoteLiteral(password)))
+		} else {
+			options = append(options, "PASSWORD DISABLE")
+		}
+	}
+	if changed(roleCreateDBAttr) {
+		options = append(options, map[bool]string{true: "CREATEDB", false: "NOCREATEDB"}[d.Get(roleCreateDBAttr).(bool)])
+	}
+	if changed(roleSuperuserAttr) {
+		options = append(options, map[bool]string{true: "CREATEUSER", false: "NOCREATEUSER"}[d.Get(roleSuperuserAttr).(bool)])
+	}
+	if changed(roleConnLimitAttr) {
+		if limit := d.Get(roleConnLimitAttr).(int); limit >= 0 {
+			options = append(options, fmt.Sprintf("CONNECTION LIMIT %d", limit))
+		} else {
+			options = append(options, "CONNECTION LIMIT UNLIMITED")
+		}
+	}
+	if changed(roleValidUntilAttr) {
+		options = append(options, fmt.Sprintf("VALID UNTIL '%s'", pqQuoteLiteral(d.Get(roleValidUntilAttr).(string))))
+	}
+
+	return options
+}
+
+func resourceRedshiftUserCreate(db *DBConnection, d *schema.ResourceData) error {
+	userName := d.Get(roleNameAttr).(string)
+
+	sql := fmt.Sprintf("CREATE USER %s %s", pq.QuoteIdentifier(userName), strings.Join(redshiftUserOptions(d, false), " "))
+	if groups := d.Get(roleRolesAttr).(*schema.Set).List(); len(groups) > 0 {
+		quotedGroups := make([]string, len(groups))
+		for i, group := range groups {
+			quotedGroups[i] = pq.QuoteIdentifier(group.(string))
+		}
+		sql += " IN GROUP " + strings.Join(quotedGroups, ", ")
+	}
+	if _, err := db.Exec(sql); err != nil {
+		return fmt.Errorf("error creating user %s: %w", userName, err)
+	}
+
+	d.SetId(userName)
+
+	return resourceRedshiftUserRead(db, d)
+}
+
+func resourceRedshiftUserExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var userName string
+	err := db.QueryRow("SELECT usename FROM pg_catalog.pg_user WHERE usename=$1", d.Id()).Scan(&userName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourceRedshiftUserRead(db *DBConnection, d *schema.ResourceData) error {
+	var userName, validUntil, connLimit string
+	var superuser, createDB bool
+
+	userID := d.Id()
+	err := db.QueryRow(
+		"SELECT usename, usesuper, usecreatedb, COALESCE(valuntil::TEXT, 'infinity'), useconnlimit FROM pg_catalog.pg_user_info WHERE usename=$1",
+		userID,
+	).Scan(&userName, &superuser, &createDB, &validUntil, &connLimit)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] Redshift USER (%s) not found", userID)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading USER: %w", err)
+	}
+
+	groups, err := readRedshiftUserGroups(db, userName)
+	if err != nil {
+		return err
+	}
+
+	connectionLimit := -1
+	if connLimit != "UNLIMITED" {
+		if connectionLimit, err = strconv.Atoi(connLimit); err != nil {
+			return fmt.Errorf("invalid connection limit %q of user %s: %w", connLimit, userName, err)
+		}
+	}
+
+	d.Set(roleNameAttr, userName)
+	d.Set(roleSuperuserAttr, superuser)
+	d.Set(roleCreateDBAttr, createDB)
+	d.Set(roleConnLimitAttr, connectionLimit)
+	d.Set(roleValidUntilAttr, validUntil)
+	d.Set(roleRolesAttr, stringSliceToSet(groups))
+	// The attributes Redshift users don't have, see redshiftRoleCustomizeDiff.
+	// login and password can't be read.
+	d.Set(roleInheritAttr, true)
+	d.Set(roleCreateRoleAttr, false)
+	d.Set(roleReplicationAttr, false)
+	d.Set(roleBypassRLSAttr, false)
+	d.Set(roleEncryptedPassAttr, true)
+	d.Set(roleSkipDropRoleAttr, d.Get(roleSkipDropRoleAttr).(bool))
+	d.Set(roleSkipReassignOwnedAttr, d.Get(roleSkipReassignOwnedAttr).(bool))
+
+	d.SetId(userName)
+
+	return nil
+}
+
+// readRedshiftUserGroups returns the groups userName is member of.
+func readRedshiftUserGroups(db QueryAble, userName string) ([]string, error) {
+	rows, err := db.Query(
+		"SELECT groname FROM pg_catalog.pg_group WHERE (SELECT usesysid FROM pg_catalog.pg_user WHERE usename=$1) = ANY(grolist)",
+		userName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the groups of user %s: %w", userName, err)
+	}
+	defer rows.Close()
+
+	var groups []string
+	for rows.Next() {
+		var group string
+		if err := rows.Scan(&group); err != nil {
+			return nil, fmt.Errorf("could not scan the groups of user %s: %w", userName, err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+func resourceRedshiftUserUpdate(db *DBConnection, d *schema.ResourceData) error {
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChange(roleNameAttr) {
+		o, n := d.GetChange(roleNameAttr)
+		sql := fmt.Sprintf("ALTER USER %s RENAME TO %s", pq.QuoteIdentifier(o.(string)), pq.QuoteIdentifier(n.(string)))
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating user NAME: %w", err)
+		}
+		d.SetId(n.(string))
+	}
+
+	userName := d.Get(roleNameAttr).(string)
+	// Redshift only accepts one option by ALTER USER for some of them (e.g.: PASSWORD).
+	for _, option := range redshiftUserOptions(d, true) {
+		if _, err := txn.Exec(fmt.Sprintf("ALTER USER %s %s", pq.QuoteIdentifier(userName), option)); err != nil {
+			return fmt.Errorf("Error updating user %s: %w", userName, err)
+		}
+	}
+
+	if d.HasChange(roleRolesAttr) {
+		o, n := d.GetChange(roleRolesAttr)
+		for _, group := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+			sql := fmt.Sprintf("ALTER GROUP %s DROP USER %s", pq.QuoteIdentifier(group.(string)), pq.QuoteIdentifier(userName))
+			if _, err := txn.Exec(sql); err != nil {
+				return fmt.Errorf("could not remove user %s from group %s: %w", userName, group, err)
+			}
+		}
+		for _, group := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+			sql := fmt.Sprintf("ALTER GROUP %s ADD USER %s", pq.QuoteIdentifier(group.(string)), pq.QuoteIdentifier(userName))
+			if _, err := txn.Exec(sql); err != nil {
+				return fmt.Errorf("could not add user %s to group %s: %w", userName, group, err)
+			}
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return resourceRedshiftUserRead(db, d)
+}
+
+func resourceRedshiftUserDelete(db *DBConnection, d *schema.ResourceData) error {
+	// Redshift has no REASSIGN OWNED, the objects of the user must be dropped
+	// or owned by another user first.
+	if !d.Get(roleSkipDropRoleAttr).(bool) {
+		userName := d.Get(roleNameAttr).(string)
+		if _, err := db.Exec(fmt.Sprintf("DROP USER %s", pq.QuoteIdentifier(userName))); err != nil {
+			return fmt.Errorf("could not delete user %s: %w", userName, err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// readRedshiftRolePrivileges is readRolePrivileges for Redshift.
+func readRedshiftRolePrivileges(txn *sql.Tx, d *schema.ResourceData) error {
+	role := d.Get("role").(string)
+
+	var privilegesQuery, objectsQuery string
+	switch d.Get("object_type").(string) {
+	case "database":
+		privileges, err := queryPrivilegesByObject(txn,
+			"SELECT '', privilege_type FROM svv_database_privileges WHERE database_name = $1 AND identity_name = $2",
+			d.Get("database"), role,
+		)
+		if err != nil {
+			return err
+		}
+		d.Set("privileges", stringSliceToSet(privileges[""]))
+		return nil
+
+	case "schema":
+		privileges, err := queryPrivilegesByObject(txn,
+			"SELECT '', privilege_type FROM svv_schema_privileges WHERE namespace_name = $1 AND identity_name = $2",
+			d.Get("schema"), role,
+		)
+		if err != nil {
+			return err
+		}
+		d.Set("privileges", stringSliceToSet(privileges[""]))
+		return nil
+
+	case "function":
+		privilegesQuery = "SELECT function_name, privilege_type FROM svv_function_privileges WHERE namespace_name = $1 AND identity_name = $2"
+		objectsQuery = "SELECT DISTINCT proname FROM pg_catalog.pg_proc p JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace WHERE n.nspname = $1"
+
+	default:
+		privilegesQuery = "SELECT relation_name, privilege_type FROM svv_relation_privileges WHERE namespace_name = $1 AND identity_name = $2"
+		objectsQuery = "SELECT relname FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relkind = 'r'"
+	}
+
+	// The privileges views can't be joined with the catalog tables, which are only on the leader node.
+	privileges, err := queryPrivilegesByObject(txn, privilegesQuery, d.Get("schema"), role)
+	if err != nil {
+		return err
+	}
+	objectNames, err := queryObjectNames(txn, objectsQuery, d.Get("schema"))
+	if err != nil {
+		return err
+	}
+
+	setObjectsPrivileges(d, objectNames, privileges)
+	return nil
+}
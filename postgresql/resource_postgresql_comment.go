@@ -0,0 +1,415 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	commentObjectTypeAttr = "object_type"
+	commentDatabaseAttr   = "database"
+	commentSchemaAttr     = "schema"
+	commentObjectNameAttr = "object_name"
+	commentColumnAttr     = "column"
+	commentArgumentsAttr  = "arguments"
+	commentCommentAttr    = "comment"
+)
+
+// commentObjectTypes maps the supported object types to their SQL keyword in COMMENT ON.
+var commentObjectTypes = map[string]string{
+	"column":            "COLUMN",
+	"database":          "DATABASE",
+	"domain":            "DOMAIN",
+	"extension":         "EXTENSION",
+	"function":          "FUNCTION",
+	"index":             "INDEX",
+	"materialized_view": "MATERIALIZED VIEW",
+	"procedure":         "PROCEDURE",
+	"role":              "ROLE",
+	"schema":            "SCHEMA",
+	"sequence":          "SEQUENCE",
+	"table":             "TABLE",
+	"type":              "TYPE",
+	"view":              "VIEW",
+}
+
+// commentRelationKinds maps the object types stored in pg_class to their relkind.
+var commentRelationKinds = map[string][]string{
+	"column":            {"r", "p", "v", "m", "f"},
+	"index":             {"i", "I"},
+	"materialized_view": {"m"},
+	"sequence":          {"S"},
+	"table":             {"r", "p"},
+	"view":              {"v"},
+}
+
+func resourcePostgreSQLComment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLCommentCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLCommentExists, resourcePostgreSQLCommentRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLCommentUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLCommentDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			commentObjectTypeAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(getCommentObjectTypes(), false),
+				Description:  "The type of the commented object (any of: " + strings.Join(getCommentObjectTypes(), ", ") + ")",
+			},
+			commentDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the commented object is located",
+			},
+			commentSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The schema of the commented object. Defaults to public for the objects which belong to a schema",
+			},
+			commentObjectNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the commented object (the name of the table or view for a column)",
+			},
+			commentColumnAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the commented column, required when object_type is column",
+			},
+			commentArgumentsAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The argument types of the commented function or procedure (e.g.: integer, text)",
+			},
+			commentCommentAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The text of the comment",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLCommentCreate(db *DBConnection, d *schema.ResourceData) error {
+	objectType := d.Get(commentObjectTypeAttr).(string)
+	if objectType == "column" && d.Get(commentColumnAttr).(string) == "" {
+		return fmt.Errorf("%s is required when %s is column", commentColumnAttr, commentObjectTypeAttr)
+	}
+
+	if isCommentObjectInSchema(objectType) && d.Get(commentSchemaAttr).(string) == "" {
+		d.Set(commentSchemaAttr, "public")
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	if err := setComment(db, d, database, fmt.Sprintf("'%s'", pqQuoteLiteral(d.Get(commentCommentAttr).(string)))); err != nil {
+		return fmt.Errorf("Error creating comment: %w", err)
+	}
+
+	d.SetId(generateCommentID(d, database))
+
+	return resourcePostgreSQLCommentReadImpl(db, d)
+}
+
+func resourcePostgreSQLCommentExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, err := getDBCommentObject(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	_, err = getComment(txn, d)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLCommentRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLCommentReadImpl(db, d)
+}
+
+func resourcePostgreSQLCommentReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, err := getDBCommentObject(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	comment, err := getComment(txn, d)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL object commented by %s not found for database %s", d.Id(), database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading comment: %w", err)
+	}
+
+	d.Set(commentDatabaseAttr, database)
+	// A comment removed outside of Terraform is reported as an empty comment, so it will be set again.
+	d.Set(commentCommentAttr, comment.String)
+
+	return nil
+}
+
+func resourcePostgreSQLCommentUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	if d.HasChange(commentCommentAttr) {
+		if err := setComment(db, d, database, fmt.Sprintf("'%s'", pqQuoteLiteral(d.Get(commentCommentAttr).(string)))); err != nil {
+			return fmt.Errorf("Error updating comment: %w", err)
+		}
+	}
+
+	return resourcePostgreSQLCommentReadImpl(db, d)
+}
+
+func resourcePostgreSQLCommentDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	if err := setComment(db, d, database, "NULL"); err != nil {
+		return fmt.Errorf("Error deleting comment: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// setComment runs COMMENT ON the object with the given (already quoted) comment.
+func setComment(db *DBConnection, d *schema.ResourceData, database, comment string) error {
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("COMMENT ON %s IS %s", getCommentObjectIdentity(d), comment)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not set comment on %s: %w", getCommentObjectIdentity(d), err)
+	}
+
+	return txn.Commit()
+}
+
+// getComment returns the comment of the object, it returns sql.ErrNoRows if the object does not exist.
+func getComment(txn *sql.Tx, d *schema.ResourceData) (sql.NullString, error) {
+	var comment sql.NullString
+
+	objectType := d.Get(commentObjectTypeAttr).(string)
+	objectSchema := d.Get(commentSchemaAttr).(string)
+	objectName := d.Get(commentObjectNameAttr).(string)
+
+	var err error
+	switch objectType {
+	case "database":
+		query := "SELECT pg_catalog.shobj_description(oid, 'pg_database') FROM pg_catalog.pg_database WHERE datname = $1"
+		err = txn.QueryRow(query, objectName).Scan(&comment)
+	case "role":
+		query := "SELECT pg_catalog.shobj_description(oid, 'pg_authid') FROM pg_catalog.pg_roles WHERE rolname = $1"
+		err = txn.QueryRow(query, objectName).Scan(&comment)
+	case "schema":
+		query := "SELECT pg_catalog.obj_description(oid, 'pg_namespace') FROM pg_catalog.pg_namespace WHERE nspname = $1"
+		err = txn.QueryRow(query, objectName).Scan(&comment)
+	case "extension":
+		query := "SELECT pg_catalog.obj_description(oid, 'pg_extension') FROM pg_catalog.pg_extension WHERE extname = $1"
+		err = txn.QueryRow(query, objectName).Scan(&comment)
+	case "column":
+		query := `SELECT pg_catalog.col_description(c.oid, a.attnum) FROM pg_catalog.pg_class c ` +
+			`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+			`JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid ` +
+			`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = ANY($3) AND a.attname = $4 AND NOT a.attisdropped`
+		err = txn.QueryRow(
+			query, objectSchema, objectName, pq.Array(commentRelationKinds[objectType]), d.Get(commentColumnAttr).(string),
+		).Scan(&comment)
+	case "domain", "type":
+		query := `SELECT pg_catalog.obj_description(t.oid, 'pg_type') FROM pg_catalog.pg_type t ` +
+			`JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace ` +
+			`WHERE n.nspname = $1 AND t.typname = $2 AND (t.typtype = 'd') = $3`
+		err = txn.QueryRow(query, objectSchema, objectName, objectType == "domain").Scan(&comment)
+	case "function", "procedure":
+		// The arguments are resolved by PostgreSQL, so we first check that a routine with this name exists
+		// as the cast to regprocedure fails if the routine does not exist.
+		var _rez bool
+		query := `SELECT TRUE FROM pg_catalog.pg_proc p ` +
+			`JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace ` +
+			`WHERE n.nspname = $1 AND p.proname = $2 LIMIT 1`
+		if err = txn.QueryRow(query, objectSchema, objectName).Scan(&_rez); err != nil {
+			return comment, err
+		}
+		query = "SELECT pg_catalog.obj_description($1::regprocedure, 'pg_proc')"
+		err = txn.QueryRow(query, getCommentRoutineSignature(d)).Scan(&comment)
+	default:
+		query := `SELECT pg_catalog.obj_description(c.oid, 'pg_class') FROM pg_catalog.pg_class c ` +
+			`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+			`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = ANY($3)`
+		err = txn.QueryRow(query, objectSchema, objectName, pq.Array(commentRelationKinds[objectType])).Scan(&comment)
+	}
+
+	return comment, err
+}
+
+// getCommentObjectIdentity returns the object as it is written in COMMENT ON (e.g.: COLUMN "public"."users"."email").
+func getCommentObjectIdentity(d *schema.ResourceData) string {
+	objectType := d.Get(commentObjectTypeAttr).(string)
+
+	switch objectType {
+	case "column":
+		return fmt.Sprintf(
+			"COLUMN %s.%s.%s",
+			pq.QuoteIdentifier(d.Get(commentSchemaAttr).(string)),
+			pq.QuoteIdentifier(d.Get(commentObjectNameAttr).(string)),
+			pq.QuoteIdentifier(d.Get(commentColumnAttr).(string)),
+		)
+	case "function", "procedure":
+		return fmt.Sprintf("%s %s", commentObjectTypes[objectType], getCommentRoutineSignature(d))
+	}
+
+	if isCommentObjectInSchema(objectType) {
+		return fmt.Sprintf(
+			"%s %s.%s",
+			commentObjectTypes[objectType],
+			pq.QuoteIdentifier(d.Get(commentSchemaAttr).(string)),
+			pq.QuoteIdentifier(d.Get(commentObjectNameAttr).(string)),
+		)
+	}
+
+	return fmt.Sprintf("%s %s", commentObjectTypes[objectType], pq.QuoteIdentifier(d.Get(commentObjectNameAttr).(string)))
+}
+
+func getCommentRoutineSignature(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s(%s)",
+		pq.QuoteIdentifier(d.Get(commentSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(commentObjectNameAttr).(string)),
+		d.Get(commentArgumentsAttr).(string),
+	)
+}
+
+// isCommentObjectInSchema returns true if the objects of this type belong to a schema.
+func isCommentObjectInSchema(objectType string) bool {
+	switch objectType {
+	case "database", "extension", "role", "schema":
+		return false
+	}
+
+	return true
+}
+
+func getCommentObjectTypes() []string {
+	objectTypes := make([]string, 0, len(commentObjectTypes))
+	for objectType := range commentObjectTypes {
+		objectTypes = append(objectTypes, objectType)
+	}
+	sort.Strings(objectTypes)
+
+	return objectTypes
+}
+
+// generateCommentID returns the ID of the comment, i.e.: database.object_type.identity where identity is
+// the name of the object, prefixed by its schema, suffixed by the column or the arguments if needed.
+func generateCommentID(d *schema.ResourceData, databaseName string) string {
+	objectType := d.Get(commentObjectTypeAttr).(string)
+	identity := d.Get(commentObjectNameAttr).(string)
+
+	switch objectType {
+	case "column":
+		identity = identity + "." + d.Get(commentColumnAttr).(string)
+	case "function", "procedure":
+		identity = fmt.Sprintf("%s(%s)", identity, d.Get(commentArgumentsAttr).(string))
+	}
+
+	if isCommentObjectInSchema(objectType) {
+		identity = d.Get(commentSchemaAttr).(string) + "." + identity
+	}
+
+	return strings.Join([]string{databaseName, objectType, identity}, ".")
+}
+
+// getDBCommentObject returns the database of the commented object. If we are importing this
+// resource, the object is parsed from the resource ID and set in the state (it will return an error if parsing failed).
+func getDBCommentObject(d *schema.ResourceData, client *Client) (string, error) {
+	database := getDatabase(d, client.databaseName)
+
+	// When importing, we have to parse the ID to find the object and database names.
+	if d.Get(commentObjectNameAttr).(string) == "" {
+		parsed := strings.SplitN(d.Id(), ".", 3)
+		if len(parsed) != 3 || commentObjectTypes[parsed[1]] == "" {
+			return "", fmt.Errorf("comment ID %s has not the expected format 'database.object_type.identity': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		objectType := parsed[1]
+		identity := parsed[2]
+
+		if isCommentObjectInSchema(objectType) {
+			parts := strings.SplitN(identity, ".", 2)
+			if len(parts) != 2 {
+				return "", fmt.Errorf("comment ID %s has not the expected format 'database.%s.schema.name'", d.Id(), objectType)
+			}
+			d.Set(commentSchemaAttr, parts[0])
+			identity = parts[1]
+		}
+
+		switch objectType {
+		case "column":
+			parts := strings.SplitN(identity, ".", 2)
+			if len(parts) != 2 {
+				return "", fmt.Errorf("comment ID %s has not the expected format 'database.column.schema.table.column'", d.Id())
+			}
+			identity = parts[0]
+			d.Set(commentColumnAttr, parts[1])
+		case "function", "procedure":
+			i := strings.Index(identity, "(")
+			if i < 0 || !strings.HasSuffix(identity, ")") {
+				return "", fmt.Errorf("comment ID %s has not the expected format 'database.%s.schema.name(arguments)'", d.Id(), objectType)
+			}
+			d.Set(commentArgumentsAttr, identity[i+1:len(identity)-1])
+			identity = identity[:i]
+		}
+
+		d.Set(commentObjectTypeAttr, objectType)
+		d.Set(commentObjectNameAttr, identity)
+	}
+
+	return database, nil
+}
@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlComment_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE users (id integer, email text)")
+	dbExecute(t, config.connStr(dbName), "CREATE FUNCTION increment(i integer) RETURNS integer AS 'SELECT i + 1' LANGUAGE SQL")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlCommentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_comment" "table" {
+					object_type = "table"
+					database    = "%[1]s"
+					object_name = "users"
+					comment     = "The users of the application"
+				}
+
+				resource "postgresql_comment" "column" {
+					object_type = "column"
+					database    = "%[1]s"
+					object_name = "users"
+					column      = "email"
+					comment     = "The email of the user"
+				}
+
+				resource "postgresql_comment" "function" {
+					object_type = "function"
+					database    = "%[1]s"
+					object_name = "increment"
+					arguments   = "integer"
+					comment     = "Increments an integer"
+				}
+
+				resource "postgresql_comment" "database" {
+					object_type = "database"
+					database    = "%[1]s"
+					object_name = "%[1]s"
+					comment     = "The test database"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlCommentExists("postgresql_comment.table", "The users of the application"),
+					testAccCheckPostgresqlCommentExists("postgresql_comment.column", "The email of the user"),
+					testAccCheckPostgresqlCommentExists("postgresql_comment.function", "Increments an integer"),
+					testAccCheckPostgresqlCommentExists("postgresql_comment.database", "The test database"),
+					resource.TestCheckResourceAttr("postgresql_comment.table", "id", fmt.Sprintf("%s.table.public.users", dbName)),
+					resource.TestCheckResourceAttr("postgresql_comment.column", "id", fmt.Sprintf("%s.column.public.users.email", dbName)),
+					resource.TestCheckResourceAttr("postgresql_comment.function", "id", fmt.Sprintf("%s.function.public.increment(integer)", dbName)),
+					resource.TestCheckResourceAttr("postgresql_comment.table", "schema", "public"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_comment" "table" {
+					object_type = "table"
+					database    = "%s"
+					object_name = "users"
+					comment     = "The users, it's updated"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlCommentExists("postgresql_comment.table", "The users, it's updated"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_comment.table",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlCommentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_comment" {
+			continue
+		}
+
+		comment, err := getTestComment(client, rs)
+		if err != nil {
+			return err
+		}
+
+		if comment.Valid {
+			return fmt.Errorf("Comment still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlCommentExists(n, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		comment, err := getTestComment(testAccProvider.Meta().(*Client), rs)
+		if err != nil {
+			return err
+		}
+
+		if comment.String != expected {
+			return fmt.Errorf("Expected comment %q, got %q", expected, comment.String)
+		}
+
+		return nil
+	}
+}
+
+func getTestComment(client *Client, rs *terraform.ResourceState) (sql.NullString, error) {
+	d := resourcePostgreSQLComment().Data(rs.Primary)
+
+	txn, err := startTransaction(client, rs.Primary.Attributes[commentDatabaseAttr])
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	defer deferredRollback(txn)
+
+	comment, err := getComment(txn, d)
+	if err != nil {
+		return comment, fmt.Errorf("Error checking comment %s", err)
+	}
+
+	return comment, nil
+}
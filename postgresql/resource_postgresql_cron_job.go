@@ -0,0 +1,154 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	cronJobNameAttr     = "name"
+	cronJobScheduleAttr = "schedule"
+	cronJobCommandAttr  = "command"
+	cronJobDatabaseAttr = "database"
+	cronJobActiveAttr   = "active"
+)
+
+func resourcePostgreSQLCronJob() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLCronJobCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLCronJobRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLCronJobUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLCronJobDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			cronJobNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the cron job",
+			},
+			cronJobScheduleAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The schedule of the job, in cron syntax (e.g.: 0 3 * * *) or as an interval (e.g.: 30 seconds)",
+			},
+			cronJobCommandAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SQL command run by the job",
+			},
+			cronJobDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The database in which the command is run. Defaults to the database where pg_cron is installed",
+			},
+			cronJobActiveAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If the job is active",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLCronJobCreate(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(cronJobNameAttr).(string)
+
+	// The jobs are stored in the database where pg_cron is installed (cron.database_name),
+	// which has to be the database of the provider.
+	// The job runs as the current role, so the schedule runs in a transaction where assume_role is set.
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var jobID int64
+	query := "SELECT cron.schedule_in_database($1, $2, $3, COALESCE(NULLIF($4, ''), current_database()), NULL, $5)"
+	err = txn.QueryRow(
+		query,
+		name,
+		d.Get(cronJobScheduleAttr).(string),
+		d.Get(cronJobCommandAttr).(string),
+		d.Get(cronJobDatabaseAttr).(string),
+		d.Get(cronJobActiveAttr).(bool),
+	).Scan(&jobID)
+	if err != nil {
+		return fmt.Errorf("could not schedule cron job %s: %w", name, err)
+	}
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	d.SetId(strconv.FormatInt(jobID, 10))
+
+	return resourcePostgreSQLCronJobReadImpl(db, d)
+}
+
+func resourcePostgreSQLCronJobRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLCronJobReadImpl(db, d)
+}
+
+func resourcePostgreSQLCronJobReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	jobID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("cron job ID %s has not the expected format (the job ID from cron.job): %w", d.Id(), err)
+	}
+
+	var name, schedule, command, database string
+	var active bool
+	query := "SELECT COALESCE(jobname, ''), schedule, command, database, active FROM cron.job WHERE jobid = $1"
+	err = db.QueryRow(query, jobID).Scan(&name, &schedule, &command, &database, &active)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] pg_cron job (%d) not found", jobID)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading cron job: %w", err)
+	}
+
+	d.Set(cronJobNameAttr, name)
+	d.Set(cronJobScheduleAttr, schedule)
+	d.Set(cronJobCommandAttr, command)
+	d.Set(cronJobDatabaseAttr, database)
+	d.Set(cronJobActiveAttr, active)
+
+	return nil
+}
+
+func resourcePostgreSQLCronJobUpdate(db *DBConnection, d *schema.ResourceData) error {
+	query := "SELECT cron.alter_job($1::bigint, $2, $3, COALESCE(NULLIF($4, ''), current_database()), NULL, $5)"
+	_, err := db.Exec(
+		query,
+		d.Id(),
+		d.Get(cronJobScheduleAttr).(string),
+		d.Get(cronJobCommandAttr).(string),
+		d.Get(cronJobDatabaseAttr).(string),
+		d.Get(cronJobActiveAttr).(bool),
+	)
+	if err != nil {
+		return fmt.Errorf("could not update cron job %s: %w", d.Get(cronJobNameAttr).(string), err)
+	}
+
+	return resourcePostgreSQLCronJobReadImpl(db, d)
+}
+
+func resourcePostgreSQLCronJobDelete(db *DBConnection, d *schema.ResourceData) error {
+	if _, err := db.Exec("SELECT cron.unschedule($1::bigint)", d.Id()); err != nil {
+		return fmt.Errorf("could not unschedule cron job %s: %w", d.Get(cronJobNameAttr).(string), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlCronJob_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	testAccPostgresqlCronJobConfig := func(schedule string, active bool) string {
+		return fmt.Sprintf(`
+		resource "postgresql_cron_job" "test" {
+			name     = "test_vacuum"
+			schedule = "%s"
+			command  = "VACUUM ANALYZE"
+			database = "%s"
+			active   = %t
+		}`, schedule, dbName, active)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCronPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlCronJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlCronJobConfig("0 3 * * *", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlCronJobExists("postgresql_cron_job.test"),
+					resource.TestCheckResourceAttr("postgresql_cron_job.test", "schedule", "0 3 * * *"),
+					resource.TestCheckResourceAttr("postgresql_cron_job.test", "database", dbName),
+					resource.TestCheckResourceAttr("postgresql_cron_job.test", "active", "true"),
+				),
+			},
+			{
+				Config: testAccPostgresqlCronJobConfig("30 4 * * *", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlCronJobExists("postgresql_cron_job.test"),
+					resource.TestCheckResourceAttr("postgresql_cron_job.test", "schedule", "30 4 * * *"),
+					resource.TestCheckResourceAttr("postgresql_cron_job.test", "active", "false"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_cron_job.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testCronPreCheck skips the test if pg_cron is not installed in the database of the provider.
+func testCronPreCheck(t *testing.T) {
+	exists, err := checkCronJob(testAccProvider.Meta().(*Client), "SELECT 1 FROM pg_catalog.pg_extension WHERE extname = 'pg_cron'")
+	if err != nil {
+		t.Fatalf("could not check pg_cron extension: %v", err)
+	}
+	if !exists {
+		t.Skip("Skip test: pg_cron is not installed")
+	}
+}
+
+func testAccCheckPostgresqlCronJobDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_cron_job" {
+			continue
+		}
+
+		exists, err := checkCronJob(client, "SELECT 1 FROM cron.job WHERE jobid = $1", rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking cron job %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Cron job still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlCronJobExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		exists, err := checkCronJob(testAccProvider.Meta().(*Client), "SELECT 1 FROM cron.job WHERE jobid = $1", rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking cron job %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Cron job not found")
+		}
+
+		return nil
+	}
+}
+
+func checkCronJob(client *Client, query string, args ...interface{}) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	var _rez int
+	err = db.QueryRow(query, args...).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
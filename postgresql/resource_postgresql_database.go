@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -28,13 +29,19 @@ const (
 
 func resourcePostgreSQLDatabase() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLDatabaseCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLDatabaseRead),
-		Update: PGResourceFunc(resourcePostgreSQLDatabaseUpdate),
-		Delete: PGResourceFunc(resourcePostgreSQLDatabaseDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLDatabaseExists),
+		CreateContext: PGResourceContextFunc(resourcePostgreSQLDatabaseCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLDatabaseExists, resourcePostgreSQLDatabaseRead),
+		UpdateContext: PGResourceContextFunc(resourcePostgreSQLDatabaseUpdate),
+		DeleteContext: PGResourceContextFunc(resourcePostgreSQLDatabaseDelete),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithOID(readDatabaseNameByOID),
+		},
+		Identity: oidIdentity(nil),
+		// Copying a large template or moving the database to another tablespace can take a while.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultLongRunningTimeout),
+			Update: schema.DefaultTimeout(defaultLongRunningTimeout),
+			Delete: schema.DefaultTimeout(defaultLongRunningTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -106,8 +113,8 @@ func resourcePostgreSQLDatabase() *schema.Resource {
 	}
 }
 
-func resourcePostgreSQLDatabaseCreate(db *DBConnection, d *schema.ResourceData) error {
-	if err := createDatabase(db, d); err != nil {
+func resourcePostgreSQLDatabaseCreate(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
+	if err := createDatabase(ctx, db, d); err != nil {
 		return err
 	}
 
@@ -116,7 +123,7 @@ func resourcePostgreSQLDatabaseCreate(db *DBConnection, d *schema.ResourceData)
 	return resourcePostgreSQLDatabaseReadImpl(db, d)
 }
 
-func createDatabase(db *DBConnection, d *schema.ResourceData) error {
+func createDatabase(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
 	currentUser := db.client.config.getDatabaseUsername()
 	owner := d.Get(dbOwnerAttr).(string)
 
@@ -125,21 +132,26 @@ func createDatabase(db *DBConnection, d *schema.ResourceData) error {
 		// Take a lock on db currentUser to avoid multiple database creation at the same time
 		// It can fail if they grant the same owner to current at the same time as it's not done in transaction.
 		lockTxn, err := startTransaction(db.client, "")
-		if err := pgLockRole(lockTxn, currentUser); err != nil {
+		if err != nil {
+			return err
+		}
+		if err := db.lockRole(lockTxn, currentUser); err != nil {
 			return err
 		}
 		defer deferredRollback(lockTxn)
 
 		// Needed in order to set the owner of the db if the connection user is not a
 		// superuser
-		ownerGranted, err := grantRoleMembership(db, owner, currentUser)
-		if err != nil {
-			return err
-		}
-		if ownerGranted {
-			defer func() {
-				_, err = revokeRoleMembership(db, owner, currentUser)
-			}()
+		if !db.isSuperuser() {
+			ownerGranted, err := grantRoleMembership(db, owner, currentUser)
+			if err != nil {
+				return err
+			}
+			if ownerGranted {
+				defer func() {
+					_, err = revokeRoleMembership(db, owner, currentUser)
+				}()
+			}
 		}
 	}
 
@@ -162,6 +174,8 @@ func createDatabase(db *DBConnection, d *schema.ResourceData) error {
 	case ok && strings.ToUpper(v.(string)) == "DEFAULT":
 		fmt.Fprint(b, " TEMPLATE DEFAULT")
 	case ok:
+		// The template can't be copied while there are connections to it.
+		db.client.closeIdleConnections(v.(string))
 		fmt.Fprint(b, " TEMPLATE ", pq.QuoteIdentifier(v.(string)))
 	case v.(string) == "":
 		fmt.Fprint(b, " TEMPLATE template0")
@@ -217,7 +231,7 @@ func createDatabase(db *DBConnection, d *schema.ResourceData) error {
 	}
 
 	sql := b.String()
-	if _, err := db.Exec(sql); err != nil {
+	if err := db.execOutsideTransaction(ctx, sql); err != nil {
 		return fmt.Errorf("Error creating database %q: %w", dbName, err)
 	}
 
@@ -226,7 +240,7 @@ func createDatabase(db *DBConnection, d *schema.ResourceData) error {
 	return err
 }
 
-func resourcePostgreSQLDatabaseDelete(db *DBConnection, d *schema.ResourceData) error {
+func resourcePostgreSQLDatabaseDelete(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
 	currentUser := db.client.config.getDatabaseUsername()
 	owner := d.Get(dbOwnerAttr).(string)
 
@@ -234,21 +248,26 @@ func resourcePostgreSQLDatabaseDelete(db *DBConnection, d *schema.ResourceData)
 	var err error
 	if owner != "" {
 		lockTxn, err := startTransaction(db.client, "")
-		if err := pgLockRole(lockTxn, currentUser); err != nil {
+		if err != nil {
+			return err
+		}
+		if err := db.lockRole(lockTxn, currentUser); err != nil {
 			return err
 		}
 		defer deferredRollback(lockTxn)
 
 		// Needed in order to set the owner of the db if the connection user is not a
 		// superuser
-		ownerGranted, err := grantRoleMembership(db, owner, currentUser)
-		if err != nil {
-			return err
-		}
-		if ownerGranted {
-			defer func() {
-				_, err = revokeRoleMembership(db, owner, currentUser)
-			}()
+		if !db.isSuperuser() {
+			ownerGranted, err := grantRoleMembership(db, owner, currentUser)
+			if err != nil {
+				return err
+			}
+			if ownerGranted {
+				defer func() {
+					_, err = revokeRoleMembership(db, owner, currentUser)
+				}()
+			}
 		}
 	}
 
@@ -267,6 +286,8 @@ func resourcePostgreSQLDatabaseDelete(db *DBConnection, d *schema.ResourceData)
 		return err
 	}
 
+	db.client.closeIdleConnections(dbName)
+
 	// Terminate all active connections and block new one
 	if err := terminateBConnections(db, dbName); err != nil {
 		return err
@@ -278,7 +299,7 @@ func resourcePostgreSQLDatabaseDelete(db *DBConnection, d *schema.ResourceData)
 	}
 
 	sql := fmt.Sprintf("DROP DATABASE %s %s", pq.QuoteIdentifier(dbName), dropWithForce)
-	if _, err := db.Exec(sql); err != nil {
+	if err := db.execOutsideTransaction(ctx, sql); err != nil {
 		return fmt.Errorf("Error dropping database: %w", err)
 	}
 
@@ -302,10 +323,25 @@ func resourcePostgreSQLDatabaseRead(db *DBConnection, d *schema.ResourceData) er
 	return resourcePostgreSQLDatabaseReadImpl(db, d)
 }
 
+// readDatabaseNameByOID returns the name of the database with the OID of the identity.
+func readDatabaseNameByOID(db *DBConnection, identity *schema.IdentityData) (string, error) {
+	var dbName string
+	oid := identity.Get(identityOIDAttr).(int)
+	err := db.QueryRow("SELECT datname FROM pg_catalog.pg_database WHERE oid=$1", oid).Scan(&dbName)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", fmt.Errorf("database with OID %d not found", oid)
+	case err != nil:
+		return "", fmt.Errorf("Error reading database: %w", err)
+	}
+	return dbName, nil
+}
+
 func resourcePostgreSQLDatabaseReadImpl(db *DBConnection, d *schema.ResourceData) error {
 	dbId := d.Id()
 	var dbName, ownerName string
-	err := db.QueryRow("SELECT d.datname, pg_catalog.pg_get_userbyid(d.datdba) from pg_database d WHERE datname=$1", dbId).Scan(&dbName, &ownerName)
+	var dbOID int
+	err := db.QueryRow("SELECT d.oid, d.datname, pg_catalog.pg_get_userbyid(d.datdba) from pg_database d WHERE datname=$1", dbId).Scan(&dbOID, &dbName, &ownerName)
 	switch {
 	case err == sql.ErrNoRows:
 		log.Printf("[WARN] PostgreSQL database (%q) not found", dbId)
@@ -322,13 +358,14 @@ func resourcePostgreSQLDatabaseReadImpl(db *DBConnection, d *schema.ResourceData
 		"pg_catalog.pg_encoding_to_char(d.encoding)",
 		"d.datcollate",
 		"d.datctype",
-		"ts.spcname",
+		"COALESCE(ts.spcname, 'pg_default')",
 		"d.datconnlimit",
 	}
 
+	// CockroachDB has no tablespaces.
 	dbSQLFmt := `SELECT %s ` +
-		`FROM pg_catalog.pg_database AS d, pg_catalog.pg_tablespace AS ts ` +
-		`WHERE d.datname = $1 AND d.dattablespace = ts.oid`
+		`FROM pg_catalog.pg_database AS d LEFT JOIN pg_catalog.pg_tablespace AS ts ON d.dattablespace = ts.oid ` +
+		`WHERE d.datname = $1`
 	dbSQL := fmt.Sprintf(dbSQLFmt, strings.Join(columns, ", "))
 	err = db.QueryRow(dbSQL, dbId).
 		Scan(
@@ -349,6 +386,9 @@ func resourcePostgreSQLDatabaseReadImpl(db *DBConnection, d *schema.ResourceData
 
 	d.Set(dbNameAttr, dbName)
 	d.Set(dbOwnerAttr, ownerName)
+	if err := setIdentity(d, map[string]interface{}{identityOIDAttr: dbOID}); err != nil {
+		return err
+	}
 	d.Set(dbEncodingAttr, dbEncoding)
 	d.Set(dbCollationAttr, dbCollation)
 	d.Set(dbCTypeAttr, dbCType)
@@ -385,7 +425,7 @@ func resourcePostgreSQLDatabaseReadImpl(db *DBConnection, d *schema.ResourceData
 	return nil
 }
 
-func resourcePostgreSQLDatabaseUpdate(db *DBConnection, d *schema.ResourceData) error {
+func resourcePostgreSQLDatabaseUpdate(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
 	if err := setDBName(db, d); err != nil {
 		return err
 	}
@@ -394,7 +434,7 @@ func resourcePostgreSQLDatabaseUpdate(db *DBConnection, d *schema.ResourceData)
 		return err
 	}
 
-	if err := setDBTablespace(db, d); err != nil {
+	if err := setDBTablespace(ctx, db, d); err != nil {
 		return err
 	}
 
@@ -415,7 +455,7 @@ func resourcePostgreSQLDatabaseUpdate(db *DBConnection, d *schema.ResourceData)
 	return resourcePostgreSQLDatabaseReadImpl(db, d)
 }
 
-func setDBName(db QueryAble, d *schema.ResourceData) error {
+func setDBName(db *DBConnection, d *schema.ResourceData) error {
 	if !d.HasChange(dbNameAttr) {
 		return nil
 	}
@@ -427,6 +467,9 @@ func setDBName(db QueryAble, d *schema.ResourceData) error {
 		return errors.New("Error setting database name to an empty string")
 	}
 
+	// A database can't be renamed while there are connections to it.
+	db.client.closeIdleConnections(o)
+
 	sql := fmt.Sprintf("ALTER DATABASE %s RENAME TO %s", pq.QuoteIdentifier(o), pq.QuoteIdentifier(n))
 	if _, err := db.Exec(sql); err != nil {
 		return fmt.Errorf("Error updating database name: %w", err)
@@ -448,20 +491,22 @@ func setDBOwner(db *DBConnection, d *schema.ResourceData) error {
 	currentUser := db.client.config.getDatabaseUsername()
 
 	lockTxn, err := startTransaction(db.client, "")
-	if err := pgLockRole(lockTxn, currentUser); err != nil {
+	if err := db.lockRole(lockTxn, currentUser); err != nil {
 		return err
 	}
 	defer deferredRollback(lockTxn)
 
 	//needed in order to set the owner of the db if the connection user is not a superuser
-	ownerGranted, err := grantRoleMembership(db, owner, currentUser)
-	if err != nil {
-		return err
-	}
-	if ownerGranted {
-		defer func() {
-			_, err = revokeRoleMembership(db, owner, currentUser)
-		}()
+	if !db.isSuperuser() {
+		ownerGranted, err := grantRoleMembership(db, owner, currentUser)
+		if err != nil {
+			return err
+		}
+		if ownerGranted {
+			defer func() {
+				_, err = revokeRoleMembership(db, owner, currentUser)
+			}()
+		}
 	}
 
 	dbName := d.Get(dbNameAttr).(string)
@@ -473,7 +518,7 @@ func setDBOwner(db *DBConnection, d *schema.ResourceData) error {
 	return err
 }
 
-func setDBTablespace(db QueryAble, d *schema.ResourceData) error {
+func setDBTablespace(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
 	if !d.HasChange(dbTablespaceAttr) {
 		return nil
 	}
@@ -487,7 +532,8 @@ func setDBTablespace(db QueryAble, d *schema.ResourceData) error {
 		sql = fmt.Sprintf("ALTER DATABASE %s SET TABLESPACE %s", pq.QuoteIdentifier(dbName), pq.QuoteIdentifier(tbspName))
 	}
 
-	if _, err := db.Exec(sql); err != nil {
+	// ALTER DATABASE SET TABLESPACE cannot be executed inside a transaction block.
+	if err := db.execOutsideTransaction(ctx, sql); err != nil {
 		return fmt.Errorf("Error updating database TABLESPACE: %w", err)
 	}
 
@@ -556,6 +602,12 @@ func doSetDBIsTemplate(db *DBConnection, dbName string, isTemplate bool) error {
 func terminateBConnections(db *DBConnection, dbName string) error {
 	var terminateSql string
 
+	// CockroachDB has no pg_terminate_backend and drops the databases
+	// with their open sessions.
+	if db.isCockroachDB() {
+		return nil
+	}
+
 	if db.featureSupported(featureDBAllowConnections) {
 		alterSql := fmt.Sprintf("ALTER DATABASE %s ALLOW_CONNECTIONS false", pq.QuoteIdentifier(dbName))
 
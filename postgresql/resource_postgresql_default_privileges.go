@@ -15,10 +15,13 @@ import (
 
 func resourcePostgreSQLDefaultPrivileges() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesCreate),
-		Update: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLDefaultPrivilegesRead),
-		Delete: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesDelete),
+		CreateContext: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesCreate),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLDefaultPrivilegesRead),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLDefaultPrivilegesDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -77,6 +80,14 @@ func resourcePostgreSQLDefaultPrivileges() *schema.Resource {
 }
 
 func resourcePostgreSQLDefaultPrivilegesRead(db *DBConnection, d *schema.ResourceData) error {
+	// When importing, we have to parse the ID to find the default privileges.
+	if d.Get("role").(string) == "" {
+		if err := setDefaultPrivilegesFromImportID(d); err != nil {
+			return err
+		}
+		d.SetId(generateDefaultPrivilegesID(d))
+	}
+
 	pgSchema := d.Get("schema").(string)
 	objectType := d.Get("object_type").(string)
 
@@ -229,7 +240,7 @@ func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	var queryArgs []interface{}
 
 	if pgSchema != "" {
-		query = `SELECT array_agg(prtype) FROM (
+		query = `SELECT array_agg(prtype), bool_and(grantable) FROM (
 		SELECT defaclnamespace, (aclexplode(defaclacl)).* FROM pg_default_acl
 		WHERE defaclobjtype = $3
 	) AS t (namespace, grantor_oid, grantee_oid, prtype, grantable)
@@ -238,7 +249,7 @@ func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 `
 		queryArgs = []interface{}{roleOID, pgSchema, objectTypes[objectType], owner}
 	} else {
-		query = `SELECT array_agg(prtype) FROM (
+		query = `SELECT array_agg(prtype), bool_and(grantable) FROM (
 		SELECT defaclnamespace, (aclexplode(defaclacl)).* FROM pg_default_acl
 		WHERE defaclobjtype = $2
 	) AS t (namespace, grantor_oid, grantee_oid, prtype, grantable)
@@ -250,11 +261,13 @@ func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	// This query aggregates the list of default privileges type (prtype)
 	// for the role (grantee), owner (grantor), schema (namespace name)
 	// and the specified object type (defaclobjtype).
+	// It also checks if all these privileges are grantable.
 
 	var privileges pq.ByteaArray
+	var grantable sql.NullBool
 	if err := txn.QueryRow(
 		query, queryArgs...,
-	).Scan(&privileges); err != nil {
+	).Scan(&privileges, &grantable); err != nil {
 		return fmt.Errorf("could not read default privileges: %w", err)
 	}
 
@@ -269,6 +282,9 @@ func readRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 
 	privilegesSet := pgArrayToSet(privileges)
 	d.Set("privileges", privilegesSet)
+	if grantable.Valid {
+		d.Set("with_grant_option", grantable.Bool)
+	}
 	d.SetId(generateDefaultPrivilegesID(d))
 
 	return nil
@@ -340,6 +356,29 @@ func revokeRoleDefaultPrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+// setDefaultPrivilegesFromImportID sets the arguments of the default privileges from
+// their import ID: role/database/owner/object_type, or role/database/schema/owner/object_type.
+func setDefaultPrivilegesFromImportID(d *schema.ResourceData) error {
+	parsed := strings.Split(d.Id(), "/")
+	switch len(parsed) {
+	case 4:
+		parsed = []string{parsed[0], parsed[1], "", parsed[2], parsed[3]}
+	case 5:
+	default:
+		return fmt.Errorf("default privileges ID %s has not the expected format 'role/database/[schema/]owner/object_type': %v", d.Id(), parsed)
+	}
+	if _, ok := objectTypes[parsed[4]]; !ok {
+		return fmt.Errorf("invalid object type %s in default privileges ID %s", parsed[4], d.Id())
+	}
+
+	d.Set("role", parsed[0])
+	d.Set("database", parsed[1])
+	d.Set("schema", parsed[2])
+	d.Set("owner", parsed[3])
+	d.Set("object_type", parsed[4])
+	return nil
+}
+
 func generateDefaultPrivilegesID(d *schema.ResourceData) string {
 	pgSchema := d.Get("schema").(string)
 	if pgSchema == "" {
@@ -5,9 +5,46 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestSetDefaultPrivilegesFromImportID(t *testing.T) {
+	var tests = []struct {
+		id      string
+		schema  string
+		wantErr bool
+	}{
+		{id: "test_role/test_db/test_owner/table"},
+		{id: "test_role/test_db/test_schema/test_owner/table", schema: "test_schema"},
+		{id: "test_role/test_db/test_owner", wantErr: true},
+		{id: "test_role/test_db/test_owner/view", wantErr: true},
+	}
+
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLDefaultPrivileges().Schema, map[string]interface{}{})
+		d.SetId(test.id)
+
+		err := setDefaultPrivilegesFromImportID(d)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("setDefaultPrivilegesFromImportID(%s) should have failed", test.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("setDefaultPrivilegesFromImportID(%s) returned an error: %v", test.id, err)
+			continue
+		}
+
+		if d.Get("role") != "test_role" || d.Get("database") != "test_db" || d.Get("schema") != test.schema ||
+			d.Get("owner") != "test_owner" || d.Get("object_type") != "table" {
+			t.Errorf("setDefaultPrivilegesFromImportID(%s) set role %v, database %v, schema %v, owner %v, object_type %v",
+				test.id, d.Get("role"), d.Get("database"), d.Get("schema"), d.Get("owner"), d.Get("object_type"))
+		}
+	}
+}
+
 func TestAccPostgresqlDefaultPrivileges(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -100,6 +137,12 @@ resource "postgresql_default_privileges" "test_ro" {
 							resource.TestCheckResourceAttr("postgresql_default_privileges.test_ro", "privileges.1", "UPDATE"),
 						),
 					},
+					{
+						ResourceName:      "postgresql_default_privileges.test_ro",
+						ImportState:       true,
+						ImportStateId:     fmt.Sprintf("%s/%s/test_schema/%s/table", role, dbName, config.Username),
+						ImportStateVerify: true,
+					},
 					{
 						Config: fmt.Sprintf(tfConfig, `[]`),
 						Check: resource.ComposeTestCheckFunc(
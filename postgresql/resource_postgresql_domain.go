@@ -0,0 +1,452 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	domainNameAttr            = "name"
+	domainSchemaAttr          = "schema"
+	domainDatabaseAttr        = "database"
+	domainBaseTypeAttr        = "base_type"
+	domainDefaultAttr         = "default"
+	domainNotNullAttr         = "not_null"
+	domainConstraintAttr      = "constraint"
+	domainConstraintNameAttr  = "name"
+	domainConstraintCheckAttr = "check"
+	domainDropCascadeAttr     = "drop_cascade"
+)
+
+func resourcePostgreSQLDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLDomainCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLDomainExists, resourcePostgreSQLDomainRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLDomainUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLDomainDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			domainNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the domain",
+			},
+			domainSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the domain is located",
+			},
+			domainDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the domain is located",
+			},
+			domainBaseTypeAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The underlying data type of the domain",
+			},
+			domainDefaultAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default value expression of the domain",
+			},
+			domainNotNullAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the values of the domain are prevented from being null",
+			},
+			domainConstraintAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						domainConstraintNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the constraint",
+						},
+						domainConstraintCheckAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The boolean expression of the CHECK constraint, VALUE refers to the value being tested",
+						},
+					},
+				},
+				Description: "The named CHECK constraints of the domain",
+			},
+			domainDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the domain, and in turn all objects that depend on those objects",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLDomainCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE DOMAIN ")
+	fmt.Fprint(b, getDomainQualifiedName(d), " AS ", d.Get(domainBaseTypeAttr).(string))
+
+	if def := d.Get(domainDefaultAttr).(string); def != "" {
+		fmt.Fprint(b, " DEFAULT ", def)
+	}
+
+	if d.Get(domainNotNullAttr).(bool) {
+		fmt.Fprint(b, " NOT NULL")
+	}
+
+	for _, constraint := range d.Get(domainConstraintAttr).(*schema.Set).List() {
+		fmt.Fprint(b, " ", getDomainConstraintDefinition(constraint.(map[string]interface{})))
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create domain %s: %w", d.Get(domainNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating domain: %w", err)
+	}
+
+	d.SetId(generateDomainID(d, database))
+
+	return resourcePostgreSQLDomainReadImpl(db, d)
+}
+
+func resourcePostgreSQLDomainExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, domainSchema, domainName, err := getDBDomainName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return typeExists(txn, domainSchema, domainName, "d")
+}
+
+func resourcePostgreSQLDomainRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLDomainReadImpl(db, d)
+}
+
+func resourcePostgreSQLDomainReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, domainSchema, domainName, err := getDBDomainName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var domainOID int
+	var baseType string
+	var def sql.NullString
+	var notNull bool
+
+	query := `SELECT t.oid, pg_catalog.format_type(t.typbasetype, t.typtypmod), t.typdefault, t.typnotnull ` +
+		`FROM pg_catalog.pg_type t ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace ` +
+		`WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = 'd'`
+	err = txn.QueryRow(query, domainSchema, domainName).Scan(&domainOID, &baseType, &def, &notNull)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL domain (%s.%s) not found for database %s", domainSchema, domainName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading domain: %w", err)
+	}
+
+	constraints, err := getDomainConstraints(txn, domainOID, d)
+	if err != nil {
+		return err
+	}
+
+	// PostgreSQL normalizes the type name (e.g.: int becomes integer),
+	// so we only set it when importing.
+	if d.Get(domainBaseTypeAttr).(string) == "" {
+		d.Set(domainBaseTypeAttr, baseType)
+	}
+
+	// Same for the default expression which is only replaced if it has been added or removed.
+	if (d.Get(domainDefaultAttr).(string) == "") == def.Valid {
+		d.Set(domainDefaultAttr, def.String)
+	}
+
+	d.Set(domainNameAttr, domainName)
+	d.Set(domainSchemaAttr, domainSchema)
+	d.Set(domainDatabaseAttr, database)
+	d.Set(domainNotNullAttr, notNull)
+	d.Set(domainConstraintAttr, constraints)
+
+	return nil
+}
+
+func resourcePostgreSQLDomainUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setDomainDefault(txn, d); err != nil {
+		return err
+	}
+
+	if err := setDomainNotNull(txn, d); err != nil {
+		return err
+	}
+
+	if err := setDomainConstraints(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating domain: %w", err)
+	}
+
+	return resourcePostgreSQLDomainReadImpl(db, d)
+}
+
+func resourcePostgreSQLDomainDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(domainDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP DOMAIN %s %s", getDomainQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop domain %s: %w", d.Get(domainNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting domain: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setDomainDefault(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(domainDefaultAttr) {
+		return nil
+	}
+
+	action := "DROP DEFAULT"
+	if def := d.Get(domainDefaultAttr).(string); def != "" {
+		action = "SET DEFAULT " + def
+	}
+
+	sql := fmt.Sprintf("ALTER DOMAIN %s %s", getDomainQualifiedName(d), action)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating domain default: %w", err)
+	}
+
+	return nil
+}
+
+func setDomainNotNull(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(domainNotNullAttr) {
+		return nil
+	}
+
+	action := "DROP NOT NULL"
+	if d.Get(domainNotNullAttr).(bool) {
+		action = "SET NOT NULL"
+	}
+
+	sql := fmt.Sprintf("ALTER DOMAIN %s %s", getDomainQualifiedName(d), action)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating domain not null: %w", err)
+	}
+
+	return nil
+}
+
+// setDomainConstraints drops the constraints which have been removed or changed
+// and adds the new ones.
+func setDomainConstraints(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(domainConstraintAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(domainConstraintAttr)
+	oldConstraints := oraw.(*schema.Set)
+	newConstraints := nraw.(*schema.Set)
+
+	for _, constraint := range oldConstraints.Difference(newConstraints).List() {
+		name := constraint.(map[string]interface{})[domainConstraintNameAttr].(string)
+		sql := fmt.Sprintf("ALTER DOMAIN %s DROP CONSTRAINT %s", getDomainQualifiedName(d), pq.QuoteIdentifier(name))
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("could not drop constraint %s of domain: %w", name, err)
+		}
+	}
+
+	for _, constraint := range newConstraints.Difference(oldConstraints).List() {
+		sql := fmt.Sprintf(
+			"ALTER DOMAIN %s ADD %s",
+			getDomainQualifiedName(d), getDomainConstraintDefinition(constraint.(map[string]interface{})),
+		)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("could not add constraint to domain: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getDomainConstraints reads the CHECK constraints of the domain.
+// As PostgreSQL normalizes the expressions, the check of a constraint
+// already in the state is kept, the expression from the server is only
+// used for new constraints (e.g.: when importing).
+func getDomainConstraints(txn *sql.Tx, domainOID int, d *schema.ResourceData) ([]interface{}, error) {
+	knownChecks := map[string]string{}
+	for _, constraint := range d.Get(domainConstraintAttr).(*schema.Set).List() {
+		c := constraint.(map[string]interface{})
+		knownChecks[c[domainConstraintNameAttr].(string)] = c[domainConstraintCheckAttr].(string)
+	}
+
+	query := `SELECT conname, pg_catalog.pg_get_constraintdef(oid) ` +
+		`FROM pg_catalog.pg_constraint ` +
+		`WHERE contypid = $1 AND contype = 'c' ` +
+		`ORDER BY conname`
+	rows, err := txn.Query(query, domainOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read constraints of domain: %w", err)
+	}
+	defer rows.Close()
+
+	constraints := []interface{}{}
+	for rows.Next() {
+		var name, definition string
+		if err := rows.Scan(&name, &definition); err != nil {
+			return nil, fmt.Errorf("could not scan constraint of domain: %w", err)
+		}
+
+		check, ok := knownChecks[name]
+		if !ok {
+			// The definition is in the form: CHECK (expression)
+			check = strings.TrimSuffix(strings.TrimPrefix(definition, "CHECK ("), ")")
+		}
+
+		constraints = append(constraints, map[string]interface{}{
+			domainConstraintNameAttr:  name,
+			domainConstraintCheckAttr: check,
+		})
+	}
+
+	return constraints, rows.Err()
+}
+
+func getDomainConstraintDefinition(constraint map[string]interface{}) string {
+	return fmt.Sprintf(
+		"CONSTRAINT %s CHECK (%s)",
+		pq.QuoteIdentifier(constraint[domainConstraintNameAttr].(string)),
+		constraint[domainConstraintCheckAttr].(string),
+	)
+}
+
+// typeExists checks if a type of the given kind (pg_type.typtype) exists.
+func typeExists(txn *sql.Tx, typeSchema, typeName, typeKind string) (bool, error) {
+	var _rez bool
+
+	query := `SELECT TRUE FROM pg_catalog.pg_type t ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace ` +
+		`WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = $3`
+	err := txn.QueryRow(query, typeSchema, typeName, typeKind).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func getDomainQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(domainSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(domainNameAttr).(string)),
+	)
+}
+
+func generateDomainID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(domainSchemaAttr).(string),
+		d.Get(domainNameAttr).(string),
+	}, ".")
+}
+
+// getDBDomainName returns the database, schema and name of the domain. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBDomainName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	domainSchema := d.Get(domainSchemaAttr).(string)
+	domainName := d.Get(domainNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the domain, schema and database names.
+	if domainName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("domain ID %s has not the expected format 'database.schema.domain': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		domainSchema = parsed[1]
+		domainName = parsed[2]
+	}
+
+	return database, domainSchema, domainName, nil
+}
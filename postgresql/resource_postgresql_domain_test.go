@@ -0,0 +1,160 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlDomain_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_domain" "test" {
+					name      = "positive_int"
+					database  = "%s"
+					base_type = "integer"
+					not_null  = true
+					constraint {
+						name  = "positive"
+						check = "VALUE > 0"
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlDomainExists("postgresql_domain.test"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "id", fmt.Sprintf("%s.public.positive_int", dbName)),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "base_type", "integer"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "default", ""),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "not_null", "true"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "constraint.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_domain" "test" {
+					name      = "positive_int"
+					database  = "%s"
+					base_type = "integer"
+					default   = "1"
+					constraint {
+						name  = "positive"
+						check = "VALUE > 0"
+					}
+					constraint {
+						name  = "small"
+						check = "VALUE < 1000"
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlDomainExists("postgresql_domain.test"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "default", "1"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "not_null", "false"),
+					resource.TestCheckResourceAttr("postgresql_domain.test", "constraint.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_domain.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{domainDropCascadeAttr},
+			},
+			{
+				// Drop a constraint outside of Terraform, the next plan should add it again.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "ALTER DOMAIN positive_int DROP CONSTRAINT small")
+				},
+				Config: fmt.Sprintf(`
+				resource "postgresql_domain" "test" {
+					name      = "positive_int"
+					database  = "%s"
+					base_type = "integer"
+					default   = "1"
+					constraint {
+						name  = "positive"
+						check = "VALUE > 0"
+					}
+					constraint {
+						name  = "small"
+						check = "VALUE < 1000"
+					}
+				}`, dbName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlDomainDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_domain" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[domainDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := typeExists(txn, rs.Primary.Attributes[domainSchemaAttr], rs.Primary.Attributes[domainNameAttr], "d")
+		if err != nil {
+			return fmt.Errorf("Error checking domain %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Domain still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlDomainExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[domainDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := typeExists(txn, rs.Primary.Attributes[domainSchemaAttr], rs.Primary.Attributes[domainNameAttr], "d")
+		if err != nil {
+			return fmt.Errorf("Error checking domain %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Domain not found")
+		}
+
+		return nil
+	}
+}
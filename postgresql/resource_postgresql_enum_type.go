@@ -0,0 +1,311 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	enumNameAttr        = "name"
+	enumSchemaAttr      = "schema"
+	enumDatabaseAttr    = "database"
+	enumValuesAttr      = "values"
+	enumDropCascadeAttr = "drop_cascade"
+)
+
+func resourcePostgreSQLEnumType() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLEnumTypeCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLEnumTypeExists, resourcePostgreSQLEnumTypeRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLEnumTypeUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLEnumTypeDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// PostgreSQL can add values to an enum but it cannot remove or reorder them.
+		CustomizeDiff: customdiff.ForceNewIfChange(enumValuesAttr, func(_ context.Context, old, new, _ interface{}) bool {
+			return !enumValuesCanBeAdded(old.([]interface{}), new.([]interface{}))
+		}),
+
+		Schema: map[string]*schema.Schema{
+			enumNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the enum type",
+			},
+			enumSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the enum type is located",
+			},
+			enumDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the enum type is located",
+			},
+			enumValuesAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ordered list of the values of the enum type",
+			},
+			enumDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the enum type, and in turn all objects that depend on those objects",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLEnumTypeCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	values := []string{}
+	for _, value := range d.Get(enumValuesAttr).([]interface{}) {
+		values = append(values, fmt.Sprintf("'%s'", pqQuoteLiteral(value.(string))))
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", getEnumTypeQualifiedName(d), strings.Join(values, ", "))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not create enum type %s: %w", d.Get(enumNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating enum type: %w", err)
+	}
+
+	d.SetId(generateEnumTypeID(d, database))
+
+	return resourcePostgreSQLEnumTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLEnumTypeExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, enumSchema, enumName, err := getDBEnumTypeName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return typeExists(txn, enumSchema, enumName, "e")
+}
+
+func resourcePostgreSQLEnumTypeRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLEnumTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLEnumTypeReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, enumSchema, enumName, err := getDBEnumTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	exists, err := typeExists(txn, enumSchema, enumName, "e")
+	if err != nil {
+		return fmt.Errorf("Error reading enum type: %w", err)
+	}
+	if !exists {
+		log.Printf("[WARN] PostgreSQL enum type (%s.%s) not found for database %s", enumSchema, enumName, database)
+		d.SetId("")
+		return nil
+	}
+
+	query := `SELECT e.enumlabel ` +
+		`FROM pg_catalog.pg_enum e ` +
+		`JOIN pg_catalog.pg_type t ON t.oid = e.enumtypid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace ` +
+		`WHERE n.nspname = $1 AND t.typname = $2 ` +
+		`ORDER BY e.enumsortorder`
+	rows, err := txn.Query(query, enumSchema, enumName)
+	if err != nil {
+		return fmt.Errorf("Error reading enum type values: %w", err)
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return fmt.Errorf("could not scan enum type value: %w", err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set(enumNameAttr, enumName)
+	d.Set(enumSchemaAttr, enumSchema)
+	d.Set(enumDatabaseAttr, database)
+	d.Set(enumValuesAttr, values)
+
+	return nil
+}
+
+func resourcePostgreSQLEnumTypeUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(enumValuesAttr) {
+		return resourcePostgreSQLEnumTypeReadImpl(db, d)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	conn, err := connectToDatabase(db.client, database)
+	if err != nil {
+		return err
+	}
+
+	oraw, nraw := d.GetChange(enumValuesAttr)
+	oldValues := map[string]bool{}
+	for _, value := range oraw.([]interface{}) {
+		oldValues[value.(string)] = true
+	}
+	newValues := nraw.([]interface{})
+
+	// Each new value is added after the previous one in the list (or before the next one if it is the first),
+	// as the values are added in order, the previous value always exists.
+	for i, value := range newValues {
+		if oldValues[value.(string)] {
+			continue
+		}
+
+		position := ""
+		if i > 0 {
+			position = fmt.Sprintf(" AFTER '%s'", pqQuoteLiteral(newValues[i-1].(string)))
+		} else if len(newValues) > 1 {
+			position = fmt.Sprintf(" BEFORE '%s'", pqQuoteLiteral(newValues[1].(string)))
+		}
+
+		sql := fmt.Sprintf(
+			"ALTER TYPE %s ADD VALUE '%s'%s",
+			getEnumTypeQualifiedName(d), pqQuoteLiteral(value.(string)), position,
+		)
+		// Before PostgreSQL 12, ALTER TYPE ... ADD VALUE cannot be executed inside a transaction block.
+		if conn.featureSupported(featureEnumAddValueInTransaction) {
+			_, err = conn.Exec(sql)
+		} else {
+			err = conn.execOutsideTransaction(conn.client.ctx, sql)
+		}
+		if err != nil {
+			return fmt.Errorf("could not add value %s to enum type: %w", value.(string), err)
+		}
+	}
+
+	return resourcePostgreSQLEnumTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLEnumTypeDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(enumDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP TYPE %s %s", getEnumTypeQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop enum type %s: %w", d.Get(enumNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting enum type: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// enumValuesCanBeAdded returns true if the new values only add values to the old ones,
+// i.e.: all the old values are still present and in the same order.
+func enumValuesCanBeAdded(oldValues, newValues []interface{}) bool {
+	i := 0
+	for _, value := range newValues {
+		if i < len(oldValues) && value == oldValues[i] {
+			i++
+		}
+	}
+
+	return i == len(oldValues)
+}
+
+func getEnumTypeQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(enumSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(enumNameAttr).(string)),
+	)
+}
+
+func generateEnumTypeID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(enumSchemaAttr).(string),
+		d.Get(enumNameAttr).(string),
+	}, ".")
+}
+
+// getDBEnumTypeName returns the database, schema and name of the enum type. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBEnumTypeName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	enumSchema := d.Get(enumSchemaAttr).(string)
+	enumName := d.Get(enumNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the enum type, schema and database names.
+	if enumName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("enum type ID %s has not the expected format 'database.schema.enum': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		enumSchema = parsed[1]
+		enumName = parsed[2]
+	}
+
+	return database, enumSchema, enumName, nil
+}
@@ -0,0 +1,268 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlEnumType_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlEnumTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlEnumTypeConfig(dbName, `"low", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEnumTypeExists("postgresql_enum_type.test"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "id", fmt.Sprintf("%s.public.priority", dbName)),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.0", "low"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.1", "high"),
+				),
+			},
+			{
+				// Values are added in place, at the right position.
+				Config: testAccPostgresqlEnumTypeConfig(dbName, `"lowest", "low", "medium", "high", "highest"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEnumTypeExists("postgresql_enum_type.test"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "5"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.0", "lowest"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.2", "medium"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.4", "highest"),
+				),
+			},
+			{
+				// Removing a value recreates the type.
+				Config: testAccPostgresqlEnumTypeConfig(dbName, `"low", "medium", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEnumTypeExists("postgresql_enum_type.test"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "3"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.0", "low"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_enum_type.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{enumDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlEnumType_ValuesChangedOutside(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlEnumTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlEnumTypeConfig(dbName, `"low", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEnumTypeExists("postgresql_enum_type.test"),
+				),
+			},
+			{
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "ALTER TYPE priority ADD VALUE 'medium' BEFORE 'high'")
+				},
+				Config:             testAccPostgresqlEnumTypeConfig(dbName, `"low", "high"`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlEnumType_TransactionPooling(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT CREATE ON SCHEMA public TO %s", roleName))
+
+	// The role and the timeouts are set in each transaction,
+	// including the one adding the values, which requires to own the type.
+	providerConfig := fmt.Sprintf(`
+provider "postgresql" {
+  transaction_pooling = true
+  assume_role         = "%s"
+  statement_timeout   = 60000
+}
+`, roleName)
+	ownerQuery := "SELECT pg_catalog.pg_get_userbyid(typowner) FROM pg_catalog.pg_type WHERE typname = $1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlEnumTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccPostgresqlEnumTypeConfig(dbName, `"low", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEnumTypeExists("postgresql_enum_type.test"),
+					testCheckOwner(t, dbName, ownerQuery, "priority", roleName),
+				),
+			},
+			{
+				Config: providerConfig + testAccPostgresqlEnumTypeConfig(dbName, `"low", "medium", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "3"),
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.1", "medium"),
+					testCheckOwner(t, dbName, ownerQuery, "priority", roleName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlEnumType_AssumeRole(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("GRANT CREATE ON SCHEMA public TO %s", roleName))
+
+	// With transaction_pooling, the connections to the database are shared with the provider,
+	// the role of the resource is set in its transactions.
+	testConfig := func(values string) string {
+		return fmt.Sprintf(`
+provider "postgresql" {
+  transaction_pooling = true
+}
+
+resource "postgresql_enum_type" "test" {
+  name        = "priority"
+  database    = "%s"
+  values      = [%s]
+  assume_role = "%s"
+}
+`, dbName, values, roleName)
+	}
+	ownerQuery := "SELECT pg_catalog.pg_get_userbyid(typowner) FROM pg_catalog.pg_type WHERE typname = $1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlEnumTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(`"low", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEnumTypeExists("postgresql_enum_type.test"),
+					testCheckOwner(t, dbName, ownerQuery, "priority", roleName),
+				),
+			},
+			{
+				Config: testConfig(`"low", "medium", "high"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_enum_type.test", "values.#", "3"),
+					testCheckOwner(t, dbName, ownerQuery, "priority", roleName),
+				),
+			},
+		},
+	})
+}
+
+func testAccPostgresqlEnumTypeConfig(dbName, values string) string {
+	return fmt.Sprintf(`
+resource "postgresql_enum_type" "test" {
+  name     = "priority"
+  database = "%s"
+  values   = [%s]
+}
+`, dbName, values)
+}
+
+func testAccCheckPostgresqlEnumTypeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_enum_type" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[enumDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := typeExists(txn, rs.Primary.Attributes[enumSchemaAttr], rs.Primary.Attributes[enumNameAttr], "e")
+		if err != nil {
+			return fmt.Errorf("Error checking enum type %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Enum type still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlEnumTypeExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[enumDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := typeExists(txn, rs.Primary.Attributes[enumSchemaAttr], rs.Primary.Attributes[enumNameAttr], "e")
+		if err != nil {
+			return fmt.Errorf("Error checking enum type %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Enum type not found")
+		}
+
+		return nil
+	}
+}
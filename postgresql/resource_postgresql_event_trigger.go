@@ -0,0 +1,344 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	eventTriggerNameAttr           = "name"
+	eventTriggerDatabaseAttr       = "database"
+	eventTriggerEventAttr          = "event"
+	eventTriggerTagsAttr           = "tags"
+	eventTriggerFunctionAttr       = "function"
+	eventTriggerFunctionSchemaAttr = "function_schema"
+	eventTriggerOwnerAttr          = "owner"
+	eventTriggerEnabledAttr        = "enabled"
+)
+
+var allowedEventTriggerEvents = []string{"ddl_command_start", "ddl_command_end", "sql_drop", "table_rewrite"}
+
+func resourcePostgreSQLEventTrigger() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLEventTriggerCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLEventTriggerExists, resourcePostgreSQLEventTriggerRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLEventTriggerUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLEventTriggerDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			eventTriggerNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the event trigger",
+			},
+			eventTriggerDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the event trigger is located",
+			},
+			eventTriggerEventAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(allowedEventTriggerEvents, false),
+				Description:  "The event that fires the trigger (any of: " + strings.Join(allowedEventTriggerEvents, ", ") + ")",
+			},
+			eventTriggerTagsAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					// PostgreSQL stores the tags in upper case.
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z ]+$`), "must be an upper case command tag"),
+				},
+				Set:         schema.HashString,
+				Description: "The command tags (e.g.: CREATE TABLE) for which the trigger will fire. Fires for all the commands by default",
+			},
+			eventTriggerFunctionAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the event trigger function",
+			},
+			eventTriggerFunctionSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the event trigger function",
+			},
+			eventTriggerOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE name who owns the event trigger",
+			},
+			eventTriggerEnabledAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If the event trigger is enabled",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLEventTriggerCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := db.requireSuperuser("creating an event trigger"); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(eventTriggerNameAttr).(string)
+
+	b := bytes.NewBufferString("CREATE EVENT TRIGGER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(name), " ON ", pq.QuoteIdentifier(d.Get(eventTriggerEventAttr).(string)))
+
+	if tags := d.Get(eventTriggerTagsAttr).(*schema.Set); tags.Len() > 0 {
+		quotedTags := []string{}
+		for _, tag := range tags.List() {
+			quotedTags = append(quotedTags, fmt.Sprintf("'%s'", pqQuoteLiteral(tag.(string))))
+		}
+		sort.Strings(quotedTags)
+		fmt.Fprintf(b, " WHEN TAG IN (%s)", strings.Join(quotedTags, ", "))
+	}
+
+	fmt.Fprintf(
+		b, " EXECUTE PROCEDURE %s.%s()",
+		pq.QuoteIdentifier(d.Get(eventTriggerFunctionSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(eventTriggerFunctionAttr).(string)),
+	)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create event trigger %s: %w", name, err)
+	}
+
+	if err := setEventTriggerOwner(txn, d); err != nil {
+		return err
+	}
+
+	if !d.Get(eventTriggerEnabledAttr).(bool) {
+		if err := setEventTriggerEnabled(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating event trigger: %w", err)
+	}
+
+	d.SetId(generateEventTriggerID(d, database))
+
+	return resourcePostgreSQLEventTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLEventTriggerExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, eventTriggerName, err := getDBEventTriggerName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	query := "SELECT evtname FROM pg_catalog.pg_event_trigger WHERE evtname = $1"
+	err = txn.QueryRow(query, eventTriggerName).Scan(&eventTriggerName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLEventTriggerRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLEventTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLEventTriggerReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, eventTriggerName, err := getDBEventTriggerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var event, owner, enabled, function, functionSchema string
+	var tags pq.StringArray
+
+	query := `SELECT e.evtevent, pg_catalog.pg_get_userbyid(e.evtowner), e.evtenabled, e.evttags, p.proname, n.nspname ` +
+		`FROM pg_catalog.pg_event_trigger e ` +
+		`JOIN pg_catalog.pg_proc p ON p.oid = e.evtfoid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace ` +
+		`WHERE e.evtname = $1`
+	err = txn.QueryRow(query, eventTriggerName).Scan(&event, &owner, &enabled, &tags, &function, &functionSchema)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL event trigger (%s) not found for database %s", eventTriggerName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading event trigger: %w", err)
+	}
+
+	d.Set(eventTriggerNameAttr, eventTriggerName)
+	d.Set(eventTriggerDatabaseAttr, database)
+	d.Set(eventTriggerEventAttr, event)
+	d.Set(eventTriggerTagsAttr, stringSliceToSet(tags))
+	d.Set(eventTriggerFunctionAttr, function)
+	d.Set(eventTriggerFunctionSchemaAttr, functionSchema)
+	d.Set(eventTriggerOwnerAttr, owner)
+	// 'D' means disabled, the other values mean enabled in different session_replication_role modes.
+	d.Set(eventTriggerEnabledAttr, enabled != "D")
+
+	return nil
+}
+
+func resourcePostgreSQLEventTriggerUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setEventTriggerOwner(txn, d); err != nil {
+		return err
+	}
+
+	if d.HasChange(eventTriggerEnabledAttr) {
+		if err := setEventTriggerEnabled(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating event trigger: %w", err)
+	}
+
+	return resourcePostgreSQLEventTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLEventTriggerDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(eventTriggerNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("DROP EVENT TRIGGER %s", pq.QuoteIdentifier(name))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop event trigger %s: %w", name, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting event trigger: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setEventTriggerOwner(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(eventTriggerOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(eventTriggerOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER EVENT TRIGGER %s OWNER TO %s",
+		pq.QuoteIdentifier(d.Get(eventTriggerNameAttr).(string)), pq.QuoteIdentifier(owner),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating event trigger owner: %w", err)
+	}
+
+	return nil
+}
+
+func setEventTriggerEnabled(txn *sql.Tx, d *schema.ResourceData) error {
+	action := "DISABLE"
+	if d.Get(eventTriggerEnabledAttr).(bool) {
+		action = "ENABLE"
+	}
+
+	sql := fmt.Sprintf("ALTER EVENT TRIGGER %s %s", pq.QuoteIdentifier(d.Get(eventTriggerNameAttr).(string)), action)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating event trigger enabled state: %w", err)
+	}
+
+	return nil
+}
+
+func generateEventTriggerID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(eventTriggerNameAttr).(string),
+	}, ".")
+}
+
+// getDBEventTriggerName returns database and event trigger name. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBEventTriggerName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	eventTriggerName := d.Get(eventTriggerNameAttr).(string)
+
+	// When importing, we have to parse the ID to find event trigger and database names.
+	if eventTriggerName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("event trigger ID %s has not the expected format 'database.event_trigger': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		eventTriggerName = parsed[1]
+	}
+
+	return database, eventTriggerName, nil
+}
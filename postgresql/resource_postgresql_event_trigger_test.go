@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlEventTrigger_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), `
+	CREATE FUNCTION log_ddl() RETURNS event_trigger LANGUAGE plpgsql AS $$
+	BEGIN
+		RAISE NOTICE 'DDL command: %', tg_tag;
+	END;
+	$$`)
+
+	testAccPostgresqlEventTriggerConfig := func(owner string, enabled bool) string {
+		return fmt.Sprintf(`
+		resource "postgresql_event_trigger" "test" {
+			name     = "test_event_trigger"
+			database = "%s"
+			event    = "ddl_command_end"
+			tags     = ["CREATE TABLE", "ALTER TABLE"]
+			function = "log_ddl"
+			owner    = "%s"
+			enabled  = %t
+		}`, dbName, owner, enabled)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlEventTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlEventTriggerConfig(config.Username, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEventTriggerExists("postgresql_event_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "id", fmt.Sprintf("%s.test_event_trigger", dbName)),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "event", "ddl_command_end"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "function", "log_ddl"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "function_schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "owner", config.Username),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "enabled", "true"),
+				),
+			},
+			{
+				// The owner of an event trigger must be a superuser.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), fmt.Sprintf("ALTER ROLE %s SUPERUSER", roleName))
+				},
+				Config: testAccPostgresqlEventTriggerConfig(roleName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlEventTriggerExists("postgresql_event_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_event_trigger.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_event_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlEventTriggerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_event_trigger" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[eventTriggerDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkEventTriggerExists(txn, rs.Primary.Attributes[eventTriggerNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking event trigger %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Event trigger still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlEventTriggerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[eventTriggerDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkEventTriggerExists(txn, rs.Primary.Attributes[eventTriggerNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking event trigger %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Event trigger not found")
+		}
+
+		return nil
+	}
+}
+
+func checkEventTriggerExists(txn *sql.Tx, name string) (bool, error) {
+	var _rez bool
+	err := txn.QueryRow("SELECT TRUE FROM pg_catalog.pg_event_trigger WHERE evtname = $1", name).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about event trigger: %s", err)
+	}
+
+	return true, nil
+}
@@ -23,14 +23,14 @@ const (
 
 func resourcePostgreSQLExtension() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLExtensionCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLExtensionRead),
-		Update: PGResourceFunc(resourcePostgreSQLExtensionUpdate),
-		Delete: PGResourceFunc(resourcePostgreSQLExtensionDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLExtensionExists),
+		CreateContext: PGResourceFunc(resourcePostgreSQLExtensionCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLExtensionExists, resourcePostgreSQLExtensionRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLExtensionUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLExtensionDelete),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: requireFeature(featureExtension, "postgresql_extension resource", nil),
 
 		Schema: map[string]*schema.Schema{
 			extNameAttr: {
@@ -69,6 +69,7 @@ func resourcePostgreSQLExtension() *schema.Resource {
 				Default:     false,
 				Description: "When true, will also create any extensions that this extension depends on that are not already installed",
 			},
+			assumeRoleAttr: assumeRoleSchema(),
 		},
 	}
 }
@@ -334,7 +335,7 @@ func generateExtensionID(d *schema.ResourceData, databaseName string) string {
 
 func getExtensionNameFromID(ID string) string {
 	splitted := strings.Split(ID, ".")
-	return splitted[0]
+	return splitted[len(splitted)-1]
 }
 
 // getDBExtName returns database and extension name. If we are importing this resource, they will be parsed
@@ -36,6 +36,12 @@ func TestAccPostgresqlExtension_Basic(t *testing.T) {
 						"postgresql_extension.myextension", "version"),
 				),
 			},
+			{
+				ResourceName:            "postgresql_extension.myextension",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{extDropCascadeAttr, extCreateCascadeAttr},
+			},
 		},
 	})
 }
@@ -240,6 +246,54 @@ resource "postgresql_extension" "cascade" {
 	})
 }
 
+func TestAccPostgresqlExtension_DroppedOutside(t *testing.T) {
+	skipIfNotAcc(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureExtension)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlExtensionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlExtensionExists("postgresql_extension.myextension"),
+					// Drop the extension behind Terraform's back,
+					// the next plan should want to create it again.
+					testAccDropExtension("pg_trgm"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccPostgresqlExtensionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlExtensionExists("postgresql_extension.myextension"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDropExtension(extName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		if _, err = db.Exec(fmt.Sprintf("DROP EXTENSION %s", extName)); err != nil {
+			return fmt.Errorf("could not drop extension %s: %s", extName, err)
+		}
+
+		return nil
+	}
+}
+
 func testAccCreateExtensionDependency(tableName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -0,0 +1,382 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	fdwNameAttr      = "name"
+	fdwDatabaseAttr  = "database"
+	fdwHandlerAttr   = "handler"
+	fdwValidatorAttr = "validator"
+	fdwOptionsAttr   = "options"
+	fdwOwnerAttr     = "owner"
+)
+
+func resourcePostgreSQLForeignDataWrapper() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLForeignDataWrapperCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLForeignDataWrapperExists, resourcePostgreSQLForeignDataWrapperRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLForeignDataWrapperUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLForeignDataWrapperDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			fdwNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the foreign-data wrapper",
+			},
+			fdwDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign-data wrapper is located",
+			},
+			fdwHandlerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the handler function of the foreign-data wrapper",
+			},
+			fdwValidatorAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the validator function of the foreign-data wrapper",
+			},
+			fdwOptionsAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The options of the foreign-data wrapper",
+			},
+			fdwOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the foreign-data wrapper",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLForeignDataWrapperCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(fdwNameAttr).(string)
+
+	b := bytes.NewBufferString("CREATE FOREIGN DATA WRAPPER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(name))
+
+	// Functions are not quoted so they can be schema qualified.
+	if v, ok := d.GetOk(fdwHandlerAttr); ok {
+		fmt.Fprint(b, " HANDLER ", v.(string))
+	}
+	if v, ok := d.GetOk(fdwValidatorAttr); ok {
+		fmt.Fprint(b, " VALIDATOR ", v.(string))
+	}
+	if options := d.Get(fdwOptionsAttr).(map[string]interface{}); len(options) > 0 {
+		fmt.Fprint(b, " ", getFDWOptionsClause(options))
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create foreign data wrapper %s: %w", name, err)
+	}
+
+	if err := setForeignDataWrapperOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating foreign data wrapper: %w", err)
+	}
+
+	d.SetId(generateForeignDataWrapperID(d, database))
+
+	return resourcePostgreSQLForeignDataWrapperReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignDataWrapperExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, fdwName, err := getDBForeignDataWrapperName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	query := "SELECT fdwname FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname = $1"
+	err = txn.QueryRow(query, fdwName).Scan(&fdwName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLForeignDataWrapperRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLForeignDataWrapperReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignDataWrapperReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, fdwName, err := getDBForeignDataWrapperName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var handler, validator, owner string
+	var options pq.StringArray
+
+	query := `SELECT ` +
+		`CASE WHEN fdwhandler = 0 THEN '' ELSE fdwhandler::regproc::text END, ` +
+		`CASE WHEN fdwvalidator = 0 THEN '' ELSE fdwvalidator::regproc::text END, ` +
+		`pg_catalog.pg_get_userbyid(fdwowner), COALESCE(fdwoptions, '{}') ` +
+		`FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname = $1`
+	err = txn.QueryRow(query, fdwName).Scan(&handler, &validator, &owner, &options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL foreign data wrapper (%s) not found for database %s", fdwName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading foreign data wrapper: %w", err)
+	}
+
+	d.Set(fdwNameAttr, fdwName)
+	d.Set(fdwDatabaseAttr, database)
+	d.Set(fdwHandlerAttr, handler)
+	d.Set(fdwValidatorAttr, validator)
+	d.Set(fdwOptionsAttr, parseFDWOptions(options))
+	d.Set(fdwOwnerAttr, owner)
+
+	return nil
+}
+
+func resourcePostgreSQLForeignDataWrapperUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := pq.QuoteIdentifier(d.Get(fdwNameAttr).(string))
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChange(fdwHandlerAttr) {
+		handler := "NO HANDLER"
+		if v, ok := d.GetOk(fdwHandlerAttr); ok {
+			handler = "HANDLER " + v.(string)
+		}
+		sql := fmt.Sprintf("ALTER FOREIGN DATA WRAPPER %s %s", name, handler)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating foreign data wrapper handler: %w", err)
+		}
+	}
+
+	if d.HasChange(fdwValidatorAttr) {
+		validator := "NO VALIDATOR"
+		if v, ok := d.GetOk(fdwValidatorAttr); ok {
+			validator = "VALIDATOR " + v.(string)
+		}
+		sql := fmt.Sprintf("ALTER FOREIGN DATA WRAPPER %s %s", name, validator)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating foreign data wrapper validator: %w", err)
+		}
+	}
+
+	if options := getFDWAlterOptionsClause(d, fdwOptionsAttr); options != "" {
+		sql := fmt.Sprintf("ALTER FOREIGN DATA WRAPPER %s %s", name, options)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating foreign data wrapper options: %w", err)
+		}
+	}
+
+	if err := setForeignDataWrapperOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating foreign data wrapper: %w", err)
+	}
+
+	return resourcePostgreSQLForeignDataWrapperReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignDataWrapperDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(fdwNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("DROP FOREIGN DATA WRAPPER %s", pq.QuoteIdentifier(name))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop foreign data wrapper %s: %w", name, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting foreign data wrapper: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setForeignDataWrapperOwner(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(fdwOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(fdwOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER FOREIGN DATA WRAPPER %s OWNER TO %s",
+		pq.QuoteIdentifier(d.Get(fdwNameAttr).(string)), pq.QuoteIdentifier(owner),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating foreign data wrapper owner: %w", err)
+	}
+
+	return nil
+}
+
+// getFDWOptionsClause returns the OPTIONS clause used to create a foreign-data object
+// (foreign-data wrapper, server, user mapping, foreign table...)
+func getFDWOptionsClause(options map[string]interface{}) string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	clauses := make([]string, 0, len(options))
+	for _, name := range names {
+		clauses = append(clauses, fmt.Sprintf("%s '%s'", pq.QuoteIdentifier(name), pqQuoteLiteral(options[name].(string))))
+	}
+
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(clauses, ", "))
+}
+
+// getFDWAlterOptionsClause returns the OPTIONS clause used to update the options of a foreign-data object
+// from the changes of the attribute. It returns an empty string if the options have not changed.
+func getFDWAlterOptionsClause(d *schema.ResourceData, attr string) string {
+	if !d.HasChange(attr) {
+		return ""
+	}
+
+	oraw, nraw := d.GetChange(attr)
+	oldOptions := oraw.(map[string]interface{})
+	newOptions := nraw.(map[string]interface{})
+
+	names := make([]string, 0, len(oldOptions)+len(newOptions))
+	for name := range oldOptions {
+		names = append(names, name)
+	}
+	for name := range newOptions {
+		if _, ok := oldOptions[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	clauses := []string{}
+	for _, name := range names {
+		oldValue, inOld := oldOptions[name]
+		newValue, inNew := newOptions[name]
+		switch {
+		case !inNew:
+			clauses = append(clauses, fmt.Sprintf("DROP %s", pq.QuoteIdentifier(name)))
+		case !inOld:
+			clauses = append(clauses, fmt.Sprintf("ADD %s '%s'", pq.QuoteIdentifier(name), pqQuoteLiteral(newValue.(string))))
+		case oldValue != newValue:
+			clauses = append(clauses, fmt.Sprintf("SET %s '%s'", pq.QuoteIdentifier(name), pqQuoteLiteral(newValue.(string))))
+		}
+	}
+
+	if len(clauses) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(clauses, ", "))
+}
+
+// parseFDWOptions converts the options of a foreign-data object, stored as key=value, to a map.
+func parseFDWOptions(options []string) map[string]string {
+	result := make(map[string]string, len(options))
+	for _, option := range options {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) == 2 {
+			result[parts[0]] = parts[1]
+		}
+	}
+
+	return result
+}
+
+func generateForeignDataWrapperID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(fdwNameAttr).(string),
+	}, ".")
+}
+
+// getDBForeignDataWrapperName returns database and foreign-data wrapper name. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBForeignDataWrapperName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	fdwName := d.Get(fdwNameAttr).(string)
+
+	// When importing, we have to parse the ID to find foreign-data wrapper and database names.
+	if fdwName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("foreign data wrapper ID %s has not the expected format 'database.foreign_data_wrapper': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		fdwName = parsed[1]
+	}
+
+	return database, fdwName, nil
+}
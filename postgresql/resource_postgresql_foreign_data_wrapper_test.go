@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlForeignDataWrapper_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlForeignDataWrapperDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_foreign_data_wrapper" "test" {
+					name      = "test_fdw"
+					database  = "%s"
+					validator = "postgresql_fdw_validator"
+					options = {
+						debug = "true"
+						mode  = "fast"
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignDataWrapperExists("postgresql_foreign_data_wrapper.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "id", fmt.Sprintf("%s.test_fdw", dbName)),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "handler", ""),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "validator", "postgresql_fdw_validator"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.debug", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_foreign_data_wrapper" "test" {
+					name     = "test_fdw"
+					database = "%s"
+					options = {
+						debug = "false"
+						level = "1"
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignDataWrapperExists("postgresql_foreign_data_wrapper.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "validator", ""),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.debug", "false"),
+					resource.TestCheckResourceAttr("postgresql_foreign_data_wrapper.test", "options.level", "1"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_foreign_data_wrapper.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlForeignDataWrapperDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_foreign_data_wrapper" {
+			continue
+		}
+
+		exists, err := checkForeignDataWrapperExists(client, rs.Primary.Attributes[fdwDatabaseAttr], rs.Primary.Attributes[fdwNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign data wrapper %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Foreign data wrapper still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlForeignDataWrapperExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkForeignDataWrapperExists(client, rs.Primary.Attributes[fdwDatabaseAttr], rs.Primary.Attributes[fdwNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign data wrapper %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Foreign data wrapper not found")
+		}
+
+		return nil
+	}
+}
+
+func checkForeignDataWrapperExists(client *Client, database, name string) (bool, error) {
+	txn, err := startTransaction(client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	err = txn.QueryRow("SELECT 1 FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname = $1", name).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about foreign data wrapper: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,327 @@
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	foreignServerNameAttr        = "name"
+	foreignServerDatabaseAttr    = "database"
+	foreignServerFDWAttr         = "foreign_data_wrapper"
+	foreignServerTypeAttr        = "type"
+	foreignServerVersionAttr     = "version"
+	foreignServerOptionsAttr     = "options"
+	foreignServerOwnerAttr       = "owner"
+	foreignServerDropCascadeAttr = "drop_cascade"
+)
+
+func resourcePostgreSQLForeignServer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLForeignServerCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLForeignServerExists, resourcePostgreSQLForeignServerRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLForeignServerUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLForeignServerDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// The version of a server can be changed but not removed.
+		CustomizeDiff: customdiff.ForceNewIfChange(foreignServerVersionAttr, func(_ context.Context, old, new, _ interface{}) bool {
+			return new.(string) == ""
+		}),
+
+		Schema: map[string]*schema.Schema{
+			foreignServerNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the foreign server",
+			},
+			foreignServerDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign server is located",
+			},
+			foreignServerFDWAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the foreign-data wrapper that manages the server",
+			},
+			foreignServerTypeAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The type of the server, potentially useful to the foreign-data wrapper",
+			},
+			foreignServerVersionAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The version of the server, potentially useful to the foreign-data wrapper",
+			},
+			foreignServerOptionsAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The options of the foreign server (e.g.: host, port, dbname)",
+			},
+			foreignServerOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the foreign server",
+			},
+			foreignServerDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the foreign server (user mappings, foreign tables...)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLForeignServerCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(foreignServerNameAttr).(string)
+
+	b := bytes.NewBufferString("CREATE SERVER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(name))
+
+	if v, ok := d.GetOk(foreignServerTypeAttr); ok {
+		fmt.Fprintf(b, " TYPE '%s'", pqQuoteLiteral(v.(string)))
+	}
+	if v, ok := d.GetOk(foreignServerVersionAttr); ok {
+		fmt.Fprintf(b, " VERSION '%s'", pqQuoteLiteral(v.(string)))
+	}
+
+	fmt.Fprint(b, " FOREIGN DATA WRAPPER ", pq.QuoteIdentifier(d.Get(foreignServerFDWAttr).(string)))
+
+	if options := d.Get(foreignServerOptionsAttr).(map[string]interface{}); len(options) > 0 {
+		fmt.Fprint(b, " ", getFDWOptionsClause(options))
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create foreign server %s: %w", name, err)
+	}
+
+	if err := setForeignServerOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating foreign server: %w", err)
+	}
+
+	d.SetId(generateForeignServerID(d, database))
+
+	return resourcePostgreSQLForeignServerReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignServerExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, serverName, err := getDBForeignServerName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	query := "SELECT srvname FROM pg_catalog.pg_foreign_server WHERE srvname = $1"
+	err = txn.QueryRow(query, serverName).Scan(&serverName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLForeignServerRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLForeignServerReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignServerReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, serverName, err := getDBForeignServerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var fdwName, owner string
+	var serverType, version sql.NullString
+	var options pq.StringArray
+
+	query := `SELECT w.fdwname, s.srvtype, s.srvversion, pg_catalog.pg_get_userbyid(s.srvowner), ` +
+		`COALESCE(s.srvoptions, '{}') ` +
+		`FROM pg_catalog.pg_foreign_server s ` +
+		`JOIN pg_catalog.pg_foreign_data_wrapper w ON w.oid = s.srvfdw ` +
+		`WHERE s.srvname = $1`
+	err = txn.QueryRow(query, serverName).Scan(&fdwName, &serverType, &version, &owner, &options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL foreign server (%s) not found for database %s", serverName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading foreign server: %w", err)
+	}
+
+	d.Set(foreignServerNameAttr, serverName)
+	d.Set(foreignServerDatabaseAttr, database)
+	d.Set(foreignServerFDWAttr, fdwName)
+	d.Set(foreignServerTypeAttr, serverType.String)
+	d.Set(foreignServerVersionAttr, version.String)
+	d.Set(foreignServerOptionsAttr, parseFDWOptions(options))
+	d.Set(foreignServerOwnerAttr, owner)
+
+	return nil
+}
+
+func resourcePostgreSQLForeignServerUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := pq.QuoteIdentifier(d.Get(foreignServerNameAttr).(string))
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChange(foreignServerVersionAttr) {
+		sql := fmt.Sprintf("ALTER SERVER %s VERSION '%s'", name, pqQuoteLiteral(d.Get(foreignServerVersionAttr).(string)))
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating foreign server version: %w", err)
+		}
+	}
+
+	if options := getFDWAlterOptionsClause(d, foreignServerOptionsAttr); options != "" {
+		sql := fmt.Sprintf("ALTER SERVER %s %s", name, options)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating foreign server options: %w", err)
+		}
+	}
+
+	if err := setForeignServerOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating foreign server: %w", err)
+	}
+
+	return resourcePostgreSQLForeignServerReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignServerDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(foreignServerNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(foreignServerDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP SERVER %s %s", pq.QuoteIdentifier(name), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop foreign server %s: %w", name, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting foreign server: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setForeignServerOwner(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(foreignServerOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(foreignServerOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER SERVER %s OWNER TO %s",
+		pq.QuoteIdentifier(d.Get(foreignServerNameAttr).(string)), pq.QuoteIdentifier(owner),
+	)
+	if err := withRolesGranted(txn, []string{owner}, func() error {
+		_, err := txn.Exec(sql)
+		return err
+	}); err != nil {
+		return fmt.Errorf("Error updating foreign server owner: %w", err)
+	}
+
+	return nil
+}
+
+func generateForeignServerID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(foreignServerNameAttr).(string),
+	}, ".")
+}
+
+// getDBForeignServerName returns database and foreign server name. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBForeignServerName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	serverName := d.Get(foreignServerNameAttr).(string)
+
+	// When importing, we have to parse the ID to find foreign server and database names.
+	if serverName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("foreign server ID %s has not the expected format 'database.server': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		serverName = parsed[1]
+	}
+
+	return database, serverName, nil
+}
@@ -0,0 +1,158 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlForeignServer_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlForeignServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_extension" "postgres_fdw" {
+					name     = "postgres_fdw"
+					database = "%s"
+				}
+
+				resource "postgresql_foreign_server" "test" {
+					name                 = "test_server"
+					database             = "%s"
+					foreign_data_wrapper = postgresql_extension.postgres_fdw.name
+					type                 = "postgresql"
+					version              = "13"
+					options = {
+						host   = "localhost"
+						port   = "5432"
+						dbname = "remote"
+					}
+				}`, dbName, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignServerExists("postgresql_foreign_server.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "id", fmt.Sprintf("%s.test_server", dbName)),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "foreign_data_wrapper", "postgres_fdw"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "type", "postgresql"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "version", "13"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "options.%", "3"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "options.port", "5432"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_extension" "postgres_fdw" {
+					name     = "postgres_fdw"
+					database = "%s"
+				}
+
+				resource "postgresql_foreign_server" "test" {
+					name                 = "test_server"
+					database             = "%s"
+					foreign_data_wrapper = postgresql_extension.postgres_fdw.name
+					type                 = "postgresql"
+					version              = "14"
+					owner                = "%s"
+					options = {
+						host       = "remote.example.com"
+						dbname     = "remote"
+						fetch_size = "1000"
+					}
+				}`, dbName, dbName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignServerExists("postgresql_foreign_server.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "version", "14"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "owner", roleName),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "options.%", "3"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "options.host", "remote.example.com"),
+					resource.TestCheckResourceAttr("postgresql_foreign_server.test", "options.fetch_size", "1000"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_foreign_server.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{foreignServerDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlForeignServerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_foreign_server" {
+			continue
+		}
+
+		exists, err := checkForeignServerExists(client, rs.Primary.Attributes[foreignServerDatabaseAttr], rs.Primary.Attributes[foreignServerNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign server %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Foreign server still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlForeignServerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkForeignServerExists(client, rs.Primary.Attributes[foreignServerDatabaseAttr], rs.Primary.Attributes[foreignServerNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking foreign server %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Foreign server not found")
+		}
+
+		return nil
+	}
+}
+
+func checkForeignServerExists(client *Client, database, name string) (bool, error) {
+	txn, err := startTransaction(client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	err = txn.QueryRow("SELECT 1 FROM pg_catalog.pg_foreign_server WHERE srvname = $1", name).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about foreign server: %s", err)
+	}
+
+	return true, nil
+}
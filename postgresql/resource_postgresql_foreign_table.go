@@ -0,0 +1,452 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	foreignTableNameAttr                = "name"
+	foreignTableSchemaAttr              = "schema"
+	foreignTableDatabaseAttr            = "database"
+	foreignTableServerAttr              = "server"
+	foreignTableColumnAttr              = "column"
+	foreignTableColumnNameAttr          = "name"
+	foreignTableColumnTypeAttr          = "type"
+	foreignTableColumnNotNullAttr       = "not_null"
+	foreignTableOptionsAttr             = "options"
+	foreignTableImportForeignSchemaAttr = "import_foreign_schema"
+	foreignTableRemoteSchemaAttr        = "remote_schema"
+	foreignTableDropCascadeAttr         = "drop_cascade"
+)
+
+func resourcePostgreSQLForeignTable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLForeignTableCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLForeignTableExists, resourcePostgreSQLForeignTableRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLForeignTableUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLForeignTableDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			foreignTableNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the foreign table",
+			},
+			foreignTableSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the foreign table is located",
+			},
+			foreignTableDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign table is located",
+			},
+			foreignTableServerAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the foreign server of the foreign table",
+			},
+			foreignTableColumnAttr: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{foreignTableImportForeignSchemaAttr},
+				Description:   "The columns of the foreign table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						foreignTableColumnNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column",
+						},
+						foreignTableColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The data type of the column",
+						},
+						foreignTableColumnNotNullAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "If the column is not allowed to contain null values",
+						},
+					},
+				},
+			},
+			foreignTableOptionsAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The options of the foreign table (e.g.: schema_name, table_name)",
+			},
+			foreignTableImportForeignSchemaAttr: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{foreignTableColumnAttr},
+				Description:   "Create the foreign table with IMPORT FOREIGN SCHEMA instead of defining its columns",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						foreignTableRemoteSchemaAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The remote schema to import the table from",
+						},
+					},
+				},
+			},
+			foreignTableDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the foreign table, and in turn all objects that depend on those objects",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLForeignTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(foreignTableNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if v, ok := d.GetOk(foreignTableImportForeignSchemaAttr); ok {
+		importForeignSchema := v.([]interface{})[0].(map[string]interface{})
+		if err := importForeignTable(txn, d, importForeignSchema[foreignTableRemoteSchemaAttr].(string)); err != nil {
+			return err
+		}
+	} else {
+		if err := createForeignTable(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating foreign table %s: %w", name, err)
+	}
+
+	d.SetId(generateForeignTableID(d, database))
+
+	return resourcePostgreSQLForeignTableReadImpl(db, d)
+}
+
+func createForeignTable(txn *sql.Tx, d *schema.ResourceData) error {
+	columns := []string{}
+	for _, c := range d.Get(foreignTableColumnAttr).([]interface{}) {
+		column := c.(map[string]interface{})
+		definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column[foreignTableColumnNameAttr].(string)), column[foreignTableColumnTypeAttr].(string))
+		if column[foreignTableColumnNotNullAttr].(bool) {
+			definition += " NOT NULL"
+		}
+		columns = append(columns, definition)
+	}
+
+	b := bytes.NewBufferString("CREATE FOREIGN TABLE ")
+	fmt.Fprintf(b, "%s (%s) SERVER %s",
+		getForeignTableQualifiedName(d), strings.Join(columns, ", "),
+		pq.QuoteIdentifier(d.Get(foreignTableServerAttr).(string)),
+	)
+
+	if options := d.Get(foreignTableOptionsAttr).(map[string]interface{}); len(options) > 0 {
+		fmt.Fprint(b, " ", getFDWOptionsClause(options))
+	}
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create foreign table %s: %w", d.Get(foreignTableNameAttr).(string), err)
+	}
+
+	return nil
+}
+
+// importForeignTable creates the foreign table with IMPORT FOREIGN SCHEMA,
+// the configured options are then added to (or replace) the ones set by the foreign-data wrapper.
+func importForeignTable(txn *sql.Tx, d *schema.ResourceData, remoteSchema string) error {
+	name := d.Get(foreignTableNameAttr).(string)
+
+	sql := fmt.Sprintf(
+		"IMPORT FOREIGN SCHEMA %s LIMIT TO (%s) FROM SERVER %s INTO %s",
+		pq.QuoteIdentifier(remoteSchema), pq.QuoteIdentifier(name),
+		pq.QuoteIdentifier(d.Get(foreignTableServerAttr).(string)),
+		pq.QuoteIdentifier(d.Get(foreignTableSchemaAttr).(string)),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not import foreign table %s: %w", name, err)
+	}
+
+	exists, err := relationExists(txn, d.Get(foreignTableSchemaAttr).(string), name, "f")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("could not import foreign table %s: table not found in remote schema %s", name, remoteSchema)
+	}
+
+	options := d.Get(foreignTableOptionsAttr).(map[string]interface{})
+	if len(options) == 0 {
+		return nil
+	}
+
+	currentOptions, err := getForeignTableOptions(txn, d.Get(foreignTableSchemaAttr).(string), name)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	clauses := []string{}
+	for _, option := range names {
+		action := "ADD"
+		if _, ok := currentOptions[option]; ok {
+			action = "SET"
+		}
+		clauses = append(clauses, fmt.Sprintf("%s %s '%s'", action, pq.QuoteIdentifier(option), pqQuoteLiteral(options[option].(string))))
+	}
+
+	sql = fmt.Sprintf("ALTER FOREIGN TABLE %s OPTIONS (%s)", getForeignTableQualifiedName(d), strings.Join(clauses, ", "))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not set options of foreign table %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func resourcePostgreSQLForeignTableExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, tableSchema, tableName, err := getDBForeignTableName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return relationExists(txn, tableSchema, tableName, "f")
+}
+
+func resourcePostgreSQLForeignTableRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLForeignTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignTableReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, tableSchema, tableName, err := getDBForeignTableName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var tableOID int
+	var server string
+	var options pq.StringArray
+
+	query := `SELECT c.oid, s.srvname, COALESCE(ft.ftoptions, '{}') ` +
+		`FROM pg_catalog.pg_foreign_table ft ` +
+		`JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver ` +
+		`WHERE n.nspname = $1 AND c.relname = $2`
+	err = txn.QueryRow(query, tableSchema, tableName).Scan(&tableOID, &server, &options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL foreign table (%s.%s) not found for database %s", tableSchema, tableName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading foreign table: %w", err)
+	}
+
+	// PostgreSQL normalizes the type names (e.g.: int becomes integer),
+	// so we only set the columns when they are unknown (import or IMPORT FOREIGN SCHEMA).
+	if len(d.Get(foreignTableColumnAttr).([]interface{})) == 0 {
+		columns, err := getForeignTableColumns(txn, tableOID)
+		if err != nil {
+			return err
+		}
+		d.Set(foreignTableColumnAttr, columns)
+	}
+
+	d.Set(foreignTableNameAttr, tableName)
+	d.Set(foreignTableSchemaAttr, tableSchema)
+	d.Set(foreignTableDatabaseAttr, database)
+	d.Set(foreignTableServerAttr, server)
+	d.Set(foreignTableOptionsAttr, parseFDWOptions(options))
+
+	return nil
+}
+
+func resourcePostgreSQLForeignTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	options := getFDWAlterOptionsClause(d, foreignTableOptionsAttr)
+	if options == "" {
+		return resourcePostgreSQLForeignTableReadImpl(db, d)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("ALTER FOREIGN TABLE %s %s", getForeignTableQualifiedName(d), options)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating foreign table options: %w", err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating foreign table: %w", err)
+	}
+
+	return resourcePostgreSQLForeignTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLForeignTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(foreignTableDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP FOREIGN TABLE %s %s", getForeignTableQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop foreign table %s: %w", d.Get(foreignTableNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting foreign table: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getForeignTableColumns(txn *sql.Tx, tableOID int) ([]interface{}, error) {
+	query := `SELECT attname, pg_catalog.format_type(atttypid, atttypmod), attnotnull ` +
+		`FROM pg_catalog.pg_attribute ` +
+		`WHERE attrelid = $1 AND attnum > 0 AND NOT attisdropped ` +
+		`ORDER BY attnum`
+	rows, err := txn.Query(query, tableOID)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading foreign table columns: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []interface{}{}
+	for rows.Next() {
+		var name, columnType string
+		var notNull bool
+		if err := rows.Scan(&name, &columnType, &notNull); err != nil {
+			return nil, fmt.Errorf("could not scan foreign table column: %w", err)
+		}
+		columns = append(columns, map[string]interface{}{
+			foreignTableColumnNameAttr:    name,
+			foreignTableColumnTypeAttr:    columnType,
+			foreignTableColumnNotNullAttr: notNull,
+		})
+	}
+
+	return columns, rows.Err()
+}
+
+func getForeignTableOptions(txn *sql.Tx, tableSchema, tableName string) (map[string]string, error) {
+	var options pq.StringArray
+
+	query := `SELECT COALESCE(ft.ftoptions, '{}') ` +
+		`FROM pg_catalog.pg_foreign_table ft ` +
+		`JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2`
+	if err := txn.QueryRow(query, tableSchema, tableName).Scan(&options); err != nil {
+		return nil, fmt.Errorf("Error reading foreign table options: %w", err)
+	}
+
+	return parseFDWOptions(options), nil
+}
+
+func getForeignTableQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(foreignTableSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(foreignTableNameAttr).(string)),
+	)
+}
+
+func generateForeignTableID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(foreignTableSchemaAttr).(string),
+		d.Get(foreignTableNameAttr).(string),
+	}, ".")
+}
+
+// getDBForeignTableName returns the database, schema and name of the foreign table. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBForeignTableName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	tableSchema := d.Get(foreignTableSchemaAttr).(string)
+	tableName := d.Get(foreignTableNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the foreign table, schema and database names.
+	if tableName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("foreign table ID %s has not the expected format 'database.schema.table': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		tableSchema = parsed[1]
+		tableName = parsed[2]
+	}
+
+	return database, tableSchema, tableName, nil
+}
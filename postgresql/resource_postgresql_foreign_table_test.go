@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlForeignTable_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlForeignTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlForeignTableConfig(dbName, "users"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignTableExists("postgresql_foreign_table.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "id", fmt.Sprintf("%s.public.remote_users", dbName)),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "server", "test_server"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.0.name", "id"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "column.0.not_null", "true"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "options.table_name", "users"),
+				),
+			},
+			{
+				Config: testAccPostgresqlForeignTableConfig(dbName, "customers"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlForeignTableExists("postgresql_foreign_table.test"),
+					resource.TestCheckResourceAttr("postgresql_foreign_table.test", "options.table_name", "customers"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_foreign_table.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{foreignTableDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func testAccPostgresqlForeignTableConfig(dbName, remoteTable string) string {
+	return fmt.Sprintf(`
+resource "postgresql_extension" "postgres_fdw" {
+  name     = "postgres_fdw"
+  database = "%[1]s"
+}
+
+resource "postgresql_foreign_server" "test" {
+  name                 = "test_server"
+  database             = "%[1]s"
+  foreign_data_wrapper = postgresql_extension.postgres_fdw.name
+  options = {
+    host   = "localhost"
+    dbname = "remote"
+  }
+}
+
+resource "postgresql_foreign_table" "test" {
+  name     = "remote_users"
+  database = "%[1]s"
+  server   = postgresql_foreign_server.test.name
+
+  column {
+    name     = "id"
+    type     = "integer"
+    not_null = true
+  }
+  column {
+    name = "email"
+    type = "text"
+  }
+
+  options = {
+    schema_name = "public"
+    table_name  = "%[2]s"
+  }
+}
+`, dbName, remoteTable)
+}
+
+func testAccCheckPostgresqlForeignTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_foreign_table" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[foreignTableDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[foreignTableSchemaAttr], rs.Primary.Attributes[foreignTableNameAttr], "f")
+		if err != nil {
+			return fmt.Errorf("Error checking foreign table %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Foreign table still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlForeignTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[foreignTableDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[foreignTableSchemaAttr], rs.Primary.Attributes[foreignTableNameAttr], "f")
+		if err != nil {
+			return fmt.Errorf("Error checking foreign table %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Foreign table not found")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,496 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	funcNameAttr            = "name"
+	funcSchemaAttr          = "schema"
+	funcDatabaseAttr        = "database"
+	funcArgAttr             = "arg"
+	funcArgNameAttr         = "name"
+	funcArgTypeAttr         = "type"
+	funcArgModeAttr         = "mode"
+	funcArgDefaultAttr      = "default"
+	funcReturnsAttr         = "returns"
+	funcLanguageAttr        = "language"
+	funcBodyAttr            = "body"
+	funcVolatilityAttr      = "volatility"
+	funcSecurityDefinerAttr = "security_definer"
+	funcStrictAttr          = "strict"
+	funcDropCascadeAttr     = "drop_cascade"
+)
+
+var (
+	allowedFunctionArgModes     = []string{"IN", "OUT", "INOUT", "VARIADIC"}
+	allowedFunctionVolatilities = []string{"VOLATILE", "STABLE", "IMMUTABLE"}
+
+	// Mapping of pg_proc.proargmodes values to argument modes.
+	functionArgModes = map[string]string{
+		"i": "IN",
+		"o": "OUT",
+		"b": "INOUT",
+		"v": "VARIADIC",
+	}
+
+	// Mapping of pg_proc.provolatile values to volatilities.
+	functionVolatilities = map[string]string{
+		"v": "VOLATILE",
+		"s": "STABLE",
+		"i": "IMMUTABLE",
+	}
+)
+
+func resourcePostgreSQLFunction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLFunctionCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLFunctionExists, resourcePostgreSQLFunctionRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLFunctionUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLFunctionDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			funcNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the function",
+			},
+			funcSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the function is located",
+			},
+			funcDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the function is located",
+			},
+			funcArgAttr: functionArgSchema(allowedFunctionArgModes),
+			funcReturnsAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The return type of the function. Defaults to the type inferred from OUT arguments",
+			},
+			funcLanguageAttr: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "plpgsql",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+				Description: "The language of the function",
+			},
+			funcBodyAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The body of the function",
+			},
+			funcVolatilityAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "VOLATILE",
+				ValidateFunc: validation.StringInSlice(allowedFunctionVolatilities, false),
+				Description:  "The volatility of the function (any of: " + strings.Join(allowedFunctionVolatilities, ", ") + ")",
+			},
+			funcSecurityDefinerAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the function should be executed with the privileges of the user that owns it",
+			},
+			funcStrictAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the function should return null when any of its arguments is null",
+			},
+			funcDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the function, and in turn all objects that depend on those objects",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+// functionArgSchema returns the schema of the arguments of a routine.
+// Arguments are part of the signature of the routine so they cannot be updated.
+func functionArgSchema(allowedModes []string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				funcArgNameAttr: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+					Description: "The name of the argument",
+				},
+				funcArgTypeAttr: {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: "The type of the argument",
+				},
+				funcArgModeAttr: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "IN",
+					ForceNew:     true,
+					ValidateFunc: validation.StringInSlice(allowedModes, false),
+					Description:  "The mode of the argument (any of: " + strings.Join(allowedModes, ", ") + ")",
+				},
+				funcArgDefaultAttr: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+					Description: "An expression to be used as default value if the parameter is not specified",
+				},
+			},
+		},
+		Description: "The arguments of the routine",
+	}
+}
+
+func resourcePostgreSQLFunctionCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := createFunction(db, d, false); err != nil {
+		return err
+	}
+
+	return resourcePostgreSQLFunctionReadImpl(db, d)
+}
+
+func resourcePostgreSQLFunctionExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, signature, err := getDBFunctionSignature(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	query := "SELECT TRUE FROM pg_catalog.pg_proc WHERE oid = to_regprocedure($1)"
+	if db.featureSupported(featureProcedure) {
+		query += " AND prokind = 'f'"
+	}
+
+	var _rez bool
+	err = txn.QueryRow(query, signature).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLFunctionRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLFunctionReadImpl(db, d)
+}
+
+func resourcePostgreSQLFunctionReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, signature, err := getDBFunctionSignature(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var funcName, funcSchema, language, body, volatility, returns string
+	var securityDefiner, strict bool
+
+	query := `SELECT p.proname, n.nspname, l.lanname, p.prosrc, p.provolatile, p.prosecdef, p.proisstrict, ` +
+		`pg_catalog.pg_get_function_result(p.oid) ` +
+		`FROM pg_catalog.pg_proc p ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace ` +
+		`JOIN pg_catalog.pg_language l ON l.oid = p.prolang ` +
+		`WHERE p.oid = to_regprocedure($1)`
+	if db.featureSupported(featureProcedure) {
+		query += " AND p.prokind = 'f'"
+	}
+
+	err = txn.QueryRow(query, signature).Scan(
+		&funcName, &funcSchema, &language, &body, &volatility, &securityDefiner, &strict, &returns,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL function (%s) not found for database %s", signature, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading function: %w", err)
+	}
+
+	// The arguments and the return type are not read back as PostgreSQL normalizes
+	// the type names (e.g.: int becomes integer) which would force a new function.
+	// They are only read when importing.
+	if d.Get(funcNameAttr).(string) == "" {
+		args, err := getFunctionArgs(txn, signature)
+		if err != nil {
+			return err
+		}
+		d.Set(funcArgAttr, args)
+		d.Set(funcReturnsAttr, returns)
+	}
+
+	d.Set(funcNameAttr, funcName)
+	d.Set(funcSchemaAttr, funcSchema)
+	d.Set(funcDatabaseAttr, database)
+	d.Set(funcLanguageAttr, language)
+	d.Set(funcBodyAttr, body)
+	d.Set(funcVolatilityAttr, functionVolatilities[volatility])
+	d.Set(funcSecurityDefinerAttr, securityDefiner)
+	d.Set(funcStrictAttr, strict)
+
+	return nil
+}
+
+func resourcePostgreSQLFunctionUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := createFunction(db, d, true); err != nil {
+		return err
+	}
+
+	return resourcePostgreSQLFunctionReadImpl(db, d)
+}
+
+func resourcePostgreSQLFunctionDelete(db *DBConnection, d *schema.ResourceData) error {
+	database, signature, err := getDBFunctionSignature(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(funcDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP FUNCTION %s %s", signature, dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop function %s: %w", signature, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting function: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// createFunction creates the function or, if replace is true, replaces its definition.
+func createFunction(db *DBConnection, d *schema.ResourceData, replace bool) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE ")
+	if replace {
+		fmt.Fprint(b, "OR REPLACE ")
+	}
+	fmt.Fprint(b, "FUNCTION ", getFunctionQualifiedName(d), "(", strings.Join(getFunctionArgsDefinition(d), ", "), ")")
+
+	if returns, ok := d.GetOk(funcReturnsAttr); ok {
+		fmt.Fprint(b, " RETURNS ", returns.(string))
+	}
+
+	fmt.Fprint(b, " LANGUAGE ", pq.QuoteIdentifier(strings.ToLower(d.Get(funcLanguageAttr).(string))))
+	fmt.Fprint(b, " ", d.Get(funcVolatilityAttr).(string))
+
+	if d.Get(funcStrictAttr).(bool) {
+		fmt.Fprint(b, " STRICT")
+	} else {
+		fmt.Fprint(b, " CALLED ON NULL INPUT")
+	}
+
+	if d.Get(funcSecurityDefinerAttr).(bool) {
+		fmt.Fprint(b, " SECURITY DEFINER")
+	} else {
+		fmt.Fprint(b, " SECURITY INVOKER")
+	}
+
+	fmt.Fprintf(b, " AS '%s'", pqQuoteLiteral(d.Get(funcBodyAttr).(string)))
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create function %s: %w", d.Get(funcNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating function: %w", err)
+	}
+
+	d.SetId(generateFunctionID(d, database))
+
+	return nil
+}
+
+func getFunctionQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(funcSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(funcNameAttr).(string)),
+	)
+}
+
+// getFunctionArgsDefinition returns the arguments as they have to be
+// written in the CREATE statement (e.g.: IN "a" integer DEFAULT 1).
+func getFunctionArgsDefinition(d *schema.ResourceData) []string {
+	args := []string{}
+	for _, rawArg := range d.Get(funcArgAttr).([]interface{}) {
+		arg := rawArg.(map[string]interface{})
+
+		b := bytes.NewBufferString(arg[funcArgModeAttr].(string))
+		if name := arg[funcArgNameAttr].(string); name != "" {
+			fmt.Fprint(b, " ", pq.QuoteIdentifier(name))
+		}
+		fmt.Fprint(b, " ", arg[funcArgTypeAttr].(string))
+		if def := arg[funcArgDefaultAttr].(string); def != "" {
+			fmt.Fprint(b, " DEFAULT ", def)
+		}
+		args = append(args, b.String())
+	}
+
+	return args
+}
+
+// getFunctionArgTypes returns the types of the arguments which identify
+// the function, OUT arguments are not part of its signature.
+func getFunctionArgTypes(d *schema.ResourceData) []string {
+	types := []string{}
+	for _, rawArg := range d.Get(funcArgAttr).([]interface{}) {
+		arg := rawArg.(map[string]interface{})
+		if arg[funcArgModeAttr].(string) == "OUT" {
+			continue
+		}
+		types = append(types, arg[funcArgTypeAttr].(string))
+	}
+
+	return types
+}
+
+// getFunctionArgs reads the arguments of a function from the catalog.
+func getFunctionArgs(txn *sql.Tx, signature string) ([]interface{}, error) {
+	var names, modes, types pq.StringArray
+
+	query := `SELECT COALESCE(p.proargnames, '{}'), COALESCE(p.proargmodes::text[], '{}'), ` +
+		`ARRAY(SELECT pg_catalog.format_type(t.oid, NULL) ` +
+		`FROM unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[])) WITH ORDINALITY AS t(oid, pos) ORDER BY t.pos) ` +
+		`FROM pg_catalog.pg_proc p WHERE p.oid = to_regprocedure($1)`
+	if err := txn.QueryRow(query, signature).Scan(&names, &modes, &types); err != nil {
+		return nil, fmt.Errorf("could not read arguments of function %s: %w", signature, err)
+	}
+
+	args := []interface{}{}
+	for i, argType := range types {
+		mode := "IN"
+		if i < len(modes) {
+			// Columns of a RETURNS TABLE function are part of its return type.
+			if modes[i] == "t" {
+				continue
+			}
+			mode = functionArgModes[modes[i]]
+		}
+
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+
+		args = append(args, map[string]interface{}{
+			funcArgNameAttr: name,
+			funcArgTypeAttr: argType,
+			funcArgModeAttr: mode,
+			// Default values cannot easily be read back from the catalog.
+			funcArgDefaultAttr: "",
+		})
+	}
+
+	return args, nil
+}
+
+func generateFunctionID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(funcSchemaAttr).(string),
+		fmt.Sprintf("%s(%s)", d.Get(funcNameAttr).(string), strings.Join(getFunctionArgTypes(d), ",")),
+	}, ".")
+}
+
+// getDBFunctionSignature returns the database and the signature of the function
+// (e.g.: "public"."my_function"(integer, text)) which can be used to identify it.
+// If we are importing this resource, they will be parsed from the resource ID
+// (it will return an error if parsing failed) otherwise they will be simply get from the state.
+func getDBFunctionSignature(d *schema.ResourceData, client *Client) (string, string, error) {
+	// When importing, we have to parse the ID to find the database, schema and function signature.
+	if d.Get(funcNameAttr).(string) == "" {
+		return getFunctionSignatureFromID(d.Id())
+	}
+
+	database := getDatabase(d, client.databaseName)
+	return database, fmt.Sprintf("%s(%s)", getFunctionQualifiedName(d), strings.Join(getFunctionArgTypes(d), ", ")), nil
+}
+
+// getFunctionSignatureFromID parses an ID in the form database.schema.function(argtypes)
+// and returns the database and the signature of the function.
+func getFunctionSignatureFromID(ID string) (string, string, error) {
+	parsed := strings.SplitN(ID, ".", 3)
+	if len(parsed) != 3 || !strings.HasSuffix(parsed[2], ")") || !strings.Contains(parsed[2], "(") {
+		return "", "", fmt.Errorf(
+			"function ID %s has not the expected format 'database.schema.function(argtypes)': %v",
+			ID, parsed,
+		)
+	}
+
+	nameAndArgs := strings.SplitN(parsed[2], "(", 2)
+	return parsed[0], fmt.Sprintf(
+		"%s.%s(%s",
+		pq.QuoteIdentifier(parsed[1]), pq.QuoteIdentifier(nameAndArgs[0]), nameAndArgs[1],
+	), nil
+}
@@ -0,0 +1,206 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlFunction_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	testAccPostgresqlFunctionConfig := func(body, volatility string) string {
+		return fmt.Sprintf(`
+		resource "postgresql_function" "increment" {
+			name     = "increment"
+			database = "%s"
+			arg {
+				name = "i"
+				type = "integer"
+			}
+			arg {
+				name    = "step"
+				type    = "integer"
+				default = "1"
+			}
+			returns    = "integer"
+			language   = "plpgsql"
+			volatility = "%s"
+			body       = <<-EOF
+				BEGIN
+					RETURN %s;
+				END;
+			EOF
+		}`, dbName, volatility, body)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlFunctionConfig("i + step", "VOLATILE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlFunctionExists("postgresql_function.increment"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "id", fmt.Sprintf("%s.public.increment(integer,integer)", dbName)),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "name", "increment"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "arg.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "arg.1.mode", "IN"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "volatility", "VOLATILE"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "security_definer", "false"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "strict", "false"),
+				),
+			},
+			{
+				Config: testAccPostgresqlFunctionConfig("i + step * 2", "IMMUTABLE"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlFunctionExists("postgresql_function.increment"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "volatility", "IMMUTABLE"),
+					resource.TestCheckResourceAttr("postgresql_function.increment", "body", "BEGIN\n\tRETURN i + step * 2;\nEND;\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlFunction_Overload(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	config := fmt.Sprintf(`
+	resource "postgresql_function" "text" {
+		name     = "describe"
+		database = "%[1]s"
+		arg {
+			type = "text"
+		}
+		returns  = "text"
+		language = "sql"
+		body     = "SELECT 'text: ' || $1"
+	}
+
+	resource "postgresql_function" "integer" {
+		name     = "describe"
+		database = "%[1]s"
+		arg {
+			type = "integer"
+		}
+		returns  = "text"
+		language = "sql"
+		body     = "SELECT 'integer: ' || $1"
+	}
+	`, dbName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlFunctionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlFunctionExists("postgresql_function.text"),
+					testAccCheckPostgresqlFunctionExists("postgresql_function.integer"),
+					resource.TestCheckResourceAttr("postgresql_function.text", "id", fmt.Sprintf("%s.public.describe(text)", dbName)),
+					resource.TestCheckResourceAttr("postgresql_function.integer", "id", fmt.Sprintf("%s.public.describe(integer)", dbName)),
+				),
+			},
+			{
+				ResourceName:      "postgresql_function.integer",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"drop_cascade",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlFunctionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_function" {
+			continue
+		}
+
+		exists, err := checkFunctionExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking function %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Function still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlFunctionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkFunctionExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking function %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Function not found")
+		}
+
+		return nil
+	}
+}
+
+func checkFunctionExists(client *Client, ID string) (bool, error) {
+	database, signature, err := getFunctionSignatureFromID(ID)
+	if err != nil {
+		return false, err
+	}
+
+	txn, err := startTransaction(client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	err = txn.QueryRow("SELECT TRUE FROM pg_catalog.pg_proc WHERE oid = to_regprocedure($1)", signature).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about function: %s", err)
+	}
+
+	return true, nil
+}
@@ -35,11 +35,14 @@ var objectTypes = map[string]string{
 
 func resourcePostgreSQLGrant() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLGrantCreate),
+		CreateContext: PGResourceFunc(resourcePostgreSQLGrantCreate),
 		// As create revokes and grants we can use it to update too
-		Update: PGResourceFunc(resourcePostgreSQLGrantCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLGrantRead),
-		Delete: PGResourceFunc(resourcePostgreSQLGrantDelete),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLGrantCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLGrantRead),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLGrantDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -94,6 +97,14 @@ func resourcePostgreSQLGrant() *schema.Resource {
 }
 
 func resourcePostgreSQLGrantRead(db *DBConnection, d *schema.ResourceData) error {
+	// When importing, we have to parse the ID to find the grant.
+	if d.Get("role").(string) == "" {
+		if err := setGrantFromImportID(d); err != nil {
+			return err
+		}
+		d.SetId(generateGrantID(d))
+	}
+
 	if err := validateFeatureSupport(db, d); err != nil {
 		return fmt.Errorf("feature is not supported: %v", err)
 	}
@@ -114,7 +125,7 @@ func resourcePostgreSQLGrantRead(db *DBConnection, d *schema.ResourceData) error
 	}
 	defer deferredRollback(txn)
 
-	return readRolePrivileges(txn, d)
+	return readRolePrivileges(db, txn, d)
 }
 
 func resourcePostgreSQLGrantCreate(db *DBConnection, d *schema.ResourceData) error {
@@ -146,7 +157,7 @@ func resourcePostgreSQLGrantCreate(db *DBConnection, d *schema.ResourceData) err
 	defer deferredRollback(txn)
 
 	role := d.Get("role").(string)
-	if err := pgLockRole(txn, role); err != nil {
+	if err := db.lockRole(txn, role); err != nil {
 		return err
 	}
 
@@ -154,7 +165,7 @@ func resourcePostgreSQLGrantCreate(db *DBConnection, d *schema.ResourceData) err
 	if err != nil {
 		return err
 	}
-	if err := withRolesGranted(txn, owners, func() error {
+	if err := db.withRolesGranted(txn, owners, func() error {
 		// Revoke all privileges before granting otherwise reducing privileges will not work.
 		// We just have to revoke them in the same transaction so the role will not lost its
 		// privileges between the revoke and grant statements.
@@ -181,7 +192,7 @@ func resourcePostgreSQLGrantCreate(db *DBConnection, d *schema.ResourceData) err
 	}
 	defer deferredRollback(txn)
 
-	return readRolePrivileges(txn, d)
+	return readRolePrivileges(db, txn, d)
 }
 
 func resourcePostgreSQLGrantDelete(db *DBConnection, d *schema.ResourceData) error {
@@ -196,7 +207,7 @@ func resourcePostgreSQLGrantDelete(db *DBConnection, d *schema.ResourceData) err
 	defer deferredRollback(txn)
 
 	role := d.Get("role").(string)
-	if err := pgLockRole(txn, role); err != nil {
+	if err := db.lockRole(txn, role); err != nil {
 		return err
 	}
 
@@ -205,7 +216,7 @@ func resourcePostgreSQLGrantDelete(db *DBConnection, d *schema.ResourceData) err
 		return err
 	}
 
-	if err := withRolesGranted(txn, owners, func() error {
+	if err := db.withRolesGranted(txn, owners, func() error {
 		return revokeRolePrivileges(txn, d)
 	}); err != nil {
 		return err
@@ -221,7 +232,7 @@ func resourcePostgreSQLGrantDelete(db *DBConnection, d *schema.ResourceData) err
 func readDatabaseRolePriviges(txn *sql.Tx, d *schema.ResourceData, roleOID int) error {
 	dbName := d.Get("database").(string)
 	query := `
-SELECT array_agg(privilege_type)
+SELECT array_agg(privilege_type), bool_and(is_grantable)
 FROM (
 	SELECT (aclexplode(datacl)).* FROM pg_database WHERE datname=$1
 ) as privileges
@@ -229,18 +240,20 @@ WHERE grantee = $2
 `
 
 	var privileges pq.ByteaArray
-	if err := txn.QueryRow(query, dbName, roleOID).Scan(&privileges); err != nil {
+	var grantable sql.NullBool
+	if err := txn.QueryRow(query, dbName, roleOID).Scan(&privileges, &grantable); err != nil {
 		return fmt.Errorf("could not read privileges for database %s: %w", dbName, err)
 	}
 
 	d.Set("privileges", pgArrayToSet(privileges))
+	setGrantOption(d, grantable)
 	return nil
 }
 
 func readSchemaRolePriviges(txn *sql.Tx, d *schema.ResourceData, roleOID int) error {
 	dbName := d.Get("schema").(string)
 	query := `
-SELECT array_agg(privilege_type)
+SELECT array_agg(privilege_type), bool_and(is_grantable)
 FROM (
 	SELECT (aclexplode(nspacl)).* FROM pg_namespace WHERE nspname=$1
 ) as privileges
@@ -248,11 +261,13 @@ WHERE grantee = $2
 `
 
 	var privileges pq.ByteaArray
-	if err := txn.QueryRow(query, dbName, roleOID).Scan(&privileges); err != nil {
+	var grantable sql.NullBool
+	if err := txn.QueryRow(query, dbName, roleOID).Scan(&privileges, &grantable); err != nil {
 		return fmt.Errorf("could not read privileges for schema %s: %w", dbName, err)
 	}
 
 	d.Set("privileges", pgArrayToSet(privileges))
+	setGrantOption(d, grantable)
 	return nil
 }
 
@@ -260,7 +275,7 @@ func readForeignDataWrapperRolePrivileges(txn *sql.Tx, d *schema.ResourceData, r
 	objects := d.Get("objects").(*schema.Set).List()
 	fdwName := objects[0].(string)
 	query := `
-SELECT pg_catalog.array_agg(privilege_type)
+SELECT pg_catalog.array_agg(privilege_type), pg_catalog.bool_and(is_grantable)
 FROM (
 	SELECT (pg_catalog.aclexplode(fdwacl)).* FROM pg_catalog.pg_foreign_data_wrapper WHERE fdwname=$1
 ) as privileges
@@ -268,11 +283,13 @@ WHERE grantee = $2
 `
 
 	var privileges pq.ByteaArray
-	if err := txn.QueryRow(query, fdwName, roleOID).Scan(&privileges); err != nil {
+	var grantable sql.NullBool
+	if err := txn.QueryRow(query, fdwName, roleOID).Scan(&privileges, &grantable); err != nil {
 		return fmt.Errorf("could not read privileges for foreign data wrapper %s: %w", fdwName, err)
 	}
 
 	d.Set("privileges", pgArrayToSet(privileges))
+	setGrantOption(d, grantable)
 	return nil
 }
 
@@ -280,7 +297,7 @@ func readForeignServerRolePrivileges(txn *sql.Tx, d *schema.ResourceData, roleOI
 	objects := d.Get("objects").(*schema.Set).List()
 	srvName := objects[0].(string)
 	query := `
-SELECT pg_catalog.array_agg(privilege_type)
+SELECT pg_catalog.array_agg(privilege_type), pg_catalog.bool_and(is_grantable)
 FROM (
 	SELECT (pg_catalog.aclexplode(srvacl)).* FROM pg_catalog.pg_foreign_server WHERE srvname=$1
 ) as privileges
@@ -288,15 +305,24 @@ WHERE grantee = $2
 `
 
 	var privileges pq.ByteaArray
-	if err := txn.QueryRow(query, srvName, roleOID).Scan(&privileges); err != nil {
+	var grantable sql.NullBool
+	if err := txn.QueryRow(query, srvName, roleOID).Scan(&privileges, &grantable); err != nil {
 		return fmt.Errorf("could not read privileges for foreign server %s: %w", srvName, err)
 	}
 
 	d.Set("privileges", pgArrayToSet(privileges))
+	setGrantOption(d, grantable)
 	return nil
 }
 
-func readRolePrivileges(txn *sql.Tx, d *schema.ResourceData) error {
+func readRolePrivileges(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
+	switch {
+	case db.isRedshift():
+		return readRedshiftRolePrivileges(txn, d)
+	case db.isCockroachDB():
+		return readCockroachDBRolePrivileges(txn, d)
+	}
+
 	role := d.Get("role").(string)
 	objectType := d.Get("object_type").(string)
 	objects := d.Get("objects").(*schema.Set)
@@ -324,7 +350,7 @@ func readRolePrivileges(txn *sql.Tx, d *schema.ResourceData) error {
 
 	case "function", "procedure", "routine":
 		query = `
-SELECT pg_proc.proname, array_remove(array_agg(privilege_type), NULL)
+SELECT pg_proc.proname, array_remove(array_agg(privilege_type), NULL), bool_and(is_grantable)
 FROM pg_proc
 JOIN pg_namespace ON pg_namespace.oid = pg_proc.pronamespace
 LEFT JOIN (
@@ -344,7 +370,7 @@ GROUP BY pg_proc.proname
 
 	default:
 		query = `
-SELECT pg_class.relname, array_remove(array_agg(privilege_type), NULL)
+SELECT pg_class.relname, array_remove(array_agg(privilege_type), NULL), bool_and(is_grantable)
 FROM pg_class
 JOIN pg_namespace ON pg_namespace.oid = pg_class.relnamespace
 LEFT JOIN (
@@ -370,12 +396,16 @@ GROUP BY pg_class.relname
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
 
+	// The privileges are granted with the grant option if they all have it.
+	var grantOption sql.NullBool
 	for rows.Next() {
 		var objName string
 		var privileges pq.ByteaArray
+		var grantable sql.NullBool
 
-		if err := rows.Scan(&objName, &privileges); err != nil {
+		if err := rows.Scan(&objName, &privileges, &grantable); err != nil {
 			return err
 		}
 
@@ -383,6 +413,10 @@ GROUP BY pg_class.relname
 			continue
 		}
 
+		if grantable.Valid {
+			grantOption = sql.NullBool{Bool: grantable.Bool && (!grantOption.Valid || grantOption.Bool), Valid: true}
+		}
+
 		privilegesSet := pgArrayToSet(privileges)
 
 		if !privilegesSet.Equal(d.Get("privileges").(*schema.Set)) {
@@ -396,10 +430,23 @@ GROUP BY pg_class.relname
 			break
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
+	setGrantOption(d, grantOption)
 	return nil
 }
 
+// setGrantOption sets with_grant_option from the grant option of the privileges read,
+// which is NULL if the role has none of them: with_grant_option is then kept, the
+// privileges being read as missing already.
+func setGrantOption(d *schema.ResourceData, grantable sql.NullBool) {
+	if grantable.Valid {
+		d.Set("with_grant_option", grantable.Bool)
+	}
+}
+
 func createGrantQuery(d *schema.ResourceData, privileges []string) string {
 	var query string
 
@@ -594,6 +641,42 @@ func checkRoleDBSchemaExists(client *Client, d *schema.ResourceData) (bool, erro
 	return true, nil
 }
 
+// setGrantFromImportID sets the arguments of the grant from its import ID:
+// role/database/database, role/database/foreign_data_wrapper/object, role/database/foreign_server/object,
+// or role/database/schema/object_type[/object,...] for the objects of a schema.
+func setGrantFromImportID(d *schema.ResourceData) error {
+	parsed := strings.Split(d.Id(), "/")
+	if len(parsed) < 3 {
+		return fmt.Errorf("grant ID %s has not the expected format 'role/database/[schema/]object_type[/objects]': %v", d.Id(), parsed)
+	}
+
+	var schemaName string
+	var objects []string
+	objectType := parsed[2]
+	switch {
+	case objectType == "database" && len(parsed) == 3:
+	case (objectType == "foreign_data_wrapper" || objectType == "foreign_server") && len(parsed) == 4:
+		objects = parsed[3:]
+	case len(parsed) == 4 || len(parsed) == 5:
+		schemaName, objectType = parsed[2], parsed[3]
+		if len(parsed) == 5 {
+			objects = strings.Split(parsed[4], ",")
+		}
+	default:
+		return fmt.Errorf("grant ID %s has not the expected format 'role/database/[schema/]object_type[/objects]': %v", d.Id(), parsed)
+	}
+	if !sliceContainsStr(allowedObjectTypes, objectType) {
+		return fmt.Errorf("invalid object type %s in grant ID %s, must be one of: %s", objectType, d.Id(), strings.Join(allowedObjectTypes, ", "))
+	}
+
+	d.Set("role", parsed[0])
+	d.Set("database", parsed[1])
+	d.Set("schema", schemaName)
+	d.Set("object_type", objectType)
+	d.Set("objects", stringSliceToSet(objects))
+	return nil
+}
+
 func generateGrantID(d *schema.ResourceData) string {
 	parts := []string{d.Get("role").(string), d.Get("database").(string)}
 
@@ -649,6 +732,10 @@ func validateFeatureSupport(db *DBConnection, d *schema.ResourceData) error {
 			db.version,
 		)
 	}
+	objectType := d.Get("object_type").(string)
+	if objectTypes, ok := compatibilityGrantObjectTypes[db.compatibilityMode]; ok && !sliceContainsStr(objectTypes, objectType) {
+		return db.errNotSupportedInCompatibilityMode("object type " + strings.ToUpper(objectType))
+	}
 	if d.Get("object_type") == "procedure" && !db.featureSupported(featureProcedure) {
 		return fmt.Errorf(
 			"object type PROCEDURE is not supported for this Postgres version (%s)",
@@ -28,9 +28,13 @@ WHERE
 
 func resourcePostgreSQLGrantRole() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLGrantRoleCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLGrantRoleRead),
-		Delete: PGResourceFunc(resourcePostgreSQLGrantRoleDelete),
+		CreateContext: PGResourceFunc(resourcePostgreSQLGrantRoleCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLGrantRoleRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLGrantRoleUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLGrantRoleDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -48,7 +52,6 @@ func resourcePostgreSQLGrantRole() *schema.Resource {
 			"with_admin_option": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     false,
 				Description: "Permit the grant recipient to grant it to others",
 			},
@@ -99,6 +102,40 @@ func resourcePostgreSQLGrantRoleCreate(db *DBConnection, d *schema.ResourceData)
 	return readGrantRole(db, d)
 }
 
+func resourcePostgreSQLGrantRoleUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePrivileges) {
+		return fmt.Errorf(
+			"postgresql_grant_role resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if !d.HasChange("with_admin_option") {
+		return readGrantRole(db, d)
+	}
+
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// The admin option is granted or revoked without revoking the membership itself.
+	query := createGrantRoleQuery(d)
+	if !d.Get("with_admin_option").(bool) {
+		query = createRevokeAdminOptionQuery(d)
+	}
+	if _, err := txn.Exec(query); err != nil {
+		return fmt.Errorf("could not update admin option: %w", err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return readGrantRole(db, d)
+}
+
 func resourcePostgreSQLGrantRoleDelete(db *DBConnection, d *schema.ResourceData) error {
 	if !db.featureSupported(featurePrivileges) {
 		return fmt.Errorf(
@@ -129,6 +166,16 @@ func readGrantRole(db QueryAble, d *schema.ResourceData) error {
 	var withAdminOption bool
 
 	grantRoleID := d.Id()
+	role, grantRole := d.Get("role").(string), d.Get("grant_role").(string)
+
+	// When importing, we have to parse the ID to find the role and granted role names.
+	if role == "" {
+		parsed := strings.Split(grantRoleID, "/")
+		if len(parsed) != 2 {
+			return fmt.Errorf("grant role ID %s has not the expected format 'role/grant_role': %v", grantRoleID, parsed)
+		}
+		role, grantRole = parsed[0], parsed[1]
+	}
 
 	values := []interface{}{
 		&roleName,
@@ -136,7 +183,7 @@ func readGrantRole(db QueryAble, d *schema.ResourceData) error {
 		&withAdminOption,
 	}
 
-	err := db.QueryRow(getGrantRoleQuery, d.Get("role"), d.Get("grant_role")).Scan(values...)
+	err := db.QueryRow(getGrantRoleQuery, role, grantRole).Scan(values...)
 	switch {
 	case err == sql.ErrNoRows:
 		log.Printf("[WARN] PostgreSQL grant role (%q) not found", grantRoleID)
@@ -182,6 +229,17 @@ func createRevokeRoleQuery(d *schema.ResourceData) string {
 	)
 }
 
+func createRevokeAdminOptionQuery(d *schema.ResourceData) string {
+	grantRole, _ := d.Get("grant_role").(string)
+	role, _ := d.Get("role").(string)
+
+	return fmt.Sprintf(
+		"REVOKE ADMIN OPTION FOR %s FROM %s",
+		pq.QuoteIdentifier(grantRole),
+		pq.QuoteIdentifier(role),
+	)
+}
+
 func grantRole(txn *sql.Tx, d *schema.ResourceData) error {
 	query := createGrantRoleQuery(d)
 	if _, err := txn.Exec(query); err != nil {
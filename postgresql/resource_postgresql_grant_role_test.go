@@ -92,6 +92,21 @@ func TestRevokeRoleQuery(t *testing.T) {
 	}
 }
 
+func TestRevokeAdminOptionQuery(t *testing.T) {
+	var roleName = "foo"
+	var grantRoleName = "bar"
+
+	expected := fmt.Sprintf("REVOKE ADMIN OPTION FOR %s FROM %s", pq.QuoteIdentifier(grantRoleName), pq.QuoteIdentifier(roleName))
+
+	out := createRevokeAdminOptionQuery(schema.TestResourceDataRaw(t, resourcePostgreSQLGrantRole().Schema, map[string]interface{}{
+		"role":       roleName,
+		"grant_role": grantRoleName,
+	}))
+	if out != expected {
+		t.Fatalf("Error matching output and expected: %#v vs %#v", out, expected)
+	}
+}
+
 func TestAccPostgresqlGrantRole(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -105,16 +120,16 @@ func TestAccPostgresqlGrantRole(t *testing.T) {
 
 	grantedRoleName := "foo"
 
-	testAccPostgresqlGrantRoleResources := fmt.Sprintf(`
+	testAccPostgresqlGrantRoleResources := `
 	resource postgresql_role "grant" {
 		name = "%s"
 	}
 	resource postgresql_grant_role "grant_role" {
 		role              = "%s"
 		grant_role        = postgresql_role.grant.name
-		with_admin_option = true
+		with_admin_option = %t
 	}
-	`, grantedRoleName, roleName)
+	`
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -124,7 +139,7 @@ func TestAccPostgresqlGrantRole(t *testing.T) {
 		Providers: testAccProviders,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccPostgresqlGrantRoleResources,
+				Config: fmt.Sprintf(testAccPostgresqlGrantRoleResources, grantedRoleName, roleName, true),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(
 						"postgresql_grant_role.grant_role", "role", roleName),
@@ -135,6 +150,21 @@ func TestAccPostgresqlGrantRole(t *testing.T) {
 					checkGrantRole(t, dsn, roleName, grantedRoleName, true),
 				),
 			},
+			{
+				// The admin option is revoked in place.
+				Config: fmt.Sprintf(testAccPostgresqlGrantRoleResources, grantedRoleName, roleName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"postgresql_grant_role.grant_role", "with_admin_option", strconv.FormatBool(false)),
+					checkGrantRole(t, dsn, roleName, grantedRoleName, false),
+				),
+			},
+			{
+				ResourceName:      "postgresql_grant_role.grant_role",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s", roleName, grantedRoleName),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
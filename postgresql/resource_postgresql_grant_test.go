@@ -264,6 +264,49 @@ func TestCreateRevokeQuery(t *testing.T) {
 	}
 }
 
+func TestSetGrantFromImportID(t *testing.T) {
+	var tests = []struct {
+		id         string
+		schema     string
+		objectType string
+		objects    []string
+		wantErr    bool
+	}{
+		{id: "test_role/test_db/database", objectType: "database"},
+		{id: "test_role/test_db/foreign_server/test_srv", objectType: "foreign_server", objects: []string{"test_srv"}},
+		{id: "test_role/test_db/test_schema/schema", schema: "test_schema", objectType: "schema"},
+		{id: "test_role/test_db/test_schema/table", schema: "test_schema", objectType: "table"},
+		{id: "test_role/test_db/test_schema/table/t1,t2", schema: "test_schema", objectType: "table", objects: []string{"t1", "t2"}},
+		{id: "test_role/test_db", wantErr: true},
+		{id: "test_role/test_db/test_schema/view", wantErr: true},
+		{id: "test_role_test_db_test_schema_table", wantErr: true},
+	}
+
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, resourcePostgreSQLGrant().Schema, map[string]interface{}{})
+		d.SetId(test.id)
+
+		err := setGrantFromImportID(d)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("setGrantFromImportID(%s) should have failed", test.id)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("setGrantFromImportID(%s) returned an error: %v", test.id, err)
+			continue
+		}
+
+		if d.Get("role") != "test_role" || d.Get("database") != "test_db" || d.Get("schema") != test.schema || d.Get("object_type") != test.objectType {
+			t.Errorf("setGrantFromImportID(%s) set role %v, database %v, schema %v, object_type %v", test.id, d.Get("role"), d.Get("database"), d.Get("schema"), d.Get("object_type"))
+		}
+		if objects := d.Get("objects").(*schema.Set); !objects.Equal(stringSliceToSet(test.objects)) {
+			t.Errorf("setGrantFromImportID(%s) set objects %v, want %v", test.id, objects.List(), test.objects)
+		}
+	}
+}
+
 func TestAccPostgresqlGrant(t *testing.T) {
 	skipIfNotAcc(t)
 
@@ -880,6 +923,12 @@ resource "postgresql_grant" "test" {
 					testCheckDatabasesPrivileges(t, true),
 				),
 			},
+			{
+				ResourceName:      "postgresql_grant.test",
+				ImportState:       true,
+				ImportStateId:     "test_grant_role/test_grant_db/database",
+				ImportStateVerify: true,
+			},
 			// Revoke
 			{
 				Config: fmt.Sprintf(config, "[]"),
@@ -940,6 +989,12 @@ resource "postgresql_grant" "test" {
 					testCheckSchemaPrivileges(t, true, true),
 				),
 			},
+			{
+				ResourceName:      "postgresql_grant.test",
+				ImportState:       true,
+				ImportStateId:     "test_grant_role/postgres/test_schema/schema",
+				ImportStateVerify: true,
+			},
 			{
 				//Config: fmt.Sprintf(config, "[]"),
 				Config: fmt.Sprintf(config, `[]`),
@@ -952,6 +1007,54 @@ resource "postgresql_grant" "test" {
 	})
 }
 
+func TestAccPostgresqlGrantSchemaWithGrantOption(t *testing.T) {
+	config := fmt.Sprintf(`
+resource "postgresql_role" "test" {
+	name     = "test_grant_role"
+	password = "%s"
+	login    = true
+}
+
+resource "postgresql_schema" "test_schema" {
+	depends_on   = [postgresql_role.test]
+	name         = "test_schema"
+	drop_cascade = true
+}
+
+resource "postgresql_grant" "test" {
+	database          = "postgres"
+	schema            = postgresql_schema.test_schema.name
+	role              = postgresql_role.test.name
+	object_type       = "schema"
+	privileges        = ["USAGE"]
+	with_grant_option = true
+}
+`, testRolePassword)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePrivileges)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_grant.test", "privileges.#", "1"),
+					resource.TestCheckResourceAttr("postgresql_grant.test", "with_grant_option", "true"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_grant.test",
+				ImportState:       true,
+				ImportStateId:     "test_grant_role/postgres/test_schema/schema",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccPostgresqlGrantForeignDataWrapper(t *testing.T) {
 	skipIfNotAcc(t)
 	skipIfNotSuperuser(t)
@@ -0,0 +1,362 @@
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	indexNameAttr         = "name"
+	indexSchemaAttr       = "schema"
+	indexDatabaseAttr     = "database"
+	indexTableAttr        = "table"
+	indexColumnsAttr      = "columns"
+	indexMethodAttr       = "method"
+	indexUniqueAttr       = "unique"
+	indexWhereAttr        = "where"
+	indexConcurrentlyAttr = "concurrently"
+	indexDropCascadeAttr  = "drop_cascade"
+)
+
+func resourcePostgreSQLIndex() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceContextFunc(resourcePostgreSQLIndexCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLIndexExists, resourcePostgreSQLIndexRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLIndexUpdate),
+		DeleteContext: PGResourceContextFunc(resourcePostgreSQLIndexDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		// Building an index on a large table can take a while, even more so concurrently.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultLongRunningTimeout),
+			Delete: schema.DefaultTimeout(defaultLongRunningTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			indexNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the index",
+			},
+			indexSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the table (and of the index)",
+			},
+			indexDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the index is located",
+			},
+			indexTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the table to be indexed",
+			},
+			indexColumnsAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The columns or expressions (in parentheses) of the index, optionally followed by a sort order or an operator class",
+			},
+			indexMethodAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "btree",
+				ForceNew:    true,
+				Description: "The name of the index method to be used",
+			},
+			indexUniqueAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "If the index is unique",
+			},
+			indexWhereAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The predicate of a partial index",
+			},
+			indexConcurrentlyAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the index is created and dropped without locking out writes on the table",
+			},
+			indexDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the index",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLIndexCreate(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(indexNameAttr).(string)
+	concurrently := d.Get(indexConcurrentlyAttr).(bool)
+
+	b := bytes.NewBufferString("CREATE ")
+	if d.Get(indexUniqueAttr).(bool) {
+		fmt.Fprint(b, "UNIQUE ")
+	}
+	fmt.Fprint(b, "INDEX ")
+	if concurrently {
+		fmt.Fprint(b, "CONCURRENTLY ")
+	}
+
+	// Columns are not quoted so they can be expressions or include a sort order or an operator class.
+	columns := []string{}
+	for _, column := range d.Get(indexColumnsAttr).([]interface{}) {
+		columns = append(columns, column.(string))
+	}
+
+	fmt.Fprintf(b, "%s ON %s.%s USING %s (%s)",
+		pq.QuoteIdentifier(name),
+		pq.QuoteIdentifier(d.Get(indexSchemaAttr).(string)), pq.QuoteIdentifier(d.Get(indexTableAttr).(string)),
+		pq.QuoteIdentifier(d.Get(indexMethodAttr).(string)),
+		strings.Join(columns, ", "),
+	)
+
+	if v, ok := d.GetOk(indexWhereAttr); ok {
+		fmt.Fprint(b, " WHERE ", v.(string))
+	}
+
+	if concurrently {
+		// CREATE INDEX CONCURRENTLY cannot be executed inside a transaction block.
+		conn, err := connectToDatabase(db.client, database)
+		if err != nil {
+			return err
+		}
+
+		if err := conn.execOutsideTransaction(ctx, b.String()); err != nil {
+			// A failed concurrent build leaves an invalid index behind, we try to clean it up.
+			sql := fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s", getIndexQualifiedName(d))
+			// The context may be done (e.g.: timeout of the build), the index is dropped anyway.
+			if dropErr := conn.execOutsideTransaction(context.Background(), sql); dropErr != nil {
+				log.Printf("[WARN] could not drop invalid index %s: %v", name, dropErr)
+			}
+			return fmt.Errorf("could not create index %s: %w", name, err)
+		}
+	} else {
+		txn, err := startTransaction(db.client, database)
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		if _, err := txn.ExecContext(ctx, b.String()); err != nil {
+			return fmt.Errorf("could not create index %s: %w", name, err)
+		}
+
+		if err = txn.Commit(); err != nil {
+			return fmt.Errorf("Error creating index: %w", err)
+		}
+	}
+
+	d.SetId(generateIndexID(d, database))
+
+	return resourcePostgreSQLIndexReadImpl(db, d)
+}
+
+func resourcePostgreSQLIndexExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, indexSchema, indexName, err := getDBIndexName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return relationExists(txn, indexSchema, indexName, "i")
+}
+
+func resourcePostgreSQLIndexRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLIndexReadImpl(db, d)
+}
+
+func resourcePostgreSQLIndexReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, indexSchema, indexName, err := getDBIndexName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var indexOID, columnCount int
+	var table, method string
+	var unique, valid bool
+	var where sql.NullString
+
+	query := `SELECT ic.oid, tc.relname, a.amname, i.indisunique, i.indisvalid, i.indnatts, ` +
+		`pg_catalog.pg_get_expr(i.indpred, i.indrelid, true) ` +
+		`FROM pg_catalog.pg_index i ` +
+		`JOIN pg_catalog.pg_class ic ON ic.oid = i.indexrelid ` +
+		`JOIN pg_catalog.pg_class tc ON tc.oid = i.indrelid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = ic.relnamespace ` +
+		`JOIN pg_catalog.pg_am a ON a.oid = ic.relam ` +
+		`WHERE n.nspname = $1 AND ic.relname = $2`
+	err = txn.QueryRow(query, indexSchema, indexName).Scan(&indexOID, &table, &method, &unique, &valid, &columnCount, &where)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL index (%s.%s) not found for database %s", indexSchema, indexName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading index: %w", err)
+	}
+
+	// An invalid index (e.g.: after a failed concurrent build) is not used by the planner,
+	// it has to be recreated.
+	if !valid {
+		log.Printf("[WARN] PostgreSQL index (%s.%s) is invalid for database %s", indexSchema, indexName, database)
+		d.SetId("")
+		return nil
+	}
+
+	// PostgreSQL normalizes the expressions, so the columns and the predicate
+	// are only read from the server when importing.
+	if d.Get(indexTableAttr).(string) == "" {
+		columns := []string{}
+		for i := 1; i <= columnCount; i++ {
+			var column string
+			if err := txn.QueryRow("SELECT pg_catalog.pg_get_indexdef($1, $2, true)", indexOID, i).Scan(&column); err != nil {
+				return fmt.Errorf("Error reading index columns: %w", err)
+			}
+			columns = append(columns, column)
+		}
+
+		d.Set(indexColumnsAttr, columns)
+		d.Set(indexWhereAttr, where.String)
+	}
+
+	d.Set(indexNameAttr, indexName)
+	d.Set(indexSchemaAttr, indexSchema)
+	d.Set(indexDatabaseAttr, database)
+	d.Set(indexTableAttr, table)
+	d.Set(indexMethodAttr, method)
+	d.Set(indexUniqueAttr, unique)
+
+	return nil
+}
+
+func resourcePostgreSQLIndexUpdate(db *DBConnection, d *schema.ResourceData) error {
+	// All the attributes force a new resource, except concurrently and drop_cascade
+	// which are only used on create and destroy.
+	return resourcePostgreSQLIndexReadImpl(db, d)
+}
+
+func resourcePostgreSQLIndexDelete(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(indexNameAttr).(string)
+
+	dropMode := "RESTRICT"
+	if d.Get(indexDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	// DROP INDEX CONCURRENTLY does not support CASCADE.
+	if d.Get(indexConcurrentlyAttr).(bool) && dropMode == "RESTRICT" {
+		// DROP INDEX CONCURRENTLY cannot be executed inside a transaction block.
+		conn, err := connectToDatabase(db.client, database)
+		if err != nil {
+			return err
+		}
+
+		sql := fmt.Sprintf("DROP INDEX CONCURRENTLY %s %s", getIndexQualifiedName(d), dropMode)
+		if err := conn.execOutsideTransaction(ctx, sql); err != nil {
+			return fmt.Errorf("could not drop index %s: %w", name, err)
+		}
+	} else {
+		txn, err := startTransaction(db.client, database)
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		sql := fmt.Sprintf("DROP INDEX %s %s", getIndexQualifiedName(d), dropMode)
+		if _, err := txn.ExecContext(ctx, sql); err != nil {
+			return fmt.Errorf("could not drop index %s: %w", name, err)
+		}
+
+		if err = txn.Commit(); err != nil {
+			return fmt.Errorf("Error deleting index: %w", err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getIndexQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(indexSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(indexNameAttr).(string)),
+	)
+}
+
+func generateIndexID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(indexSchemaAttr).(string),
+		d.Get(indexNameAttr).(string),
+	}, ".")
+}
+
+// getDBIndexName returns the database, schema and name of the index. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBIndexName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	indexSchema := d.Get(indexSchemaAttr).(string)
+	indexName := d.Get(indexNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the index, schema and database names.
+	if indexName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("index ID %s has not the expected format 'database.schema.index': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		indexSchema = parsed[1]
+		indexName = parsed[2]
+	}
+
+	return database, indexSchema, indexName, nil
+}
@@ -0,0 +1,209 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlIndex_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE users (id integer PRIMARY KEY, email text, active boolean)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_index" "test" {
+					name         = "users_active_email_idx"
+					database     = "%s"
+					table        = "users"
+					columns      = ["email"]
+					unique       = true
+					where        = "active"
+					concurrently = true
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlIndexExists("postgresql_index.test"),
+					resource.TestCheckResourceAttr("postgresql_index.test", "id", fmt.Sprintf("%s.public.users_active_email_idx", dbName)),
+					resource.TestCheckResourceAttr("postgresql_index.test", "method", "btree"),
+					resource.TestCheckResourceAttr("postgresql_index.test", "unique", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_index" "test" {
+					name     = "users_active_email_idx"
+					database = "%s"
+					table    = "users"
+					columns  = ["(lower(email))", "id DESC"]
+					method   = "btree"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlIndexExists("postgresql_index.test"),
+					resource.TestCheckResourceAttr("postgresql_index.test", "unique", "false"),
+					resource.TestCheckResourceAttr("postgresql_index.test", "columns.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_index.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{indexColumnsAttr, indexConcurrentlyAttr, indexDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlIndex_AssumeRole(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE users (id integer PRIMARY KEY, email text)")
+	dbExecute(t, config.connStr(dbName), fmt.Sprintf("ALTER TABLE users OWNER TO %s", roleName))
+
+	// With transaction_pooling, the connections to the database are shared with the provider,
+	// the role of the resource is set in its transactions.
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				provider "postgresql" {
+					transaction_pooling = true
+				}
+
+				resource "postgresql_index" "test" {
+					name        = "users_email_idx"
+					database    = "%s"
+					table       = "users"
+					columns     = ["email"]
+					assume_role = "%s"
+				}`, dbName, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlIndexExists("postgresql_index.test"),
+					testCheckOwner(t, dbName, "SELECT pg_catalog.pg_get_userbyid(relowner) FROM pg_catalog.pg_class WHERE relname = $1", "users_email_idx", roleName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlIndex_TransactionPoolingConcurrently(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE users (id integer PRIMARY KEY, email text)")
+
+	// CREATE INDEX CONCURRENTLY can't run in a transaction, where the role is set.
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlIndexDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				provider "postgresql" {
+					transaction_pooling = true
+					assume_role         = "%s"
+				}
+
+				resource "postgresql_index" "test" {
+					name         = "users_email_idx"
+					database     = "%s"
+					table        = "users"
+					columns      = ["email"]
+					concurrently = true
+				}`, roleName, dbName),
+				ExpectError: regexp.MustCompile("can't run in a transaction"),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlIndexDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_index" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[indexDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[indexSchemaAttr], rs.Primary.Attributes[indexNameAttr], "i")
+		if err != nil {
+			return fmt.Errorf("Error checking index %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Index still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlIndexExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[indexDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[indexSchemaAttr], rs.Primary.Attributes[indexNameAttr], "i")
+		if err != nil {
+			return fmt.Errorf("Error checking index %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Index not found")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,463 @@
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	matviewNameAttr              = "name"
+	matviewSchemaAttr            = "schema"
+	matviewDatabaseAttr          = "database"
+	matviewQueryAttr             = "query"
+	matviewDefinitionAttr        = "definition"
+	matviewWithDataAttr          = "with_data"
+	matviewRefreshOnChangeAttr   = "refresh_on_change"
+	matviewTablespaceAttr        = "tablespace"
+	matviewStorageParametersAttr = "storage_parameters"
+	matviewDropCascadeAttr       = "drop_cascade"
+)
+
+var storageParameterNameRegexp = regexp.MustCompile(`^[a-z_]+$`)
+
+func resourcePostgreSQLMaterializedView() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceContextFunc(resourcePostgreSQLMaterializedViewCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLMaterializedViewExists, resourcePostgreSQLMaterializedViewRead),
+		UpdateContext: PGResourceContextFunc(resourcePostgreSQLMaterializedViewUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLMaterializedViewDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		// Populating the materialized view runs its query, which can take a while.
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultLongRunningTimeout),
+			Update: schema.DefaultTimeout(defaultLongRunningTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			matviewNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the materialized view",
+			},
+			matviewSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the materialized view is located",
+			},
+			matviewDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the materialized view is located",
+			},
+			matviewQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SELECT query of the materialized view",
+			},
+			matviewDefinitionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The query of the materialized view as reconstructed by PostgreSQL",
+			},
+			matviewWithDataAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If the materialized view should be populated when it is created",
+			},
+			matviewRefreshOnChangeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If the materialized view should be populated when it is recreated after a change of its query",
+			},
+			matviewTablespaceAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The tablespace of the materialized view",
+			},
+			matviewStorageParametersAttr: {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateStorageParameters,
+				Description:  "The storage parameters of the materialized view (e.g.: fillfactor)",
+			},
+			matviewDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the objects depending on the materialized view will be dropped when the materialized view is dropped or recreated",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLMaterializedViewCreate(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.ExecContext(ctx, getMaterializedViewCreateQuery(d, d.Get(matviewWithDataAttr).(bool))); err != nil {
+		return fmt.Errorf("could not create materialized view %s: %w", d.Get(matviewNameAttr).(string), err)
+	}
+
+	if err := setMaterializedViewDefinition(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating materialized view: %w", err)
+	}
+
+	d.SetId(generateMaterializedViewID(d, database))
+
+	return resourcePostgreSQLMaterializedViewReadImpl(db, d)
+}
+
+func resourcePostgreSQLMaterializedViewExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, matviewSchema, matviewName, err := getDBMaterializedViewName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return relationExists(txn, matviewSchema, matviewName, "m")
+}
+
+func resourcePostgreSQLMaterializedViewRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLMaterializedViewReadImpl(db, d)
+}
+
+func resourcePostgreSQLMaterializedViewReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, matviewSchema, matviewName, err := getDBMaterializedViewName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var definition, tablespace string
+	var options pq.StringArray
+
+	query := `SELECT pg_catalog.pg_get_viewdef(c.oid), COALESCE(t.spcname, ''), c.reloptions ` +
+		`FROM pg_catalog.pg_class c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`LEFT JOIN pg_catalog.pg_tablespace t ON t.oid = c.reltablespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'm'`
+	err = txn.QueryRow(query, matviewSchema, matviewName).Scan(&definition, &tablespace, &options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL materialized view (%s.%s) not found for database %s", matviewSchema, matviewName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading materialized view: %w", err)
+	}
+
+	// As for views, the query is replaced by the definition of the server
+	// only if it has been changed outside of Terraform.
+	if definition != d.Get(matviewDefinitionAttr).(string) {
+		d.Set(matviewQueryAttr, definition)
+	}
+
+	storageParameters := map[string]string{}
+	for _, option := range options {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) == 2 {
+			storageParameters[parts[0]] = parts[1]
+		}
+	}
+
+	d.Set(matviewNameAttr, matviewName)
+	d.Set(matviewSchemaAttr, matviewSchema)
+	d.Set(matviewDatabaseAttr, database)
+	d.Set(matviewDefinitionAttr, definition)
+	d.Set(matviewTablespaceAttr, tablespace)
+	d.Set(matviewStorageParametersAttr, storageParameters)
+
+	return nil
+}
+
+func resourcePostgreSQLMaterializedViewUpdate(ctx context.Context, db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// A materialized view cannot be replaced, so we have to drop it and create it again.
+	// The tablespace and the storage parameters are set by the CREATE statement.
+	if d.HasChange(matviewQueryAttr) {
+		if err := recreateMaterializedView(ctx, txn, d); err != nil {
+			return err
+		}
+	} else {
+		if err := setMaterializedViewTablespace(txn, d); err != nil {
+			return err
+		}
+
+		if err := setMaterializedViewStorageParameters(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating materialized view: %w", err)
+	}
+
+	return resourcePostgreSQLMaterializedViewReadImpl(db, d)
+}
+
+func resourcePostgreSQLMaterializedViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := dropMaterializedView(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting materialized view: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getMaterializedViewCreateQuery(d *schema.ResourceData, withData bool) string {
+	b := bytes.NewBufferString("CREATE MATERIALIZED VIEW ")
+	fmt.Fprint(b, getMaterializedViewQualifiedName(d))
+
+	if params := getStorageParameters(d.Get(matviewStorageParametersAttr).(map[string]interface{})); len(params) > 0 {
+		fmt.Fprintf(b, " WITH (%s)", strings.Join(params, ", "))
+	}
+
+	if tablespace := d.Get(matviewTablespaceAttr).(string); tablespace != "" {
+		fmt.Fprint(b, " TABLESPACE ", pq.QuoteIdentifier(tablespace))
+	}
+
+	fmt.Fprint(b, " AS ", d.Get(matviewQueryAttr).(string))
+
+	if withData {
+		fmt.Fprint(b, " WITH DATA")
+	} else {
+		fmt.Fprint(b, " WITH NO DATA")
+	}
+
+	return b.String()
+}
+
+func dropMaterializedView(txn *sql.Tx, d *schema.ResourceData) error {
+	dropMode := "RESTRICT"
+	if d.Get(matviewDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP MATERIALIZED VIEW %s %s", getMaterializedViewQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop materialized view %s: %w", d.Get(matviewNameAttr).(string), err)
+	}
+
+	return nil
+}
+
+func recreateMaterializedView(ctx context.Context, txn *sql.Tx, d *schema.ResourceData) error {
+	if err := dropMaterializedView(txn, d); err != nil {
+		return err
+	}
+
+	if _, err := txn.ExecContext(ctx, getMaterializedViewCreateQuery(d, d.Get(matviewRefreshOnChangeAttr).(bool))); err != nil {
+		return fmt.Errorf("could not create materialized view %s: %w", d.Get(matviewNameAttr).(string), err)
+	}
+
+	return setMaterializedViewDefinition(txn, d)
+}
+
+func setMaterializedViewTablespace(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(matviewTablespaceAttr) {
+		return nil
+	}
+
+	tablespace := d.Get(matviewTablespaceAttr).(string)
+	if tablespace == "" {
+		tablespace = "pg_default"
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER MATERIALIZED VIEW %s SET TABLESPACE %s",
+		getMaterializedViewQualifiedName(d), pq.QuoteIdentifier(tablespace),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating materialized view tablespace: %w", err)
+	}
+
+	return nil
+}
+
+func setMaterializedViewStorageParameters(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(matviewStorageParametersAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(matviewStorageParametersAttr)
+	oldParams := oraw.(map[string]interface{})
+	newParams := nraw.(map[string]interface{})
+
+	toReset := []string{}
+	for name := range oldParams {
+		if _, ok := newParams[name]; !ok {
+			toReset = append(toReset, name)
+		}
+	}
+	sort.Strings(toReset)
+
+	if len(toReset) > 0 {
+		sql := fmt.Sprintf(
+			"ALTER MATERIALIZED VIEW %s RESET (%s)",
+			getMaterializedViewQualifiedName(d), strings.Join(toReset, ", "),
+		)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error resetting materialized view storage parameters: %w", err)
+		}
+	}
+
+	if params := getStorageParameters(newParams); len(params) > 0 {
+		sql := fmt.Sprintf(
+			"ALTER MATERIALIZED VIEW %s SET (%s)",
+			getMaterializedViewQualifiedName(d), strings.Join(params, ", "),
+		)
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error setting materialized view storage parameters: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setMaterializedViewDefinition stores the definition of the materialized view as
+// reconstructed by PostgreSQL so it can be compared with the actual one when reading it.
+func setMaterializedViewDefinition(txn *sql.Tx, d *schema.ResourceData) error {
+	var definition string
+
+	query := "SELECT pg_catalog.pg_get_viewdef(to_regclass($1))"
+	if err := txn.QueryRow(query, getMaterializedViewQualifiedName(d)).Scan(&definition); err != nil {
+		return fmt.Errorf("could not read definition of materialized view %s: %w", d.Get(matviewNameAttr).(string), err)
+	}
+	d.Set(matviewDefinitionAttr, definition)
+
+	return nil
+}
+
+// getStorageParameters returns the storage parameters in the form
+// expected by the WITH and SET clauses (e.g.: fillfactor = '70').
+func getStorageParameters(params map[string]interface{}) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]string, 0, len(params))
+	for _, name := range names {
+		result = append(result, fmt.Sprintf("%s = '%s'", name, pqQuoteLiteral(params[name].(string))))
+	}
+
+	return result
+}
+
+func validateStorageParameters(v interface{}, key string) (warnings []string, errors []error) {
+	for name := range v.(map[string]interface{}) {
+		if !storageParameterNameRegexp.MatchString(name) {
+			errors = append(errors, fmt.Errorf("%s: invalid storage parameter name %q", key, name))
+		}
+	}
+
+	return
+}
+
+func getMaterializedViewQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(matviewSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(matviewNameAttr).(string)),
+	)
+}
+
+func generateMaterializedViewID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(matviewSchemaAttr).(string),
+		d.Get(matviewNameAttr).(string),
+	}, ".")
+}
+
+// getDBMaterializedViewName returns the database, schema and name of the materialized view.
+// If we are importing this resource, they will be parsed from the resource ID
+// (it will return an error if parsing failed) otherwise they will be simply get from the state.
+func getDBMaterializedViewName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	matviewSchema := d.Get(matviewSchemaAttr).(string)
+	matviewName := d.Get(matviewNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the materialized view, schema and database names.
+	if matviewName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf(
+				"materialized view ID %s has not the expected format 'database.schema.materialized_view': %v",
+				d.Id(), parsed,
+			)
+		}
+		database = parsed[0]
+		matviewSchema = parsed[1]
+		matviewName = parsed[2]
+	}
+
+	return database, matviewSchema, matviewName, nil
+}
@@ -0,0 +1,166 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlMaterializedView_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_table (id integer, val text)")
+
+	testAccPostgresqlMaterializedViewConfig := func(query, fillfactor string) string {
+		return fmt.Sprintf(`
+		resource "postgresql_materialized_view" "test" {
+			name      = "test_matview"
+			database  = "%s"
+			query     = "%s"
+			with_data = false
+			storage_parameters = {
+				fillfactor = "%s"
+			}
+		}`, dbName, query, fillfactor)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlMaterializedViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlMaterializedViewConfig("SELECT id, val FROM test_table", "70"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlMaterializedViewExists("postgresql_materialized_view.test"),
+					testAccCheckPostgresqlMaterializedViewPopulated("postgresql_materialized_view.test", false),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "id", fmt.Sprintf("%s.public.test_matview", dbName)),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "storage_parameters.fillfactor", "70"),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "tablespace", ""),
+				),
+			},
+			{
+				Config: testAccPostgresqlMaterializedViewConfig("SELECT id, val FROM test_table", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlMaterializedViewExists("postgresql_materialized_view.test"),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "storage_parameters.fillfactor", "80"),
+				),
+			},
+			{
+				// The materialized view is recreated and, as refresh_on_change is true, populated.
+				Config: testAccPostgresqlMaterializedViewConfig("SELECT id FROM test_table", "80"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlMaterializedViewExists("postgresql_materialized_view.test"),
+					testAccCheckPostgresqlMaterializedViewPopulated("postgresql_materialized_view.test", true),
+					resource.TestCheckResourceAttr("postgresql_materialized_view.test", "query", "SELECT id FROM test_table"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_materialized_view.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"query",
+					"with_data",
+					"refresh_on_change",
+					"drop_cascade",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlMaterializedViewDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_materialized_view" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[matviewDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[matviewSchemaAttr], rs.Primary.Attributes[matviewNameAttr], "m")
+		if err != nil {
+			return fmt.Errorf("Error checking materialized view %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Materialized view still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlMaterializedViewExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[matviewDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[matviewSchemaAttr], rs.Primary.Attributes[matviewNameAttr], "m")
+		if err != nil {
+			return fmt.Errorf("Error checking materialized view %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Materialized view not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPostgresqlMaterializedViewPopulated(n string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[matviewDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		var populated bool
+		query := "SELECT ispopulated FROM pg_catalog.pg_matviews WHERE schemaname = $1 AND matviewname = $2"
+		if err := txn.QueryRow(query, rs.Primary.Attributes[matviewSchemaAttr], rs.Primary.Attributes[matviewNameAttr]).Scan(&populated); err != nil {
+			return fmt.Errorf("Error checking materialized view %s", err)
+		}
+
+		if populated != expected {
+			return fmt.Errorf("Materialized view populated is %t, expected %t", populated, expected)
+		}
+
+		return nil
+	}
+}
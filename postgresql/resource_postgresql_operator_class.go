@@ -0,0 +1,476 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	opClassNameAttr           = "name"
+	opClassSchemaAttr         = "schema"
+	opClassDatabaseAttr       = "database"
+	opClassIndexMethodAttr    = "index_method"
+	opClassDataTypeAttr       = "data_type"
+	opClassDefaultAttr        = "default"
+	opClassFamilyAttr         = "family"
+	opClassOperatorAttr       = "operator"
+	opClassStrategyNumberAttr = "strategy_number"
+	opClassOperatorNameAttr   = "name"
+	opClassFunctionAttr       = "function"
+	opClassSupportNumberAttr  = "support_number"
+	opClassFunctionNameAttr   = "name"
+	opClassStorageAttr        = "storage"
+	opClassDropCascadeAttr    = "drop_cascade"
+)
+
+func resourcePostgreSQLOperatorClass() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLOperatorClassCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLOperatorClassExists, resourcePostgreSQLOperatorClassRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLOperatorClassUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLOperatorClassDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			opClassNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the operator class",
+			},
+			opClassSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the operator class is located",
+			},
+			opClassDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the operator class is located",
+			},
+			opClassIndexMethodAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the index method this operator class is for (e.g.: btree, gist)",
+			},
+			opClassDataTypeAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The column data type that this operator class is for",
+			},
+			opClassDefaultAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "If the operator class will become the default operator class for its data type",
+			},
+			opClassFamilyAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the existing operator family to add this operator class to. Defaults to a family with the same name as the operator class",
+			},
+			opClassOperatorAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The operators associated with the operator class",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						opClassStrategyNumberAttr: {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The index method's strategy number for the operator",
+						},
+						opClassOperatorNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name (optionally schema-qualified) of the operator, optionally followed by its operand types",
+						},
+					},
+				},
+			},
+			opClassFunctionAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The support functions associated with the operator class",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						opClassSupportNumberAttr: {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The index method's support function number for the function",
+						},
+						opClassFunctionNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name (optionally schema-qualified) of the function followed by its argument types",
+						},
+					},
+				},
+			},
+			opClassStorageAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The data type actually stored in the index, if different from the column data type",
+			},
+			opClassDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the operator class (e.g.: indexes)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLOperatorClassCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(opClassNameAttr).(string)
+
+	b := bytes.NewBufferString("CREATE OPERATOR CLASS ")
+	fmt.Fprint(b, getOperatorClassQualifiedName(d))
+
+	if d.Get(opClassDefaultAttr).(bool) {
+		fmt.Fprint(b, " DEFAULT")
+	}
+
+	fmt.Fprint(b, " FOR TYPE ", d.Get(opClassDataTypeAttr).(string))
+	fmt.Fprint(b, " USING ", pq.QuoteIdentifier(d.Get(opClassIndexMethodAttr).(string)))
+
+	if v, ok := d.GetOk(opClassFamilyAttr); ok {
+		fmt.Fprint(b, " FAMILY ", pq.QuoteIdentifier(d.Get(opClassSchemaAttr).(string)), ".", pq.QuoteIdentifier(v.(string)))
+	}
+
+	// Operators and functions are not quoted so they can be schema qualified and include their argument types.
+	members := []string{}
+	for _, o := range d.Get(opClassOperatorAttr).([]interface{}) {
+		operator := o.(map[string]interface{})
+		members = append(members, fmt.Sprintf("OPERATOR %d %s", operator[opClassStrategyNumberAttr].(int), operator[opClassOperatorNameAttr].(string)))
+	}
+	for _, f := range d.Get(opClassFunctionAttr).([]interface{}) {
+		function := f.(map[string]interface{})
+		members = append(members, fmt.Sprintf("FUNCTION %d %s", function[opClassSupportNumberAttr].(int), function[opClassFunctionNameAttr].(string)))
+	}
+	if v, ok := d.GetOk(opClassStorageAttr); ok {
+		members = append(members, fmt.Sprintf("STORAGE %s", v.(string)))
+	}
+
+	if len(members) == 0 {
+		return fmt.Errorf("operator class %s must have at least one operator, function or storage type", name)
+	}
+	fmt.Fprint(b, " AS ", strings.Join(members, ", "))
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create operator class %s: %w", name, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating operator class: %w", err)
+	}
+
+	d.SetId(generateOperatorClassID(d, database))
+
+	return resourcePostgreSQLOperatorClassReadImpl(db, d)
+}
+
+func resourcePostgreSQLOperatorClassExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, opClassSchema, indexMethod, opClassName, err := getDBOperatorClassName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	query := `SELECT TRUE FROM pg_catalog.pg_opclass c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.opcnamespace ` +
+		`JOIN pg_catalog.pg_am a ON a.oid = c.opcmethod ` +
+		`WHERE n.nspname = $1 AND a.amname = $2 AND c.opcname = $3`
+	err = txn.QueryRow(query, opClassSchema, indexMethod, opClassName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLOperatorClassRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLOperatorClassReadImpl(db, d)
+}
+
+func resourcePostgreSQLOperatorClassReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, opClassSchema, indexMethod, opClassName, err := getDBOperatorClassName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var familyOID, dataTypeOID int
+	var isDefault bool
+	var dataType, family, storage string
+
+	query := `SELECT c.opcdefault, c.opcintype, pg_catalog.format_type(c.opcintype, NULL), f.oid, f.opfname, ` +
+		`CASE WHEN c.opckeytype = 0 THEN '' ELSE pg_catalog.format_type(c.opckeytype, NULL) END ` +
+		`FROM pg_catalog.pg_opclass c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.opcnamespace ` +
+		`JOIN pg_catalog.pg_am a ON a.oid = c.opcmethod ` +
+		`JOIN pg_catalog.pg_opfamily f ON f.oid = c.opcfamily ` +
+		`WHERE n.nspname = $1 AND a.amname = $2 AND c.opcname = $3`
+	err = txn.QueryRow(query, opClassSchema, indexMethod, opClassName).Scan(
+		&isDefault, &dataTypeOID, &dataType, &familyOID, &family, &storage,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL operator class (%s.%s using %s) not found for database %s", opClassSchema, opClassName, indexMethod, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading operator class: %w", err)
+	}
+
+	// PostgreSQL normalizes the type, operator and function names,
+	// so we only set them when importing.
+	if d.Get(opClassDataTypeAttr).(string) == "" {
+		operators, functions, err := getOperatorClassMembers(txn, familyOID, dataTypeOID)
+		if err != nil {
+			return err
+		}
+
+		d.Set(opClassDataTypeAttr, dataType)
+		d.Set(opClassStorageAttr, storage)
+		d.Set(opClassOperatorAttr, operators)
+		d.Set(opClassFunctionAttr, functions)
+	}
+
+	d.Set(opClassNameAttr, opClassName)
+	d.Set(opClassSchemaAttr, opClassSchema)
+	d.Set(opClassDatabaseAttr, database)
+	d.Set(opClassIndexMethodAttr, indexMethod)
+	d.Set(opClassDefaultAttr, isDefault)
+	d.Set(opClassFamilyAttr, family)
+
+	return nil
+}
+
+func resourcePostgreSQLOperatorClassUpdate(db *DBConnection, d *schema.ResourceData) error {
+	// All the attributes force a new resource, except drop_cascade which is only used on destroy.
+	return resourcePostgreSQLOperatorClassReadImpl(db, d)
+}
+
+func resourcePostgreSQLOperatorClassDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(opClassDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf(
+		"DROP OPERATOR CLASS %s USING %s %s",
+		getOperatorClassQualifiedName(d), pq.QuoteIdentifier(d.Get(opClassIndexMethodAttr).(string)), dropMode,
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop operator class %s: %w", d.Get(opClassNameAttr).(string), err)
+	}
+
+	// When created without family, PostgreSQL creates implicitly a family with the same name as the class
+	// which is not dropped with it.
+	if d.Get(opClassFamilyAttr).(string) == d.Get(opClassNameAttr).(string) {
+		if err := dropEmptyOperatorFamily(txn, d, dropMode); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting operator class: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// dropEmptyOperatorFamily drops the operator family of the operator class if it does not contain any other class.
+func dropEmptyOperatorFamily(txn *sql.Tx, d *schema.ResourceData, dropMode string) error {
+	opClassSchema := d.Get(opClassSchemaAttr).(string)
+	indexMethod := d.Get(opClassIndexMethodAttr).(string)
+	family := d.Get(opClassFamilyAttr).(string)
+
+	var empty bool
+	query := `SELECT NOT EXISTS (SELECT 1 FROM pg_catalog.pg_opclass c WHERE c.opcfamily = f.oid) ` +
+		`FROM pg_catalog.pg_opfamily f ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = f.opfnamespace ` +
+		`JOIN pg_catalog.pg_am a ON a.oid = f.opfmethod ` +
+		`WHERE n.nspname = $1 AND a.amname = $2 AND f.opfname = $3`
+	err := txn.QueryRow(query, opClassSchema, indexMethod, family).Scan(&empty)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading operator family: %w", err)
+	}
+
+	if !empty {
+		return nil
+	}
+
+	sql := fmt.Sprintf(
+		"DROP OPERATOR FAMILY %s.%s USING %s %s",
+		pq.QuoteIdentifier(opClassSchema), pq.QuoteIdentifier(family), pq.QuoteIdentifier(indexMethod), dropMode,
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop operator family %s: %w", family, err)
+	}
+
+	return nil
+}
+
+// getOperatorClassMembers returns the operators and functions of the operator family
+// which are registered for the data type of the operator class.
+func getOperatorClassMembers(txn *sql.Tx, familyOID, dataTypeOID int) ([]interface{}, []interface{}, error) {
+	operators := []interface{}{}
+	functions := []interface{}{}
+
+	query := `SELECT amopstrategy, amopopr::regoperator::text FROM pg_catalog.pg_amop ` +
+		`WHERE amopfamily = $1 AND amoplefttype = $2 AND amoprighttype = $2 AND amoppurpose = 's' ` +
+		`ORDER BY amopstrategy`
+	rows, err := txn.Query(query, familyOID, dataTypeOID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error reading operator class operators: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var number int
+		var name string
+		if err := rows.Scan(&number, &name); err != nil {
+			return nil, nil, fmt.Errorf("could not scan operator class operator: %w", err)
+		}
+		operators = append(operators, map[string]interface{}{
+			opClassStrategyNumberAttr: number,
+			opClassOperatorNameAttr:   name,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	query = `SELECT amprocnum, amproc::regprocedure::text FROM pg_catalog.pg_amproc ` +
+		`WHERE amprocfamily = $1 AND amproclefttype = $2 AND amprocrighttype = $2 ` +
+		`ORDER BY amprocnum`
+	rows, err = txn.Query(query, familyOID, dataTypeOID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error reading operator class functions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var number int
+		var name string
+		if err := rows.Scan(&number, &name); err != nil {
+			return nil, nil, fmt.Errorf("could not scan operator class function: %w", err)
+		}
+		functions = append(functions, map[string]interface{}{
+			opClassSupportNumberAttr: number,
+			opClassFunctionNameAttr:  name,
+		})
+	}
+
+	return operators, functions, rows.Err()
+}
+
+func getOperatorClassQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(opClassSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(opClassNameAttr).(string)),
+	)
+}
+
+func generateOperatorClassID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(opClassSchemaAttr).(string),
+		d.Get(opClassIndexMethodAttr).(string),
+		d.Get(opClassNameAttr).(string),
+	}, ".")
+}
+
+// getDBOperatorClassName returns the database, schema, index method and name of the operator class.
+// If we are importing this resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBOperatorClassName(d *schema.ResourceData, client *Client) (string, string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	opClassSchema := d.Get(opClassSchemaAttr).(string)
+	indexMethod := d.Get(opClassIndexMethodAttr).(string)
+	opClassName := d.Get(opClassNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the operator class, index method, schema and database names.
+	if opClassName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 4 {
+			return "", "", "", "", fmt.Errorf(
+				"operator class ID %s has not the expected format 'database.schema.index_method.operator_class': %v",
+				d.Id(), parsed,
+			)
+		}
+		database = parsed[0]
+		opClassSchema = parsed[1]
+		indexMethod = parsed[2]
+		opClassName = parsed[3]
+	}
+
+	return database, opClassSchema, indexMethod, opClassName, nil
+}
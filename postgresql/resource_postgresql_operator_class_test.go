@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlOperatorClass_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlOperatorClassDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_operator_class" "test" {
+					name         = "int4_test_ops"
+					database     = "%s"
+					index_method = "btree"
+					data_type    = "int4"
+
+					operator {
+						strategy_number = 1
+						name            = "<"
+					}
+					operator {
+						strategy_number = 2
+						name            = "<="
+					}
+					operator {
+						strategy_number = 3
+						name            = "="
+					}
+					operator {
+						strategy_number = 4
+						name            = ">="
+					}
+					operator {
+						strategy_number = 5
+						name            = ">"
+					}
+
+					function {
+						support_number = 1
+						name           = "btint4cmp(int4, int4)"
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlOperatorClassExists("postgresql_operator_class.test"),
+					resource.TestCheckResourceAttr("postgresql_operator_class.test", "id", fmt.Sprintf("%s.public.btree.int4_test_ops", dbName)),
+					resource.TestCheckResourceAttr("postgresql_operator_class.test", "default", "false"),
+					resource.TestCheckResourceAttr("postgresql_operator_class.test", "family", "int4_test_ops"),
+					resource.TestCheckResourceAttr("postgresql_operator_class.test", "operator.#", "5"),
+					resource.TestCheckResourceAttr("postgresql_operator_class.test", "function.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_operator_class.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					opClassDataTypeAttr, opClassOperatorAttr, opClassFunctionAttr, opClassDropCascadeAttr,
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlOperatorClassDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_operator_class" {
+			continue
+		}
+
+		exists, err := checkOperatorClassExists(client, rs.Primary.Attributes)
+		if err != nil {
+			return fmt.Errorf("Error checking operator class %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Operator class still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlOperatorClassExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkOperatorClassExists(client, rs.Primary.Attributes)
+		if err != nil {
+			return fmt.Errorf("Error checking operator class %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Operator class not found")
+		}
+
+		return nil
+	}
+}
+
+func checkOperatorClassExists(client *Client, attributes map[string]string) (bool, error) {
+	txn, err := startTransaction(client, attributes[opClassDatabaseAttr])
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	err = txn.QueryRow(
+		`SELECT 1 FROM pg_catalog.pg_opclass c `+
+			`JOIN pg_catalog.pg_namespace n ON n.oid = c.opcnamespace `+
+			`JOIN pg_catalog.pg_am a ON a.oid = c.opcmethod `+
+			`WHERE n.nspname = $1 AND a.amname = $2 AND c.opcname = $3`,
+		attributes[opClassSchemaAttr], attributes[opClassIndexMethodAttr], attributes[opClassNameAttr],
+	).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about operator class: %s", err)
+	}
+
+	return true, nil
+}
@@ -1,69 +1,137 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func resourcePostgreSQLPhysicalReplicationSlot() *schema.Resource {
-	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLPhysicalReplicationSlotCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLPhysicalReplicationSlotRead),
-		Delete: PGResourceFunc(resourcePostgreSQLPhysicalReplicationSlotDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLPhysicalReplicationSlotExists),
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+// physicalReplicationSlotResource is served by the plugin framework (see frameworkProvider).
+type physicalReplicationSlotResource struct {
+	client *Client
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+type physicalReplicationSlotModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func newPhysicalReplicationSlotResource() resource.Resource {
+	return &physicalReplicationSlotResource{}
+}
+
+func (r *physicalReplicationSlotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_physical_replication_slot"
+}
+
+func (r *physicalReplicationSlotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 		},
 	}
 }
 
-func resourcePostgreSQLPhysicalReplicationSlotCreate(db *DBConnection, d *schema.ResourceData) error {
-	name := d.Get("name").(string)
-	sql := "SELECT FROM pg_create_physical_replication_slot($1)"
-	if _, err := db.Exec(sql, name); err != nil {
-		return fmt.Errorf("could not create physical ReplicationSlot %s: %w", name, err)
+func (r *physicalReplicationSlotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = frameworkClient(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *physicalReplicationSlotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan physicalReplicationSlotModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	err := r.client.withConnection(ctx, false, func(db *DBConnection) error {
+		sql := "SELECT FROM pg_create_physical_replication_slot($1)"
+		if _, err := db.Exec(sql, name); err != nil {
+			return fmt.Errorf("could not create physical ReplicationSlot %s: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		addErrorDiagnostics(&resp.Diagnostics, err)
+		return
 	}
-	d.SetId(name)
 
-	return nil
+	plan.ID = types.StringValue(name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourcePostgreSQLPhysicalReplicationSlotExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
-	query := "SELECT 1 FROM pg_catalog.pg_replication_slots WHERE slot_name = $1 and slot_type = 'physical'"
-	var unused int
-	err := db.QueryRow(query, d.Id()).Scan(&unused)
-	switch {
-	case err == sql.ErrNoRows:
-		return false, nil
-	case err != nil:
-		return false, err
+func (r *physicalReplicationSlotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state physicalReplicationSlotModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
 	}
 
-	return true, nil
-}
+	var replicationSlotName string
+	found := true
+	err := r.client.withConnection(ctx, true, func(db *DBConnection) error {
+		// Logical slots share the same namespace, so we explicitly only look for a physical one.
+		query := "SELECT slot_name FROM pg_catalog.pg_replication_slots WHERE slot_name = $1 and slot_type = 'physical'"
+		err := db.QueryRow(query, state.ID.ValueString()).Scan(&replicationSlotName)
+		switch {
+		case err == sql.ErrNoRows:
+			found = false
+		case err != nil:
+			return fmt.Errorf("Error reading physical ReplicationSlot: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		addErrorDiagnostics(&resp.Diagnostics, err)
+		return
+	}
+	if !found {
+		log.Printf("[WARN] PostgreSQL physical ReplicationSlot (%s) not found", state.ID.ValueString())
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-func resourcePostgreSQLPhysicalReplicationSlotRead(db *DBConnection, d *schema.ResourceData) error {
-	d.Set("name", d.Id())
-	return nil
+	state.Name = types.StringValue(replicationSlotName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func resourcePostgreSQLPhysicalReplicationSlotDelete(db *DBConnection, d *schema.ResourceData) error {
+// Update is never called, as all the arguments force a new resource.
+func (r *physicalReplicationSlotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan physicalReplicationSlotModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
 
-	replicationSlotName := d.Get("name").(string)
+func (r *physicalReplicationSlotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state physicalReplicationSlotModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
 
-	if _, err := db.Exec("SELECT pg_drop_replication_slot($1)", replicationSlotName); err != nil {
+	err := r.client.withConnection(ctx, false, func(db *DBConnection) error {
+		_, err := db.Exec("SELECT pg_drop_replication_slot($1)", state.Name.ValueString())
 		return err
+	})
+	if err != nil {
+		addErrorDiagnostics(&resp.Diagnostics, err)
 	}
+}
 
-	d.SetId("")
-	return nil
+func (r *physicalReplicationSlotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
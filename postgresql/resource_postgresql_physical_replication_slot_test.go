@@ -0,0 +1,107 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlPhysicalReplicationSlot_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckPostgresqlPhysicalReplicationSlotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "postgresql_physical_replication_slot" "myslot" {
+					name = "physical_slot"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlPhysicalReplicationSlotExists("postgresql_physical_replication_slot.myslot"),
+					resource.TestCheckResourceAttr(
+						"postgresql_physical_replication_slot.myslot", "name", "physical_slot"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_physical_replication_slot.myslot",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlPhysicalReplicationSlotDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+	db, err := client.Connect()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_physical_replication_slot" {
+			continue
+		}
+
+		exists, err := checkPhysicalReplicationSlotExists(db, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking physical replication slot %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Physical ReplicationSlot still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlPhysicalReplicationSlotExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		exists, err := checkPhysicalReplicationSlotExists(db, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking physical replication slot %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Physical ReplicationSlot not found")
+		}
+
+		return nil
+	}
+}
+
+func checkPhysicalReplicationSlotExists(db *DBConnection, slotName string) (bool, error) {
+	var _rez bool
+	err := db.QueryRow("SELECT TRUE from pg_catalog.pg_replication_slots WHERE slot_name=$1 AND slot_type='physical'", slotName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about physical replication slot: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,286 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+// OUT arguments are only supported for procedures since PostgreSQL 14
+// and are part of their signature, unlike functions.
+var allowedProcedureArgModes = []string{"IN", "INOUT", "VARIADIC"}
+
+// The postgresql_procedure resource shares its attributes and the handling
+// of the arguments and of the signature with the postgresql_function resource.
+func resourcePostgreSQLProcedure() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLProcedureCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLProcedureExists, resourcePostgreSQLProcedureRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLProcedureUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLProcedureDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: requireFeature(featureProcedure, "postgresql_procedure resource", nil),
+
+		Schema: map[string]*schema.Schema{
+			funcNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the procedure",
+			},
+			funcSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the procedure is located",
+			},
+			funcDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the procedure is located",
+			},
+			funcArgAttr: functionArgSchema(allowedProcedureArgModes),
+			funcLanguageAttr: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "plpgsql",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+				Description: "The language of the procedure",
+			},
+			funcBodyAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The body of the procedure",
+			},
+			funcSecurityDefinerAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the procedure should be executed with the privileges of the user that owns it",
+			},
+			funcDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the procedure, and in turn all objects that depend on those objects",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLProcedureCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureProcedure) {
+		return fmt.Errorf(
+			"postgresql_procedure resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := createProcedure(db, d, false); err != nil {
+		return err
+	}
+
+	return resourcePostgreSQLProcedureReadImpl(db, d)
+}
+
+func resourcePostgreSQLProcedureExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureProcedure) {
+		return false, fmt.Errorf(
+			"postgresql_procedure resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, signature, err := getDBFunctionSignature(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	query := "SELECT TRUE FROM pg_catalog.pg_proc WHERE oid = to_regprocedure($1) AND prokind = 'p'"
+	err = txn.QueryRow(query, signature).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLProcedureRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureProcedure) {
+		return fmt.Errorf(
+			"postgresql_procedure resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLProcedureReadImpl(db, d)
+}
+
+func resourcePostgreSQLProcedureReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, signature, err := getDBFunctionSignature(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var procName, procSchema, language, body string
+	var securityDefiner bool
+
+	query := `SELECT p.proname, n.nspname, l.lanname, p.prosrc, p.prosecdef ` +
+		`FROM pg_catalog.pg_proc p ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace ` +
+		`JOIN pg_catalog.pg_language l ON l.oid = p.prolang ` +
+		`WHERE p.oid = to_regprocedure($1) AND p.prokind = 'p'`
+	err = txn.QueryRow(query, signature).Scan(&procName, &procSchema, &language, &body, &securityDefiner)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL procedure (%s) not found for database %s", signature, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading procedure: %w", err)
+	}
+
+	// As for functions, the arguments are only read when importing.
+	if d.Get(funcNameAttr).(string) == "" {
+		args, err := getFunctionArgs(txn, signature)
+		if err != nil {
+			return err
+		}
+		d.Set(funcArgAttr, args)
+	}
+
+	d.Set(funcNameAttr, procName)
+	d.Set(funcSchemaAttr, procSchema)
+	d.Set(funcDatabaseAttr, database)
+	d.Set(funcLanguageAttr, language)
+	d.Set(funcBodyAttr, body)
+	d.Set(funcSecurityDefinerAttr, securityDefiner)
+
+	return nil
+}
+
+func resourcePostgreSQLProcedureUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureProcedure) {
+		return fmt.Errorf(
+			"postgresql_procedure resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := createProcedure(db, d, true); err != nil {
+		return err
+	}
+
+	return resourcePostgreSQLProcedureReadImpl(db, d)
+}
+
+func resourcePostgreSQLProcedureDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureProcedure) {
+		return fmt.Errorf(
+			"postgresql_procedure resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, signature, err := getDBFunctionSignature(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(funcDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP PROCEDURE %s %s", signature, dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop procedure %s: %w", signature, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting procedure: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// createProcedure creates the procedure or, if replace is true, replaces its definition.
+func createProcedure(db *DBConnection, d *schema.ResourceData, replace bool) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE ")
+	if replace {
+		fmt.Fprint(b, "OR REPLACE ")
+	}
+	fmt.Fprint(b, "PROCEDURE ", getFunctionQualifiedName(d), "(", strings.Join(getFunctionArgsDefinition(d), ", "), ")")
+	fmt.Fprint(b, " LANGUAGE ", pq.QuoteIdentifier(strings.ToLower(d.Get(funcLanguageAttr).(string))))
+
+	if d.Get(funcSecurityDefinerAttr).(bool) {
+		fmt.Fprint(b, " SECURITY DEFINER")
+	} else {
+		fmt.Fprint(b, " SECURITY INVOKER")
+	}
+
+	fmt.Fprintf(b, " AS '%s'", pqQuoteLiteral(d.Get(funcBodyAttr).(string)))
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create procedure %s: %w", d.Get(funcNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating procedure: %w", err)
+	}
+
+	d.SetId(generateFunctionID(d, database))
+
+	return nil
+}
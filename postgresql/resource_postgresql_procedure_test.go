@@ -0,0 +1,142 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlProcedure_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_log (msg text)")
+
+	testAccPostgresqlProcedureConfig := func(body string) string {
+		return fmt.Sprintf(`
+		resource "postgresql_procedure" "log" {
+			name     = "write_log"
+			database = "%s"
+			arg {
+				name = "msg"
+				type = "text"
+			}
+			language = "sql"
+			body     = "%s"
+		}`, dbName, body)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureProcedure)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlProcedureDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlProcedureConfig("INSERT INTO test_log VALUES (msg)"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlProcedureExists("postgresql_procedure.log"),
+					resource.TestCheckResourceAttr("postgresql_procedure.log", "id", fmt.Sprintf("%s.public.write_log(text)", dbName)),
+					resource.TestCheckResourceAttr("postgresql_procedure.log", "name", "write_log"),
+					resource.TestCheckResourceAttr("postgresql_procedure.log", "schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_procedure.log", "language", "sql"),
+					resource.TestCheckResourceAttr("postgresql_procedure.log", "security_definer", "false"),
+				),
+			},
+			{
+				Config: testAccPostgresqlProcedureConfig("INSERT INTO test_log VALUES (upper(msg))"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlProcedureExists("postgresql_procedure.log"),
+					resource.TestCheckResourceAttr("postgresql_procedure.log", "body", "INSERT INTO test_log VALUES (upper(msg))"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_procedure.log",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"drop_cascade",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlProcedureDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_procedure" {
+			continue
+		}
+
+		exists, err := checkProcedureExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking procedure %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Procedure still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlProcedureExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkProcedureExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking procedure %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Procedure not found")
+		}
+
+		return nil
+	}
+}
+
+func checkProcedureExists(client *Client, ID string) (bool, error) {
+	database, signature, err := getFunctionSignatureFromID(ID)
+	if err != nil {
+		return false, err
+	}
+
+	txn, err := startTransaction(client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	err = txn.QueryRow("SELECT TRUE FROM pg_catalog.pg_proc WHERE oid = to_regprocedure($1) AND prokind = 'p'", signature).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about procedure: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,584 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	pubNameAttr                    = "name"
+	pubDatabaseAttr                = "database"
+	pubOwnerAttr                   = "owner"
+	pubTablesAttr                  = "tables"
+	pubAllTablesAttr               = "all_tables"
+	pubPublishParamAttr            = "publish_param"
+	pubPublishViaPartitionRootAttr = "publish_via_partition_root"
+	pubDropCascadeAttr             = "drop_cascade"
+)
+
+var allowedPublishParams = []string{"insert", "update", "delete", "truncate"}
+
+func resourcePostgreSQLPublication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLPublicationCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLPublicationExists, resourcePostgreSQLPublicationRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLPublicationUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLPublicationDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(
+			requireFeature(featurePublication, "postgresql_publication resource", nil),
+			requireFeature(featurePublicationTruncate, "publishing truncate", func(d *schema.ResourceDiff) bool {
+				for _, param := range d.Get(pubPublishParamAttr).([]interface{}) {
+					if param.(string) == "truncate" {
+						return true
+					}
+				}
+				return false
+			}),
+			requireFeature(featurePublicationViaRoot, pubPublishViaPartitionRootAttr, attributeSet(pubPublishViaPartitionRootAttr)),
+		),
+
+		Schema: map[string]*schema.Schema{
+			pubNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the publication",
+			},
+			pubDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Sets the database to add the publication to",
+			},
+			pubOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE name who owns the publication",
+			},
+			pubTablesAttr: {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				ConflictsWith: []string{pubAllTablesAttr},
+				Description:   "Sets the tables list to publish (in the form schema.table)",
+			},
+			pubAllTablesAttr: {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{pubTablesAttr},
+				Description:   "Sets the tables list to publish to ALL tables",
+			},
+			pubPublishParamAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(allowedPublishParams, false)},
+				Description: "Sets which DML operations will be published (any of: " + strings.Join(allowedPublishParams, ", ") + ")",
+			},
+			pubPublishViaPartitionRootAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Sets whether changes in a partitioned table are published using the identity and schema of the partitioned table",
+			},
+			pubDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the publication, and in turn all objects that depend on those objects",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLPublicationCreate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validatePublicationFeatures(db, d); err != nil {
+		return err
+	}
+
+	name := d.Get(pubNameAttr).(string)
+	databaseName := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE PUBLICATION ")
+	fmt.Fprint(b, pq.QuoteIdentifier(name))
+
+	if d.Get(pubAllTablesAttr).(bool) {
+		fmt.Fprint(b, " FOR ALL TABLES")
+	} else if tables := d.Get(pubTablesAttr).(*schema.Set); tables.Len() > 0 {
+		tablesList, err := quotePublicationTables(tables.List())
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(b, " FOR TABLE ", strings.Join(tablesList, ", "))
+	}
+
+	if params := getPublicationParams(db, d); len(params) > 0 {
+		fmt.Fprintf(b, " WITH (%s)", strings.Join(params, ", "))
+	}
+
+	txn, err := startTransaction(db.client, databaseName)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create publication %s: %w", name, err)
+	}
+
+	if err := setPublicationOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating publication: %w", err)
+	}
+
+	d.SetId(generatePublicationID(d, databaseName))
+
+	return resourcePostgreSQLPublicationReadImpl(db, d)
+}
+
+func resourcePostgreSQLPublicationExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featurePublication) {
+		return false, fmt.Errorf(
+			"postgresql_publication resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, pubName, err := getDBPublicationName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	query := "SELECT pubname FROM pg_catalog.pg_publication WHERE pubname = $1"
+	err = txn.QueryRow(query, pubName).Scan(&pubName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLPublicationRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePublication) {
+		return fmt.Errorf(
+			"postgresql_publication resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLPublicationReadImpl(db, d)
+}
+
+func resourcePostgreSQLPublicationReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, pubName, err := getDBPublicationName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var owner string
+	var allTables, pubInsert, pubUpdate, pubDelete, pubTruncate, pubViaRoot bool
+
+	columns := []string{
+		"pg_catalog.pg_get_userbyid(pubowner)",
+		"puballtables",
+		"pubinsert",
+		"pubupdate",
+		"pubdelete",
+	}
+	values := []interface{}{
+		&owner,
+		&allTables,
+		&pubInsert,
+		&pubUpdate,
+		&pubDelete,
+	}
+
+	if db.featureSupported(featurePublicationTruncate) {
+		columns = append(columns, "pubtruncate")
+		values = append(values, &pubTruncate)
+	}
+	if db.featureSupported(featurePublicationViaRoot) {
+		columns = append(columns, "pubviaroot")
+		values = append(values, &pubViaRoot)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM pg_catalog.pg_publication WHERE pubname = $1",
+		strings.Join(columns, ", "),
+	)
+	err = txn.QueryRow(query, pubName).Scan(values...)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL publication (%s) not found for database %s", pubName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading publication: %w", err)
+	}
+
+	tables, err := getPublicationTables(txn, pubName)
+	if err != nil {
+		return err
+	}
+
+	publishParams := []string{}
+	for param, enabled := range map[string]bool{
+		"insert":   pubInsert,
+		"update":   pubUpdate,
+		"delete":   pubDelete,
+		"truncate": pubTruncate,
+	} {
+		if enabled {
+			publishParams = append(publishParams, param)
+		}
+	}
+
+	d.Set(pubNameAttr, pubName)
+	d.Set(pubDatabaseAttr, database)
+	d.Set(pubOwnerAttr, owner)
+	d.Set(pubAllTablesAttr, allTables)
+	d.Set(pubPublishParamAttr, sortPublishParams(d, publishParams))
+	d.Set(pubPublishViaPartitionRootAttr, pubViaRoot)
+	// Tables are implicit when publishing all tables
+	if !allTables {
+		d.Set(pubTablesAttr, stringSliceToSet(tables))
+	}
+	d.SetId(generatePublicationID(d, database))
+
+	return nil
+}
+
+func resourcePostgreSQLPublicationUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := validatePublicationFeatures(db, d); err != nil {
+		return err
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setPublicationName(txn, d, database); err != nil {
+		return err
+	}
+
+	if err := setPublicationOwner(txn, d); err != nil {
+		return err
+	}
+
+	if err := setPublicationTables(txn, d); err != nil {
+		return err
+	}
+
+	if err := setPublicationParams(db, txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating publication: %w", err)
+	}
+
+	return resourcePostgreSQLPublicationReadImpl(db, d)
+}
+
+func resourcePostgreSQLPublicationDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePublication) {
+		return fmt.Errorf(
+			"postgresql_publication resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	pubName := d.Get(pubNameAttr).(string)
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(pubDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP PUBLICATION %s %s", pq.QuoteIdentifier(pubName), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop publication %s: %w", pubName, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting publication: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func validatePublicationFeatures(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePublication) {
+		return fmt.Errorf(
+			"postgresql_publication resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	for _, param := range d.Get(pubPublishParamAttr).([]interface{}) {
+		if param.(string) == "truncate" && !db.featureSupported(featurePublicationTruncate) {
+			return fmt.Errorf(
+				"publishing truncate is not supported for this Postgres version (%s)",
+				db.version,
+			)
+		}
+	}
+
+	if d.Get(pubPublishViaPartitionRootAttr).(bool) && !db.featureSupported(featurePublicationViaRoot) {
+		return fmt.Errorf(
+			"publish_via_partition_root is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return nil
+}
+
+// getPublicationParams returns the list of the publication parameters
+// explicitly set by the user (e.g.: publish = 'insert, update')
+func getPublicationParams(db *DBConnection, d *schema.ResourceData) []string {
+	params := []string{}
+
+	if v, ok := d.GetOk(pubPublishParamAttr); ok {
+		publish := []string{}
+		for _, param := range v.([]interface{}) {
+			publish = append(publish, param.(string))
+		}
+		params = append(params, fmt.Sprintf("publish = '%s'", pqQuoteLiteral(strings.Join(publish, ", "))))
+	}
+
+	if db.featureSupported(featurePublicationViaRoot) {
+		params = append(params, fmt.Sprintf("publish_via_partition_root = %t", d.Get(pubPublishViaPartitionRootAttr).(bool)))
+	}
+
+	return params
+}
+
+func setPublicationName(txn *sql.Tx, d *schema.ResourceData, database string) error {
+	if !d.HasChange(pubNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(pubNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting publication name to an empty string")
+	}
+
+	sql := fmt.Sprintf("ALTER PUBLICATION %s RENAME TO %s", pq.QuoteIdentifier(o), pq.QuoteIdentifier(n))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating publication name: %w", err)
+	}
+	d.SetId(generatePublicationID(d, database))
+
+	return nil
+}
+
+func setPublicationOwner(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(pubOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(pubOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	pubName := d.Get(pubNameAttr).(string)
+	sql := fmt.Sprintf("ALTER PUBLICATION %s OWNER TO %s", pq.QuoteIdentifier(pubName), pq.QuoteIdentifier(owner))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating publication owner: %w", err)
+	}
+
+	return nil
+}
+
+func setPublicationTables(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(pubTablesAttr) {
+		return nil
+	}
+
+	pubName := d.Get(pubNameAttr).(string)
+	oraw, nraw := d.GetChange(pubTablesAttr)
+	oldTables := oraw.(*schema.Set)
+	newTables := nraw.(*schema.Set)
+
+	queries := []string{}
+	if dropped := oldTables.Difference(newTables); dropped.Len() > 0 {
+		tables, err := quotePublicationTables(dropped.List())
+		if err != nil {
+			return err
+		}
+		queries = append(queries, fmt.Sprintf(
+			"ALTER PUBLICATION %s DROP TABLE %s", pq.QuoteIdentifier(pubName), strings.Join(tables, ", "),
+		))
+	}
+	if added := newTables.Difference(oldTables); added.Len() > 0 {
+		tables, err := quotePublicationTables(added.List())
+		if err != nil {
+			return err
+		}
+		queries = append(queries, fmt.Sprintf(
+			"ALTER PUBLICATION %s ADD TABLE %s", pq.QuoteIdentifier(pubName), strings.Join(tables, ", "),
+		))
+	}
+
+	for _, query := range queries {
+		if _, err := txn.Exec(query); err != nil {
+			return fmt.Errorf("Error updating publication tables: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func setPublicationParams(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(pubPublishParamAttr) && !d.HasChange(pubPublishViaPartitionRootAttr) {
+		return nil
+	}
+
+	params := getPublicationParams(db, d)
+	if len(params) == 0 {
+		return nil
+	}
+
+	pubName := d.Get(pubNameAttr).(string)
+	sql := fmt.Sprintf("ALTER PUBLICATION %s SET (%s)", pq.QuoteIdentifier(pubName), strings.Join(params, ", "))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating publication parameters: %w", err)
+	}
+
+	return nil
+}
+
+func getPublicationTables(txn *sql.Tx, pubName string) ([]string, error) {
+	rows, err := txn.Query(
+		"SELECT schemaname, tablename FROM pg_catalog.pg_publication_tables WHERE pubname = $1",
+		pubName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not read tables of publication %s: %w", pubName, err)
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var schemaName, tableName string
+		if err := rows.Scan(&schemaName, &tableName); err != nil {
+			return nil, fmt.Errorf("could not scan publication table: %w", err)
+		}
+		tables = append(tables, fmt.Sprintf("%s.%s", schemaName, tableName))
+	}
+
+	return tables, rows.Err()
+}
+
+// quotePublicationTables quotes a list of schema.table table names
+func quotePublicationTables(tables []interface{}) ([]string, error) {
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		parts := strings.Split(table.(string), ".")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("table %s has not the expected format 'schema.table'", table)
+		}
+		quoted[i] = fmt.Sprintf("%s.%s", pq.QuoteIdentifier(parts[0]), pq.QuoteIdentifier(parts[1]))
+	}
+	return quoted, nil
+}
+
+// sortPublishParams keeps the order of the publish parameters defined in the configuration
+// so we don't show a diff if only the order has changed.
+func sortPublishParams(d *schema.ResourceData, params []string) []string {
+	sorted := []string{}
+	for _, param := range d.Get(pubPublishParamAttr).([]interface{}) {
+		if sliceContainsStr(params, param.(string)) {
+			sorted = append(sorted, param.(string))
+		}
+	}
+	for _, param := range allowedPublishParams {
+		if sliceContainsStr(params, param) && !sliceContainsStr(sorted, param) {
+			sorted = append(sorted, param)
+		}
+	}
+	return sorted
+}
+
+func generatePublicationID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(pubNameAttr).(string),
+	}, ".")
+}
+
+// getDBPublicationName returns database and publication name. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBPublicationName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	pubName := d.Get(pubNameAttr).(string)
+
+	// When importing, we have to parse the ID to find publication and database names.
+	if pubName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("publication ID %s has not the expected format 'database.publication': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		pubName = parsed[1]
+	}
+	return database, pubName, nil
+}
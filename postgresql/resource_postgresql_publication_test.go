@@ -0,0 +1,192 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlPublication_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	testTables := []string{"test_schema.test_table_1", "test_schema.test_table_2"}
+	createTestTables(t, dbSuffix, testTables, "")
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePublication)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlPublicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_publication" "test" {
+					name          = "test_publication"
+					database      = "%s"
+					tables        = ["test_schema.test_table_1"]
+					publish_param = ["insert", "update"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlPublicationExists("postgresql_publication.test"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "name", "test_publication"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "database", dbName),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "all_tables", "false"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "tables.#", "1"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "publish_param.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "publish_param.0", "insert"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "publish_param.1", "update"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_publication" "test" {
+					name          = "test_publication"
+					database      = "%s"
+					tables        = ["test_schema.test_table_2"]
+					publish_param = ["insert", "update", "delete"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlPublicationExists("postgresql_publication.test"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "tables.#", "1"),
+					resource.TestCheckTypeSetElemAttr("postgresql_publication.test", "tables.*", "test_schema.test_table_2"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "publish_param.#", "3"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_publication.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{pubDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlPublication_AllTables(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePublication)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlPublicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_publication" "test" {
+					name       = "test_publication"
+					database   = "%s"
+					all_tables = true
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlPublicationExists("postgresql_publication.test"),
+					resource.TestCheckResourceAttr("postgresql_publication.test", "all_tables", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlPublicationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_publication" {
+			continue
+		}
+
+		database, ok := rs.Primary.Attributes[pubDatabaseAttr]
+		if !ok {
+			return fmt.Errorf("No Attribute for database is set")
+		}
+
+		txn, err := startTransaction(client, database)
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkPublicationExists(txn, rs.Primary.Attributes[pubNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking publication %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Publication still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlPublicationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		database, ok := rs.Primary.Attributes[pubDatabaseAttr]
+		if !ok {
+			return fmt.Errorf("No Attribute for database is set")
+		}
+
+		pubName, ok := rs.Primary.Attributes[pubNameAttr]
+		if !ok {
+			return fmt.Errorf("No Attribute for publication name is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, database)
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkPublicationExists(txn, pubName)
+		if err != nil {
+			return fmt.Errorf("Error checking publication %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Publication not found")
+		}
+
+		return nil
+	}
+}
+
+func checkPublicationExists(txn *sql.Tx, pubName string) (bool, error) {
+	var _rez bool
+	err := txn.QueryRow("SELECT TRUE FROM pg_catalog.pg_publication WHERE pubname = $1", pubName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about publication: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,275 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	rangeNameAttr        = "name"
+	rangeSchemaAttr      = "schema"
+	rangeDatabaseAttr    = "database"
+	rangeSubtypeAttr     = "subtype"
+	rangeCollationAttr   = "collation"
+	rangeCanonicalAttr   = "canonical"
+	rangeSubtypeDiffAttr = "subtype_diff"
+	rangeDropCascadeAttr = "drop_cascade"
+)
+
+func resourcePostgreSQLRangeType() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLRangeTypeCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLRangeTypeExists, resourcePostgreSQLRangeTypeRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLRangeTypeUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLRangeTypeDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			rangeNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the range type",
+			},
+			rangeSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the range type is located",
+			},
+			rangeDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the range type is located",
+			},
+			rangeSubtypeAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The type of the elements of the range",
+			},
+			rangeCollationAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The collation to use for the range ordering, if the subtype is collatable",
+			},
+			rangeCanonicalAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the canonicalization function of the range type",
+			},
+			rangeSubtypeDiffAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the difference function of the subtype",
+			},
+			rangeDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the range type, and in turn all objects that depend on those objects",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLRangeTypeCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE TYPE ")
+	fmt.Fprint(b, getRangeTypeQualifiedName(d), " AS RANGE (SUBTYPE = ", d.Get(rangeSubtypeAttr).(string))
+
+	if v, ok := d.GetOk(rangeCollationAttr); ok {
+		fmt.Fprint(b, ", COLLATION = ", pq.QuoteIdentifier(v.(string)))
+	}
+	// Functions are not quoted so they can be schema qualified.
+	if v, ok := d.GetOk(rangeCanonicalAttr); ok {
+		fmt.Fprint(b, ", CANONICAL = ", v.(string))
+	}
+	if v, ok := d.GetOk(rangeSubtypeDiffAttr); ok {
+		fmt.Fprint(b, ", SUBTYPE_DIFF = ", v.(string))
+	}
+	fmt.Fprint(b, ")")
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create range type %s: %w", d.Get(rangeNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating range type: %w", err)
+	}
+
+	d.SetId(generateRangeTypeID(d, database))
+
+	return resourcePostgreSQLRangeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLRangeTypeExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, rangeSchema, rangeName, err := getDBRangeTypeName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return typeExists(txn, rangeSchema, rangeName, "r")
+}
+
+func resourcePostgreSQLRangeTypeRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLRangeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLRangeTypeReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, rangeSchema, rangeName, err := getDBRangeTypeName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var subtype, canonical, subtypeDiff string
+	var collation sql.NullString
+
+	query := `SELECT pg_catalog.format_type(r.rngsubtype, NULL), c.collname, ` +
+		`CASE WHEN r.rngcanonical = 0 THEN '' ELSE r.rngcanonical::regproc::text END, ` +
+		`CASE WHEN r.rngsubdiff = 0 THEN '' ELSE r.rngsubdiff::regproc::text END ` +
+		`FROM pg_catalog.pg_range r ` +
+		`JOIN pg_catalog.pg_type t ON t.oid = r.rngtypid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace ` +
+		`LEFT JOIN pg_catalog.pg_collation c ON c.oid = r.rngcollation ` +
+		`WHERE n.nspname = $1 AND t.typname = $2`
+	err = txn.QueryRow(query, rangeSchema, rangeName).Scan(&subtype, &collation, &canonical, &subtypeDiff)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL range type (%s.%s) not found for database %s", rangeSchema, rangeName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading range type: %w", err)
+	}
+
+	// PostgreSQL normalizes the type name (e.g.: timestamptz becomes timestamp with time zone)
+	// and the function names depend on the search_path, so we only set them when importing.
+	if d.Get(rangeSubtypeAttr).(string) == "" {
+		d.Set(rangeSubtypeAttr, subtype)
+		d.Set(rangeCanonicalAttr, canonical)
+		d.Set(rangeSubtypeDiffAttr, subtypeDiff)
+
+		// The default collation of a collatable subtype is set implicitly.
+		if collation.Valid && collation.String != "default" {
+			d.Set(rangeCollationAttr, collation.String)
+		}
+	}
+
+	d.Set(rangeNameAttr, rangeName)
+	d.Set(rangeSchemaAttr, rangeSchema)
+	d.Set(rangeDatabaseAttr, database)
+
+	return nil
+}
+
+func resourcePostgreSQLRangeTypeUpdate(db *DBConnection, d *schema.ResourceData) error {
+	// All the attributes force a new resource, except drop_cascade which is only used on destroy.
+	return resourcePostgreSQLRangeTypeReadImpl(db, d)
+}
+
+func resourcePostgreSQLRangeTypeDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(rangeDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP TYPE %s %s", getRangeTypeQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop range type %s: %w", d.Get(rangeNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting range type: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getRangeTypeQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(rangeSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(rangeNameAttr).(string)),
+	)
+}
+
+func generateRangeTypeID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(rangeSchemaAttr).(string),
+		d.Get(rangeNameAttr).(string),
+	}, ".")
+}
+
+// getDBRangeTypeName returns the database, schema and name of the range type. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBRangeTypeName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	rangeSchema := d.Get(rangeSchemaAttr).(string)
+	rangeName := d.Get(rangeNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the range type, schema and database names.
+	if rangeName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("range type ID %s has not the expected format 'database.schema.range': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		rangeSchema = parsed[1]
+		rangeName = parsed[2]
+	}
+
+	return database, rangeSchema, rangeName, nil
+}
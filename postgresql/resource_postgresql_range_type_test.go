@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlRangeType_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRangeTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_range_type" "test" {
+					name         = "floatrange"
+					database     = "%s"
+					subtype      = "float8"
+					subtype_diff = "float8mi"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRangeTypeExists("postgresql_range_type.test"),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "id", fmt.Sprintf("%s.public.floatrange", dbName)),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "subtype", "float8"),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "subtype_diff", "float8mi"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_range_type.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{rangeSubtypeAttr, rangeDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlRangeType_Collation(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRangeTypeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_range_type" "test" {
+					name      = "textrange"
+					database  = "%s"
+					subtype   = "text"
+					collation = "C"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRangeTypeExists("postgresql_range_type.test"),
+					resource.TestCheckResourceAttr("postgresql_range_type.test", "collation", "C"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlRangeTypeDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_range_type" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[rangeDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := typeExists(txn, rs.Primary.Attributes[rangeSchemaAttr], rs.Primary.Attributes[rangeNameAttr], "r")
+		if err != nil {
+			return fmt.Errorf("Error checking range type %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Range type still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlRangeTypeExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[rangeDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := typeExists(txn, rs.Primary.Attributes[rangeSchemaAttr], rs.Primary.Attributes[rangeNameAttr], "r")
+		if err != nil {
+			return fmt.Errorf("Error checking range type %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Range type not found")
+		}
+
+		return nil
+	}
+}
@@ -11,10 +11,9 @@ import (
 
 func resourcePostgreSQLReplicationSlot() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLReplicationSlotCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLReplicationSlotRead),
-		Delete: PGResourceFunc(resourcePostgreSQLReplicationSlotDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLReplicationSlotExists),
+		CreateContext: PGResourceFunc(resourcePostgreSQLReplicationSlotCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLReplicationSlotExists, resourcePostgreSQLReplicationSlotRead),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLReplicationSlotDelete),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -181,7 +180,7 @@ func generateReplicationSlotID(d *schema.ResourceData, databaseName string) stri
 
 func getReplicationSlotNameFromID(ID string) string {
 	splitted := strings.Split(ID, ".")
-	return splitted[0]
+	return splitted[len(splitted)-1]
 }
 
 // getDBReplicationSlotName returns database and replication slot name. If we are importing this
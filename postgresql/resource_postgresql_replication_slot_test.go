@@ -32,10 +32,66 @@ func TestAccPostgresqlReplicationSlot_Basic(t *testing.T) {
 						"postgresql_replication_slot.myslot", "plugin", "test_decoding"),
 				),
 			},
+			{
+				ResourceName:      "postgresql_replication_slot.myslot",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlReplicationSlot_DroppedOutside(t *testing.T) {
+	config := `
+	resource "postgresql_replication_slot" "myslot" {
+		name   = "slot"
+		plugin = "test_decoding"
+	}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlReplicationSlotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlReplicationSlotExists("postgresql_replication_slot.myslot"),
+					// Drop the slot behind Terraform's back,
+					// the next plan should want to create it again.
+					testAccDropReplicationSlot("slot"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlReplicationSlotExists("postgresql_replication_slot.myslot"),
+				),
+			},
 		},
 	})
 }
 
+func testAccDropReplicationSlot(slotName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		if _, err = db.Exec("SELECT pg_drop_replication_slot($1)", slotName); err != nil {
+			return fmt.Errorf("could not drop replication slot %s: %s", slotName, err)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckPostgresqlReplicationSlotDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
 
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lib/pq"
@@ -26,6 +27,7 @@ const (
 	roleLoginAttr                           = "login"
 	roleNameAttr                            = "name"
 	rolePasswordAttr                        = "password"
+	rolePasswordEncryptionAttr              = "password_encryption"
 	roleReplicationAttr                     = "replication"
 	roleSkipDropRoleAttr                    = "skip_drop_role"
 	roleSkipReassignOwnedAttr               = "skip_reassign_owned"
@@ -41,14 +43,21 @@ const (
 
 func resourcePostgreSQLRole() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLRoleCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLRoleRead),
-		Update: PGResourceFunc(resourcePostgreSQLRoleUpdate),
-		Delete: PGResourceFunc(resourcePostgreSQLRoleDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLRoleExists),
+		CreateContext: PGResourceFunc(resourcePostgreSQLRoleCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLRoleExists, resourcePostgreSQLRoleRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLRoleUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLRoleDelete),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithOID(readRoleNameByOID),
 		},
+		Identity: oidIdentity(nil),
+		CustomizeDiff: customdiff.All(
+			requireFeature(featureSCRAMPassword, "password_encryption scram-sha-256", func(d *schema.ResourceDiff) bool {
+				return d.Get(rolePasswordEncryptionAttr).(string) == "scram-sha-256"
+			}),
+			redshiftRoleCustomizeDiff,
+			cockroachDBRoleCustomizeDiff,
+		),
 
 		Schema: map[string]*schema.Schema{
 			roleNameAttr: {
@@ -62,6 +71,13 @@ func resourcePostgreSQLRole() *schema.Resource {
 				Sensitive:   true,
 				Description: "Sets the role's password",
 			},
+			rolePasswordEncryptionAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"md5", "scram-sha-256"}, false),
+				Description:  "The algorithm used to hash the role's password (md5 or scram-sha-256), defaults to the password_encryption setting of the server",
+			},
 			roleDepEncryptedAttr: {
 				Type:       schema.TypeString,
 				Optional:   true,
@@ -167,11 +183,35 @@ func resourcePostgreSQLRole() *schema.Resource {
 				Description:  "Abort any statement that takes more than the specified number of milliseconds",
 				ValidateFunc: validation.IntAtLeast(0),
 			},
+			roleResourceQueueAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The resource queue of the role (Greenplum only)",
+			},
+			roleResourceGroupAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The resource group of the role (Greenplum only)",
+			},
 		},
 	}
 }
 
 func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) error {
+	if d.Get(roleSuperuserAttr).(bool) {
+		if err := db.requireSuperuser("creating a SUPERUSER role"); err != nil {
+			return err
+		}
+	}
+	if db.isRedshift() {
+		return resourceRedshiftUserCreate(db, d)
+	}
+	if err := checkGreenplumRoleAttrs(db, d); err != nil {
+		return err
+	}
+
 	txn, err := startTransaction(db.client, "")
 	if err != nil {
 		return err
@@ -207,6 +247,17 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 		// {roleEncryptedPassAttr, "ENCRYPTED", "UNENCRYPTED"},
 	}
 
+	if db.isCockroachDB() {
+		// CockroachDB has no SUPERUSER, INHERIT and CONNECTION LIMIT options,
+		// see cockroachDBRoleCustomizeDiff.
+		intOpts = nil
+		boolOpts = []boolOptType{
+			{roleCreateDBAttr, "CREATEDB", "NOCREATEDB"},
+			{roleCreateRoleAttr, "CREATEROLE", "NOCREATEROLE"},
+			{roleLoginAttr, "LOGIN", "NOLOGIN"},
+		}
+	}
+
 	if db.featureSupported(featureRLS) {
 		boolOpts = append(boolOpts, boolOptType{roleBypassRLSAttr, "BYPASSRLS", "NOBYPASSRLS"})
 	}
@@ -230,9 +281,12 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 				if strings.ToUpper(v.(string)) == "NULL" {
 					createOpts = append(createOpts, "PASSWORD NULL")
 				} else {
-					if d.Get(roleEncryptedPassAttr).(bool) {
+					// CockroachDB always hashes the passwords and doesn't support ENCRYPTED.
+					switch {
+					case db.isCockroachDB():
+					case d.Get(roleEncryptedPassAttr).(bool):
 						createOpts = append(createOpts, "ENCRYPTED")
-					} else {
+					default:
 						createOpts = append(createOpts, "UNENCRYPTED")
 					}
 					createOpts = append(createOpts, fmt.Sprintf("%s '%s'", opt.sqlKey, pqQuoteLiteral(val)))
@@ -269,6 +323,14 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 		createOpts = append(createOpts, valStr)
 	}
 
+	if db.isGreenplum() {
+		createOpts = append(createOpts, greenplumRoleOptions(d)...)
+	}
+
+	if err := setPasswordEncryption(db, txn, d); err != nil {
+		return err
+	}
+
 	roleName := d.Get(roleNameAttr).(string)
 	createStr := strings.Join(createOpts, " ")
 	if len(createOpts) > 0 {
@@ -311,6 +373,10 @@ func resourcePostgreSQLRoleCreate(db *DBConnection, d *schema.ResourceData) erro
 }
 
 func resourcePostgreSQLRoleDelete(db *DBConnection, d *schema.ResourceData) error {
+	if db.isRedshift() {
+		return resourceRedshiftUserDelete(db, d)
+	}
+
 	roleName := d.Get(roleNameAttr).(string)
 
 	txn, err := startTransaction(db.client, "")
@@ -319,7 +385,7 @@ func resourcePostgreSQLRoleDelete(db *DBConnection, d *schema.ResourceData) erro
 	}
 	defer deferredRollback(txn)
 
-	if err := pgLockRole(txn, roleName); err != nil {
+	if err := db.lockRole(txn, roleName); err != nil {
 		return err
 	}
 
@@ -354,6 +420,10 @@ func resourcePostgreSQLRoleDelete(db *DBConnection, d *schema.ResourceData) erro
 }
 
 func resourcePostgreSQLRoleExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if db.isRedshift() {
+		return resourceRedshiftUserExists(db, d)
+	}
+
 	var roleName string
 	err := db.QueryRow("SELECT rolname FROM pg_catalog.pg_roles WHERE rolname=$1", d.Id()).Scan(&roleName)
 	switch {
@@ -367,18 +437,36 @@ func resourcePostgreSQLRoleExists(db *DBConnection, d *schema.ResourceData) (boo
 }
 
 func resourcePostgreSQLRoleRead(db *DBConnection, d *schema.ResourceData) error {
+	if db.isRedshift() {
+		return resourceRedshiftUserRead(db, d)
+	}
 	return resourcePostgreSQLRoleReadImpl(db, d)
 }
 
+// readRoleNameByOID returns the name of the role with the OID of the identity.
+func readRoleNameByOID(db *DBConnection, identity *schema.IdentityData) (string, error) {
+	var roleName string
+	oid := identity.Get(identityOIDAttr).(int)
+	err := db.QueryRow("SELECT rolname FROM pg_catalog.pg_roles WHERE oid=$1", oid).Scan(&roleName)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", fmt.Errorf("role with OID %d not found", oid)
+	case err != nil:
+		return "", fmt.Errorf("Error reading ROLE: %w", err)
+	}
+	return roleName, nil
+}
+
 func resourcePostgreSQLRoleReadImpl(db *DBConnection, d *schema.ResourceData) error {
 	var roleSuperuser, roleInherit, roleCreateRole, roleCreateDB, roleCanLogin, roleReplication, roleBypassRLS bool
-	var roleConnLimit int
+	var roleConnLimit, roleOID int
 	var roleName, roleValidUntil string
 	var roleRoles, roleConfig pq.ByteaArray
 
 	roleID := d.Id()
 
 	columns := []string{
+		"oid",
 		"rolname",
 		"rolsuper",
 		"rolinherit",
@@ -392,6 +480,7 @@ func resourcePostgreSQLRoleReadImpl(db *DBConnection, d *schema.ResourceData) er
 
 	values := []interface{}{
 		&roleRoles,
+		&roleOID,
 		&roleName,
 		&roleSuperuser,
 		&roleInherit,
@@ -462,13 +551,23 @@ func resourcePostgreSQLRoleReadImpl(db *DBConnection, d *schema.ResourceData) er
 	d.Set(roleIdleInTransactionSessionTimeoutAttr, idleInTransactionSessionTimeout)
 
 	d.SetId(roleName)
+	if err := setIdentity(d, map[string]interface{}{identityOIDAttr: roleOID}); err != nil {
+		return err
+	}
 
-	password, err := readRolePassword(db, d, roleCanLogin)
+	password, passwordEncryption, err := readRolePassword(db, d, roleCanLogin)
 	if err != nil {
 		return err
 	}
 
 	d.Set(rolePasswordAttr, password)
+	if passwordEncryption != "" {
+		d.Set(rolePasswordEncryptionAttr, passwordEncryption)
+	}
+
+	if db.isGreenplum() {
+		return readGreenplumRoleResources(db, d)
+	}
 	return nil
 }
 
@@ -524,66 +623,79 @@ func readStatementTimeout(roleConfig pq.ByteaArray) (int, error) {
 
 // readRolePassword reads password either from Postgres if admin user is a superuser
 // or only from Terraform state.
-func readRolePassword(db *DBConnection, d *schema.ResourceData, roleCanLogin bool) (string, error) {
+// It also returns the algorithm used to hash the password stored in Postgres,
+// or an empty string if it cannot be read.
+func readRolePassword(db *DBConnection, d *schema.ResourceData, roleCanLogin bool) (string, string, error) {
 	statePassword := d.Get(rolePasswordAttr).(string)
 
 	// Role which cannot login does not have password in pg_shadow.
-	// Also, if user specifies that admin is not a superuser we don't try to read pg_shadow
-	// (only superuser can read pg_shadow)
-	if !roleCanLogin || !db.client.config.Superuser {
-		return statePassword, nil
-	}
-
-	// Otherwise we check if connected user is really a superuser
-	// (in order to warn user instead of having a permission denied error)
-	superuser, err := db.isSuperuser()
-	if err != nil {
-		return "", err
-	}
-	if !superuser {
-		return "", fmt.Errorf(
-			"could not read role password from Postgres as "+
-				"connected user %s is not a SUPERUSER. "+
-				"You can set `superuser = false` in the provider configuration "+
-				"so it will not try to read the password from Postgres",
-			db.client.config.getDatabaseUsername(),
-		)
+	// Also, if the connected user is not a superuser we don't try to read pg_shadow
+	// (only superuser can read pg_shadow), and CockroachDB doesn't have it.
+	if !roleCanLogin || !db.isSuperuser() || db.isCockroachDB() {
+		return statePassword, "", nil
 	}
 
 	var rolePassword string
-	err = db.QueryRow("SELECT COALESCE(passwd, '') FROM pg_catalog.pg_shadow AS s WHERE s.usename = $1", d.Id()).Scan(&rolePassword)
+	err := db.QueryRow("SELECT COALESCE(passwd, '') FROM pg_catalog.pg_shadow AS s WHERE s.usename = $1", d.Id()).Scan(&rolePassword)
 	switch {
 	case err == sql.ErrNoRows:
 		// They don't have a password
-		return "", nil
+		return "", "", nil
 	case err != nil:
-		return "", fmt.Errorf("Error reading role: %w", err)
+		return "", "", fmt.Errorf("Error reading role: %w", err)
 	}
+
+	passwordEncryption := passwordHashAlgorithm(rolePassword)
+
 	// If the password isn't already in md5 format, but hashing the input
 	// matches the password in the database for the user, they are the same
 	if statePassword != "" && !strings.HasPrefix(statePassword, "md5") && !strings.HasPrefix(statePassword, "SCRAM-SHA-256") {
 		if strings.HasPrefix(rolePassword, "md5") {
 			hasher := md5.New()
 			if _, err := hasher.Write([]byte(statePassword + d.Id())); err != nil {
-				return "", err
+				return "", "", err
 			}
 			hashedPassword := "md5" + hex.EncodeToString(hasher.Sum(nil))
 
 			if hashedPassword == rolePassword {
 				// The passwords are actually the same
 				// make Terraform think they are the same
-				return statePassword, nil
+				return statePassword, passwordEncryption, nil
 			}
 		}
 		if strings.HasPrefix(rolePassword, "SCRAM-SHA-256") {
-			return statePassword, nil
+			return statePassword, passwordEncryption, nil
 			// TODO : implement scram-sha-256 challenge request to the server
 		}
 	}
-	return rolePassword, nil
+	return rolePassword, passwordEncryption, nil
+}
+
+// passwordHashAlgorithm returns the password_encryption value
+// used to hash a password stored in pg_shadow.
+func passwordHashAlgorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "SCRAM-SHA-256$"):
+		return "scram-sha-256"
+	case strings.HasPrefix(hash, "md5") && len(hash) == 35:
+		return "md5"
+	}
+	return ""
 }
 
 func resourcePostgreSQLRoleUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if d.HasChange(roleSuperuserAttr) {
+		if err := db.requireSuperuser("changing the SUPERUSER attribute of a role"); err != nil {
+			return err
+		}
+	}
+	if db.isRedshift() {
+		return resourceRedshiftUserUpdate(db, d)
+	}
+	if err := checkGreenplumRoleAttrs(db, d); err != nil {
+		return err
+	}
+
 	txn, err := startTransaction(db.client, "")
 	if err != nil {
 		return err
@@ -591,7 +703,7 @@ func resourcePostgreSQLRoleUpdate(db *DBConnection, d *schema.ResourceData) erro
 	defer deferredRollback(txn)
 
 	oldName, _ := d.GetChange(roleNameAttr)
-	if err := pgLockRole(txn, oldName.(string)); err != nil {
+	if err := db.lockRole(txn, oldName.(string)); err != nil {
 		return err
 	}
 
@@ -599,7 +711,7 @@ func resourcePostgreSQLRoleUpdate(db *DBConnection, d *schema.ResourceData) erro
 		return err
 	}
 
-	if err := setRolePassword(txn, d); err != nil {
+	if err := setRolePassword(db, txn, d); err != nil {
 		return err
 	}
 
@@ -639,6 +751,12 @@ func resourcePostgreSQLRoleUpdate(db *DBConnection, d *schema.ResourceData) erro
 		return err
 	}
 
+	if db.isGreenplum() {
+		if err := setGreenplumRoleResources(txn, d); err != nil {
+			return err
+		}
+	}
+
 	// applying roles: let's revoke all / grant the right ones
 	if err = revokeRoles(txn, d); err != nil {
 		return err
@@ -689,16 +807,21 @@ func setRoleName(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setRolePassword(txn *sql.Tx, d *schema.ResourceData) error {
+func setRolePassword(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
 	// If role is renamed, password is reset (as the md5 sum is also base on the role name)
-	// so we need to update it
-	if !d.HasChange(rolePasswordAttr) && !d.HasChange(roleNameAttr) {
+	// so we need to update it.
+	// The password also needs to be hashed again if the requested algorithm changed.
+	if !d.HasChange(rolePasswordAttr) && !d.HasChange(roleNameAttr) && !d.HasChange(rolePasswordEncryptionAttr) {
 		return nil
 	}
 
 	roleName := d.Get(roleNameAttr).(string)
 	password := d.Get(rolePasswordAttr).(string)
 
+	if err := setPasswordEncryption(db, txn, d); err != nil {
+		return err
+	}
+
 	sql := fmt.Sprintf("ALTER ROLE %s PASSWORD '%s'", pq.QuoteIdentifier(roleName), pqQuoteLiteral(password))
 	if _, err := txn.Exec(sql); err != nil {
 		return fmt.Errorf("Error updating role password: %w", err)
@@ -706,6 +829,29 @@ func setRolePassword(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
+// setPasswordEncryption sets password_encryption for the current transaction
+// so the role's password is hashed with the requested algorithm.
+func setPasswordEncryption(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
+	passwordEncryption := d.Get(rolePasswordEncryptionAttr).(string)
+	if passwordEncryption == "" {
+		return nil
+	}
+
+	if !db.featureSupported(featureSCRAMPassword) {
+		if passwordEncryption != "md5" {
+			return fmt.Errorf("PostgreSQL client is talking with a server (%q) that does not support %s password encryption", db.version.String(), passwordEncryption)
+		}
+		// Before Postgres 10, password_encryption is a boolean and
+		// ENCRYPTED passwords are always hashed with md5.
+		return nil
+	}
+
+	if _, err := txn.Exec(fmt.Sprintf("SET LOCAL password_encryption = '%s'", pqQuoteLiteral(passwordEncryption))); err != nil {
+		return fmt.Errorf("could not set password_encryption to %s: %w", passwordEncryption, err)
+	}
+	return nil
+}
+
 func setRoleBypassRLS(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
 	if !d.HasChange(roleBypassRLSAttr) {
 		return nil
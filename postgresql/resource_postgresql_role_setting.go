@@ -0,0 +1,230 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	roleSettingRoleAttr      = "role"
+	roleSettingDatabaseAttr  = "database"
+	roleSettingParameterAttr = "parameter"
+	roleSettingValueAttr     = "value"
+)
+
+func resourcePostgreSQLRoleSetting() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLRoleSettingCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLRoleSettingRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLRoleSettingUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLRoleSettingDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			roleSettingRoleAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the role for which the parameter is set",
+			},
+			roleSettingDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The database in which the parameter is set for the role. The parameter is set in all the databases if empty",
+			},
+			roleSettingParameterAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the configuration parameter",
+			},
+			roleSettingValueAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The value of the configuration parameter",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLRoleSettingCreate(db *DBConnection, d *schema.ResourceData) error {
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setRoleSetting(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating role setting: %w", err)
+	}
+
+	d.SetId(generateRoleSettingID(d))
+
+	return resourcePostgreSQLRoleSettingReadImpl(db, d)
+}
+
+func resourcePostgreSQLRoleSettingRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLRoleSettingReadImpl(db, d)
+}
+
+func resourcePostgreSQLRoleSettingReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	role, database, parameter, err := getRoleSettingParameter(d)
+	if err != nil {
+		return err
+	}
+
+	// setdatabase is 0 for the settings which apply to all the databases.
+	var config pq.StringArray
+	query := `SELECT s.setconfig FROM pg_catalog.pg_db_role_setting s ` +
+		`JOIN pg_catalog.pg_roles r ON r.oid = s.setrole ` +
+		`LEFT JOIN pg_catalog.pg_database db ON db.oid = s.setdatabase ` +
+		`WHERE r.rolname = $1 AND COALESCE(db.datname, '') = $2`
+	err = db.QueryRow(query, role, database).Scan(&config)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL role setting (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading role setting: %w", err)
+	}
+
+	value, ok := findRoleSettingValue(config, parameter)
+	if !ok {
+		log.Printf("[WARN] PostgreSQL role setting (%s) not found", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(roleSettingRoleAttr, role)
+	d.Set(roleSettingDatabaseAttr, database)
+	d.Set(roleSettingParameterAttr, parameter)
+	d.Set(roleSettingValueAttr, value)
+
+	return nil
+}
+
+func resourcePostgreSQLRoleSettingUpdate(db *DBConnection, d *schema.ResourceData) error {
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChange(roleSettingValueAttr) {
+		if err := setRoleSetting(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating role setting: %w", err)
+	}
+
+	return resourcePostgreSQLRoleSettingReadImpl(db, d)
+}
+
+func resourcePostgreSQLRoleSettingDelete(db *DBConnection, d *schema.ResourceData) error {
+	txn, err := startTransaction(db.client, "")
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	parameter := d.Get(roleSettingParameterAttr).(string)
+
+	sql := fmt.Sprintf("%s RESET %s", getRoleSettingAlterPrefix(d), pq.QuoteIdentifier(parameter))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not reset %s for role %s: %w", parameter, d.Get(roleSettingRoleAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting role setting: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setRoleSetting(txn *sql.Tx, d *schema.ResourceData) error {
+	parameter := d.Get(roleSettingParameterAttr).(string)
+	value := d.Get(roleSettingValueAttr).(string)
+
+	sql := fmt.Sprintf(
+		"%s SET %s TO '%s'",
+		getRoleSettingAlterPrefix(d), pq.QuoteIdentifier(parameter), pqQuoteLiteral(value),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not set %s to %s for role %s: %w", parameter, value, d.Get(roleSettingRoleAttr).(string), err)
+	}
+
+	return nil
+}
+
+// getRoleSettingAlterPrefix returns the beginning of the statement: ALTER ROLE role [ IN DATABASE database ]
+func getRoleSettingAlterPrefix(d *schema.ResourceData) string {
+	b := bytes.NewBufferString("ALTER ROLE ")
+	fmt.Fprint(b, pq.QuoteIdentifier(d.Get(roleSettingRoleAttr).(string)))
+
+	if database := d.Get(roleSettingDatabaseAttr).(string); database != "" {
+		fmt.Fprint(b, " IN DATABASE ", pq.QuoteIdentifier(database))
+	}
+
+	return b.String()
+}
+
+// findRoleSettingValue searches for the parameter in the setconfig array (which contains name=value entries).
+func findRoleSettingValue(config []string, parameter string) (string, bool) {
+	for _, entry := range config {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], parameter) {
+			return parts[1], true
+		}
+	}
+
+	return "", false
+}
+
+func generateRoleSettingID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		d.Get(roleSettingRoleAttr).(string),
+		d.Get(roleSettingDatabaseAttr).(string),
+		d.Get(roleSettingParameterAttr).(string),
+	}, "/")
+}
+
+// getRoleSettingParameter returns the role, database and parameter names. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getRoleSettingParameter(d *schema.ResourceData) (string, string, string, error) {
+	role := d.Get(roleSettingRoleAttr).(string)
+	database := d.Get(roleSettingDatabaseAttr).(string)
+	parameter := d.Get(roleSettingParameterAttr).(string)
+
+	// When importing, we have to parse the ID to find the role, database and parameter names.
+	if parameter == "" {
+		parsed := strings.Split(d.Id(), "/")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("role setting ID %s has not the expected format 'role/database/parameter': %v", d.Id(), parsed)
+		}
+		role = parsed[0]
+		database = parsed[1]
+		parameter = parsed[2]
+	}
+
+	return role, database, parameter, nil
+}
@@ -0,0 +1,161 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lib/pq"
+)
+
+func TestFindRoleSettingValue(t *testing.T) {
+	config := []string{"work_mem=64MB", "search_path=app, public", "log_statement=all"}
+
+	cases := []struct {
+		parameter string
+		value     string
+		found     bool
+	}{
+		{parameter: "work_mem", value: "64MB", found: true},
+		{parameter: "search_path", value: "app, public", found: true},
+		{parameter: "Log_Statement", value: "all", found: true},
+		{parameter: "statement_timeout", found: false},
+	}
+
+	for _, c := range cases {
+		value, found := findRoleSettingValue(config, c.parameter)
+		if value != c.value || found != c.found {
+			t.Fatalf("Error matching output and expected for %s: %#v, %#v vs %#v, %#v", c.parameter, value, found, c.value, c.found)
+		}
+	}
+}
+
+func TestAccPostgresqlRoleSetting_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	testAccPostgresqlRoleSettingConfig := func(value string) string {
+		return fmt.Sprintf(`
+		resource "postgresql_role_setting" "all" {
+			role      = "%[1]s"
+			parameter = "statement_timeout"
+			value     = "30s"
+		}
+
+		resource "postgresql_role_setting" "database" {
+			role      = "%[1]s"
+			database  = "%[2]s"
+			parameter = "work_mem"
+			value     = "%[3]s"
+		}`, roleName, dbName, value)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleSettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlRoleSettingConfig("64MB"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRoleSettingExists("postgresql_role_setting.all", "30s"),
+					testAccCheckPostgresqlRoleSettingExists("postgresql_role_setting.database", "64MB"),
+					resource.TestCheckResourceAttr("postgresql_role_setting.all", "id", fmt.Sprintf("%s//statement_timeout", roleName)),
+					resource.TestCheckResourceAttr("postgresql_role_setting.database", "id", fmt.Sprintf("%s/%s/work_mem", roleName, dbName)),
+				),
+			},
+			{
+				Config: testAccPostgresqlRoleSettingConfig("128MB"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRoleSettingExists("postgresql_role_setting.database", "128MB"),
+					resource.TestCheckResourceAttr("postgresql_role_setting.database", "value", "128MB"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_role_setting.database",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlRoleSettingDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_role_setting" {
+			continue
+		}
+
+		_, found, err := getTestRoleSetting(client, rs)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			return fmt.Errorf("Role setting still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlRoleSettingExists(n, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		value, found, err := getTestRoleSetting(testAccProvider.Meta().(*Client), rs)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return fmt.Errorf("Role setting not found")
+		}
+
+		if value != expected {
+			return fmt.Errorf("Expected role setting %q, got %q", expected, value)
+		}
+
+		return nil
+	}
+}
+
+func getTestRoleSetting(client *Client, rs *terraform.ResourceState) (string, bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return "", false, err
+	}
+
+	var config pq.StringArray
+	query := `SELECT s.setconfig FROM pg_catalog.pg_db_role_setting s ` +
+		`JOIN pg_catalog.pg_roles r ON r.oid = s.setrole ` +
+		`LEFT JOIN pg_catalog.pg_database db ON db.oid = s.setdatabase ` +
+		`WHERE r.rolname = $1 AND COALESCE(db.datname, '') = $2`
+	err = db.QueryRow(query, rs.Primary.Attributes[roleSettingRoleAttr], rs.Primary.Attributes[roleSettingDatabaseAttr]).Scan(&config)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", false, nil
+	case err != nil:
+		return "", false, fmt.Errorf("Error checking role setting %s", err)
+	}
+
+	value, found := findRoleSettingValue(config, rs.Primary.Attributes[roleSettingParameterAttr])
+	return value, found, nil
+}
@@ -223,6 +223,63 @@ resource "postgresql_role" "test_role" {
 	})
 }
 
+func TestAccPostgresqlRole_PasswordEncryption(t *testing.T) {
+	roleConfig := `
+resource "postgresql_role" "test_role" {
+  name                = "test_role"
+  login               = true
+  password            = "toto"
+  password_encryption = "%s"
+}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureSCRAMPassword)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(roleConfig, "md5"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRoleExists("test_role", nil, nil),
+					resource.TestCheckResourceAttr("postgresql_role.test_role", "password_encryption", "md5"),
+					testAccCheckRolePasswordEncryption("test_role", "md5"),
+					testAccCheckRoleCanLogin(t, "test_role", "toto"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(roleConfig, "scram-sha-256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_role.test_role", "password_encryption", "scram-sha-256"),
+					testAccCheckRolePasswordEncryption("test_role", "scram-sha-256"),
+					testAccCheckRoleCanLogin(t, "test_role", "toto"),
+				),
+			},
+		},
+	})
+}
+
+func TestPasswordHashAlgorithm(t *testing.T) {
+	var tests = []struct {
+		hash string
+		want string
+	}{
+		{"md5c98cbfeb6a347a47eb8e96cfb4c4b890", "md5"},
+		{"SCRAM-SHA-256$4096:c2FsdA==$c3RvcmVk:c2VydmVy", "scram-sha-256"},
+		{"md5", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := passwordHashAlgorithm(test.hash); got != test.want {
+			t.Errorf("passwordHashAlgorithm(%q) returned %q, want %q", test.hash, got, test.want)
+		}
+	}
+}
+
 func testAccCheckPostgresqlRoleDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
 
@@ -432,3 +489,23 @@ resource "postgresql_role" "role_with_search_path" {
   search_path = ["bar", "foo-with-hyphen"]
 }
 `
+
+func testAccCheckRolePasswordEncryption(roleName, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		db, err := client.Connect()
+		if err != nil {
+			return err
+		}
+
+		var hash string
+		if err := db.QueryRow("SELECT COALESCE(passwd, '') FROM pg_catalog.pg_shadow WHERE usename = $1", roleName).Scan(&hash); err != nil {
+			return fmt.Errorf("could not read password of role %s: %w", roleName, err)
+		}
+
+		if algorithm := passwordHashAlgorithm(hash); algorithm != expected {
+			return fmt.Errorf("password of role %s is hashed with %q, expected %q", roleName, algorithm, expected)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,335 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	rowsDatabaseAttr   = "database"
+	rowsSchemaAttr     = "schema"
+	rowsTableAttr      = "table"
+	rowsKeyColumnsAttr = "key_columns"
+	rowsRowsAttr       = "rows"
+)
+
+func resourcePostgreSQLRows() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLRowsCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLRowsRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLRowsUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLRowsDelete),
+
+		Schema: map[string]*schema.Schema{
+			rowsDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the table is located",
+			},
+			rowsSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the table",
+			},
+			rowsTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The table in which the rows are kept",
+			},
+			rowsKeyColumnsAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The columns identifying the rows, they must be covered by a primary key or a unique constraint",
+			},
+			rowsRowsAttr: {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+				Description: "The rows, as maps of the column names to the values in their text representation (the omitted columns are NULL or get their default)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLRowsCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	for _, row := range d.Get(rowsRowsAttr).([]interface{}) {
+		if err := upsertRow(txn, d, row.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating rows: %w", err)
+	}
+
+	d.SetId(generateRowsID(d, database))
+
+	return resourcePostgreSQLRowsReadImpl(db, d)
+}
+
+func resourcePostgreSQLRowsRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLRowsReadImpl(db, d)
+}
+
+func resourcePostgreSQLRowsReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// Each row of the state is read back with the columns it manages: the rows deleted outside
+	// of Terraform are removed and the changed values are updated, so they will be upserted again.
+	rows := []interface{}{}
+	for _, r := range d.Get(rowsRowsAttr).([]interface{}) {
+		row, err := readRow(txn, d, r.(map[string]interface{}))
+		switch {
+		case err == sql.ErrNoRows:
+			continue
+		case err != nil:
+			return fmt.Errorf("Error reading rows: %w", err)
+		}
+		rows = append(rows, row)
+	}
+
+	d.Set(rowsDatabaseAttr, database)
+	d.Set(rowsRowsAttr, rows)
+
+	return nil
+}
+
+func resourcePostgreSQLRowsUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChange(rowsRowsAttr) {
+		oraw, nraw := d.GetChange(rowsRowsAttr)
+
+		// The rows whose key is not in the configuration anymore are deleted.
+		newKeys := map[string]bool{}
+		for _, row := range nraw.([]interface{}) {
+			newKeys[getRowKey(d, row.(map[string]interface{}))] = true
+		}
+		for _, row := range oraw.([]interface{}) {
+			if !newKeys[getRowKey(d, row.(map[string]interface{}))] {
+				if err := deleteRow(txn, d, row.(map[string]interface{})); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, row := range nraw.([]interface{}) {
+			if err := upsertRow(txn, d, row.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating rows: %w", err)
+	}
+
+	return resourcePostgreSQLRowsReadImpl(db, d)
+}
+
+func resourcePostgreSQLRowsDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	for _, row := range d.Get(rowsRowsAttr).([]interface{}) {
+		if err := deleteRow(txn, d, row.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting rows: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// upsertRow inserts the row, or updates the other columns of the existing row with the same key.
+func upsertRow(txn *sql.Tx, d *schema.ResourceData, row map[string]interface{}) error {
+	keyColumns := getRowsKeyColumns(d)
+	for _, column := range keyColumns {
+		if _, ok := row[column]; !ok {
+			return fmt.Errorf("key column %s is missing in row %v", column, row)
+		}
+	}
+
+	isKey := map[string]bool{}
+	for _, column := range keyColumns {
+		isKey[column] = true
+	}
+
+	columns := getRowColumns(row)
+	quotedColumns := []string{}
+	placeholders := []string{}
+	updates := []string{}
+	args := []interface{}{}
+	for i, column := range columns {
+		quotedColumns = append(quotedColumns, pq.QuoteIdentifier(column))
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+		args = append(args, row[column].(string))
+		if !isKey[column] {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", pq.QuoteIdentifier(column), pq.QuoteIdentifier(column)))
+		}
+	}
+
+	action := "NOTHING"
+	if len(updates) > 0 {
+		action = "UPDATE SET " + strings.Join(updates, ", ")
+	}
+
+	sql := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO %s",
+		getRowsTableQualifiedName(d), strings.Join(quotedColumns, ", "), strings.Join(placeholders, ", "),
+		quoteIdentifierList(d.Get(rowsKeyColumnsAttr).([]interface{})), action,
+	)
+	if _, err := txn.Exec(sql, args...); err != nil {
+		return fmt.Errorf("could not upsert row %s: %w", getRowKey(d, row), err)
+	}
+
+	return nil
+}
+
+func deleteRow(txn *sql.Tx, d *schema.ResourceData, row map[string]interface{}) error {
+	where, args := getRowKeyCondition(d, row)
+
+	sql := fmt.Sprintf("DELETE FROM %s WHERE %s", getRowsTableQualifiedName(d), where)
+	if _, err := txn.Exec(sql, args...); err != nil {
+		return fmt.Errorf("could not delete row %s: %w", getRowKey(d, row), err)
+	}
+
+	return nil
+}
+
+// readRow reads the columns of the row from the table, it returns sql.ErrNoRows if the row does not exist.
+func readRow(txn *sql.Tx, d *schema.ResourceData, row map[string]interface{}) (map[string]interface{}, error) {
+	columns := getRowColumns(row)
+	selects := []string{}
+	for _, column := range columns {
+		selects = append(selects, pq.QuoteIdentifier(column)+"::text")
+	}
+
+	where, args := getRowKeyCondition(d, row)
+
+	values := make([]sql.NullString, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", strings.Join(selects, ", "), getRowsTableQualifiedName(d), where)
+	if err := txn.QueryRow(query, args...).Scan(pointers...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		// A NULL value is reported as an empty string, so it will be set again.
+		result[column] = values[i].String
+	}
+
+	return result, nil
+}
+
+// getRowKeyCondition returns the WHERE condition matching the key of the row, with its arguments.
+func getRowKeyCondition(d *schema.ResourceData, row map[string]interface{}) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	for i, column := range getRowsKeyColumns(d) {
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", pq.QuoteIdentifier(column), i+1))
+		value, _ := row[column].(string)
+		args = append(args, value)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// getRowKey returns a string representation of the key of the row.
+func getRowKey(d *schema.ResourceData, row map[string]interface{}) string {
+	values := []string{}
+	for _, column := range getRowsKeyColumns(d) {
+		value, _ := row[column].(string)
+		values = append(values, fmt.Sprintf("%s=%s", column, value))
+	}
+
+	return "(" + strings.Join(values, ", ") + ")"
+}
+
+// getRowColumns returns the sorted names of the columns of the row, so the generated statements are stable.
+func getRowColumns(row map[string]interface{}) []string {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+func getRowsKeyColumns(d *schema.ResourceData) []string {
+	columns := []string{}
+	for _, column := range d.Get(rowsKeyColumnsAttr).([]interface{}) {
+		columns = append(columns, column.(string))
+	}
+
+	return columns
+}
+
+func getRowsTableQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(rowsSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(rowsTableAttr).(string)),
+	)
+}
+
+func generateRowsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(rowsSchemaAttr).(string),
+		d.Get(rowsTableAttr).(string),
+	}, ".")
+}
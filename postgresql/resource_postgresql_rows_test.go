@@ -0,0 +1,140 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestGetRowColumns(t *testing.T) {
+	row := map[string]interface{}{
+		"rollout": "25",
+		"name":    "dark_mode",
+		"enabled": "false",
+	}
+
+	expected := []string{"enabled", "name", "rollout"}
+	if columns := getRowColumns(row); !reflect.DeepEqual(columns, expected) {
+		t.Fatalf("expected %v, got %v", expected, columns)
+	}
+}
+
+func TestAccPostgresqlRows_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE feature_flags (name text PRIMARY KEY, enabled boolean NOT NULL, rollout integer)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRowsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_rows" "test" {
+					database    = "%s"
+					table       = "feature_flags"
+					key_columns = ["name"]
+					rows = [
+						{ name = "new_checkout", enabled = "true" },
+						{ name = "dark_mode", enabled = "false", rollout = "25" },
+					]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_rows.test", "id", fmt.Sprintf("%s.public.feature_flags", dbName)),
+					resource.TestCheckResourceAttr("postgresql_rows.test", "rows.#", "2"),
+					testAccCheckPostgresqlRowValue(dbName, "new_checkout", "true"),
+					testAccCheckPostgresqlRowValue(dbName, "dark_mode", "false"),
+				),
+			},
+			{
+				// The row modified outside of Terraform is upserted again and the removed row is deleted.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "UPDATE feature_flags SET enabled = false WHERE name = 'new_checkout'")
+				},
+				Config: fmt.Sprintf(`
+				resource "postgresql_rows" "test" {
+					database    = "%s"
+					table       = "feature_flags"
+					key_columns = ["name"]
+					rows = [
+						{ name = "new_checkout", enabled = "true" },
+					]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("postgresql_rows.test", "rows.#", "1"),
+					testAccCheckPostgresqlRowValue(dbName, "new_checkout", "true"),
+					testAccCheckPostgresqlRowValue(dbName, "dark_mode", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlRowsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_rows" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[rowsDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		var count int
+		if err := txn.QueryRow("SELECT count(*) FROM feature_flags").Scan(&count); err != nil {
+			return fmt.Errorf("Error checking rows %s", err)
+		}
+
+		if count != 0 {
+			return fmt.Errorf("Rows still exist after destroy")
+		}
+	}
+
+	return nil
+}
+
+// testAccCheckPostgresqlRowValue checks the enabled column of the flag, an empty value means the row should not exist.
+func testAccCheckPostgresqlRowValue(database, name, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, database)
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		var enabled string
+		err = txn.QueryRow("SELECT enabled::text FROM feature_flags WHERE name = $1", name).Scan(&enabled)
+		switch {
+		case err == sql.ErrNoRows:
+			if expected != "" {
+				return fmt.Errorf("row %s not found", name)
+			}
+			return nil
+		case err != nil:
+			return fmt.Errorf("Error reading row %s: %s", name, err)
+		}
+
+		if enabled != expected {
+			return fmt.Errorf("expected row %s to have enabled = %s, got %s", name, expected, enabled)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,370 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	ruleNameAttr     = "name"
+	ruleDatabaseAttr = "database"
+	ruleSchemaAttr   = "schema"
+	ruleTableAttr    = "table"
+	ruleEventAttr    = "event"
+	ruleWhereAttr    = "where"
+	ruleInsteadAttr  = "instead"
+	ruleActionsAttr  = "actions"
+)
+
+var (
+	allowedRuleEvents = []string{"SELECT", "INSERT", "UPDATE", "DELETE"}
+
+	// Values of pg_rewrite.ev_type
+	ruleEventTypes = map[string]string{
+		"1": "SELECT",
+		"2": "UPDATE",
+		"3": "INSERT",
+		"4": "DELETE",
+	}
+
+	// ruleDefinitionRegexp parses the condition and the actions from pg_get_ruledef.
+	ruleDefinitionRegexp = regexp.MustCompile(`(?s)\sTO\s+\S+(?:\s+WHERE\s+(.*?))?\s+DO\s+(?:INSTEAD\s+|ALSO\s+)?(.*?);?\s*$`)
+)
+
+func resourcePostgreSQLRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLRuleCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLRuleExists, resourcePostgreSQLRuleRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLRuleUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLRuleDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			ruleNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the rule",
+			},
+			ruleDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the rule is located",
+			},
+			ruleSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the table of the rule",
+			},
+			ruleTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The table (or view) of the rule",
+			},
+			ruleEventAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(allowedRuleEvents, false),
+				Description:  "The event of the rule (any of: " + strings.Join(allowedRuleEvents, ", ") + ")",
+			},
+			ruleWhereAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The condition of the rule, which can refer to the NEW and OLD relations",
+			},
+			ruleInsteadAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the actions are executed instead of the original command",
+			},
+			ruleActionsAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The commands executed by the rule. The rule does nothing if empty",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLRuleCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := createRule(txn, d, false); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating rule: %w", err)
+	}
+
+	d.SetId(generateRuleID(d, database))
+
+	return resourcePostgreSQLRuleReadImpl(db, d)
+}
+
+func resourcePostgreSQLRuleExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, ruleSchema, ruleTable, ruleName, err := getDBRuleName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	query := "SELECT TRUE FROM pg_catalog.pg_rules WHERE schemaname = $1 AND tablename = $2 AND rulename = $3"
+	err = txn.QueryRow(query, ruleSchema, ruleTable, ruleName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLRuleRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLRuleReadImpl(db, d)
+}
+
+func resourcePostgreSQLRuleReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, ruleSchema, ruleTable, ruleName, err := getDBRuleName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var eventType, definition string
+	var instead bool
+
+	query := `SELECT r.ev_type, r.is_instead, pr.definition ` +
+		`FROM pg_catalog.pg_rules pr ` +
+		`JOIN pg_catalog.pg_namespace n ON n.nspname = pr.schemaname ` +
+		`JOIN pg_catalog.pg_class c ON c.relnamespace = n.oid AND c.relname = pr.tablename ` +
+		`JOIN pg_catalog.pg_rewrite r ON r.ev_class = c.oid AND r.rulename = pr.rulename ` +
+		`WHERE pr.schemaname = $1 AND pr.tablename = $2 AND pr.rulename = $3`
+	err = txn.QueryRow(query, ruleSchema, ruleTable, ruleName).Scan(&eventType, &instead, &definition)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL rule (%s) on %s.%s not found for database %s", ruleName, ruleSchema, ruleTable, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading rule: %w", err)
+	}
+
+	// As PostgreSQL normalizes the condition and the actions, they are only read from
+	// the definition when importing.
+	if d.Get(ruleNameAttr).(string) == "" {
+		where, actions := parseRuleDefinition(definition)
+		d.Set(ruleWhereAttr, where)
+		d.Set(ruleActionsAttr, actions)
+	}
+
+	d.Set(ruleNameAttr, ruleName)
+	d.Set(ruleDatabaseAttr, database)
+	d.Set(ruleSchemaAttr, ruleSchema)
+	d.Set(ruleTableAttr, ruleTable)
+	d.Set(ruleEventAttr, ruleEventTypes[eventType])
+	d.Set(ruleInsteadAttr, instead)
+
+	return nil
+}
+
+func resourcePostgreSQLRuleUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChanges(ruleEventAttr, ruleWhereAttr, ruleInsteadAttr, ruleActionsAttr) {
+		if err := createRule(txn, d, true); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating rule: %w", err)
+	}
+
+	return resourcePostgreSQLRuleReadImpl(db, d)
+}
+
+func resourcePostgreSQLRuleDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf(
+		"DROP RULE %s ON %s",
+		pq.QuoteIdentifier(d.Get(ruleNameAttr).(string)), getRuleTableQualifiedName(d),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop rule %s: %w", d.Get(ruleNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting rule: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// createRule creates the rule, or replaces it if replace is true (all the attributes except
+// the name and the table can be changed by CREATE OR REPLACE RULE).
+func createRule(txn *sql.Tx, d *schema.ResourceData, replace bool) error {
+	b := bytes.NewBufferString("CREATE ")
+	if replace {
+		fmt.Fprint(b, "OR REPLACE ")
+	}
+	fmt.Fprint(b, "RULE ", pq.QuoteIdentifier(d.Get(ruleNameAttr).(string)))
+	fmt.Fprint(b, " AS ON ", d.Get(ruleEventAttr).(string), " TO ", getRuleTableQualifiedName(d))
+
+	if where := d.Get(ruleWhereAttr).(string); where != "" {
+		fmt.Fprint(b, " WHERE ", where)
+	}
+
+	fmt.Fprint(b, " DO ")
+	if d.Get(ruleInsteadAttr).(bool) {
+		fmt.Fprint(b, "INSTEAD ")
+	}
+
+	actions := []string{}
+	for _, action := range d.Get(ruleActionsAttr).([]interface{}) {
+		actions = append(actions, strings.TrimSuffix(strings.TrimSpace(action.(string)), ";"))
+	}
+
+	switch len(actions) {
+	case 0:
+		fmt.Fprint(b, "NOTHING")
+	case 1:
+		fmt.Fprint(b, actions[0])
+	default:
+		fmt.Fprintf(b, "(%s)", strings.Join(actions, "; "))
+	}
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create rule %s: %w", d.Get(ruleNameAttr).(string), err)
+	}
+
+	return nil
+}
+
+// parseRuleDefinition returns the condition and the actions of a rule definition (as returned by pg_get_ruledef).
+func parseRuleDefinition(definition string) (string, []string) {
+	matches := ruleDefinitionRegexp.FindStringSubmatch(definition)
+	if matches == nil {
+		return "", nil
+	}
+
+	where := strings.TrimSpace(matches[1])
+	body := strings.TrimSpace(matches[2])
+
+	if body == "NOTHING" {
+		return where, nil
+	}
+
+	if strings.HasPrefix(body, "(") && strings.HasSuffix(body, ")") {
+		body = strings.TrimSpace(body[1 : len(body)-1])
+	}
+
+	actions := []string{}
+	for _, action := range strings.Split(body, ";") {
+		if action = strings.TrimSpace(action); action != "" {
+			actions = append(actions, action)
+		}
+	}
+
+	return where, actions
+}
+
+func getRuleTableQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(ruleSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(ruleTableAttr).(string)),
+	)
+}
+
+func generateRuleID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(ruleSchemaAttr).(string),
+		d.Get(ruleTableAttr).(string),
+		d.Get(ruleNameAttr).(string),
+	}, ".")
+}
+
+// getDBRuleName returns the database, schema, table and name of the rule. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBRuleName(d *schema.ResourceData, client *Client) (string, string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	ruleSchema := d.Get(ruleSchemaAttr).(string)
+	ruleTable := d.Get(ruleTableAttr).(string)
+	ruleName := d.Get(ruleNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the rule, table, schema and database names.
+	if ruleName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 4 {
+			return "", "", "", "", fmt.Errorf(
+				"rule ID %s has not the expected format 'database.schema.table.rule': %v",
+				d.Id(), parsed,
+			)
+		}
+		database = parsed[0]
+		ruleSchema = parsed[1]
+		ruleTable = parsed[2]
+		ruleName = parsed[3]
+	}
+
+	return database, ruleSchema, ruleTable, ruleName, nil
+}
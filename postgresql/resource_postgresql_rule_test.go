@@ -0,0 +1,152 @@
+package postgresql
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseRuleDefinition(t *testing.T) {
+	cases := []struct {
+		definition string
+		where      string
+		actions    []string
+	}{
+		{
+			definition: "CREATE RULE users_insert AS\n    ON INSERT TO public.users_view DO INSTEAD  INSERT INTO users (id, email)\n  VALUES (new.id, new.email);",
+			actions:    []string{"INSERT INTO users (id, email)\n  VALUES (new.id, new.email)"},
+		},
+		{
+			definition: "CREATE RULE protect AS\n    ON DELETE TO public.users\n   WHERE (old.id = 1) DO INSTEAD NOTHING;",
+			where:      "(old.id = 1)",
+		},
+		{
+			definition: "CREATE RULE audit AS\n    ON UPDATE TO public.users DO ( INSERT INTO log (id) VALUES (new.id);\n INSERT INTO log2 (id) VALUES (old.id);\n);",
+			actions:    []string{"INSERT INTO log (id) VALUES (new.id)", "INSERT INTO log2 (id) VALUES (old.id)"},
+		},
+	}
+
+	for _, c := range cases {
+		where, actions := parseRuleDefinition(c.definition)
+		if where != c.where || !reflect.DeepEqual(actions, c.actions) {
+			t.Fatalf("Error matching output and expected: %#v, %#v vs %#v, %#v", where, actions, c.where, c.actions)
+		}
+	}
+}
+
+func TestAccPostgresqlRule_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE users (id integer PRIMARY KEY, email text, deleted boolean DEFAULT false)")
+	dbExecute(t, config.connStr(dbName), "CREATE VIEW active_users AS SELECT id, email FROM users WHERE NOT deleted")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_rule" "test" {
+					name     = "active_users_delete"
+					database = "%s"
+					table    = "active_users"
+					event    = "DELETE"
+					instead  = true
+					actions  = ["UPDATE users SET deleted = true WHERE id = OLD.id"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRuleExists("postgresql_rule.test"),
+					resource.TestCheckResourceAttr("postgresql_rule.test", "id", fmt.Sprintf("%s.public.active_users.active_users_delete", dbName)),
+					resource.TestCheckResourceAttr("postgresql_rule.test", "event", "DELETE"),
+					resource.TestCheckResourceAttr("postgresql_rule.test", "instead", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_rule" "test" {
+					name     = "active_users_delete"
+					database = "%s"
+					table    = "active_users"
+					event    = "DELETE"
+					where    = "OLD.id <> 1"
+					instead  = true
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlRuleExists("postgresql_rule.test"),
+					resource.TestCheckResourceAttr("postgresql_rule.test", "actions.#", "0"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_rule.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{ruleWhereAttr, ruleActionsAttr},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlRuleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_rule" {
+			continue
+		}
+
+		exists, err := checkRuleExists(client, rs)
+		if err != nil {
+			return fmt.Errorf("Error checking rule %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Rule still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		exists, err := checkRuleExists(testAccProvider.Meta().(*Client), rs)
+		if err != nil {
+			return fmt.Errorf("Error checking rule %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Rule not found")
+		}
+
+		return nil
+	}
+}
+
+func checkRuleExists(client *Client, rs *terraform.ResourceState) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	return resourcePostgreSQLRuleExists(db, resourcePostgreSQLRule().Data(rs.Primary))
+}
@@ -31,14 +31,21 @@ const (
 
 func resourcePostgreSQLSchema() *schema.Resource {
 	return &schema.Resource{
-		Create: PGResourceFunc(resourcePostgreSQLSchemaCreate),
-		Read:   PGResourceFunc(resourcePostgreSQLSchemaRead),
-		Update: PGResourceFunc(resourcePostgreSQLSchemaUpdate),
-		Delete: PGResourceFunc(resourcePostgreSQLSchemaDelete),
-		Exists: PGResourceExistsFunc(resourcePostgreSQLSchemaExists),
+		CreateContext: PGResourceFunc(resourcePostgreSQLSchemaCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLSchemaExists, resourcePostgreSQLSchemaRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLSchemaUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLSchemaDelete),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStatePassthroughWithOID(readSchemaIDByOID),
 		},
+		// The OIDs of the schemas are unique in their database only.
+		Identity: oidIdentity(map[string]*schema.Schema{
+			schemaDatabaseAttr: {
+				Type:              schema.TypeString,
+				OptionalForImport: true,
+				Description:       "The database of the schema, the one of the provider by default",
+			},
+		}),
 
 		Schema: map[string]*schema.Schema{
 			schemaNameAttr: {
@@ -112,6 +119,7 @@ func resourcePostgreSQLSchema() *schema.Resource {
 					},
 				},
 			},
+			assumeRoleAttr: assumeRoleSchema(),
 		},
 	}
 }
@@ -142,7 +150,7 @@ func resourcePostgreSQLSchemaCreate(db *DBConnection, d *schema.ResourceData) er
 
 	}
 
-	if err := withRolesGranted(txn, rolesToGrant, func() error {
+	if err := db.withRolesGranted(txn, rolesToGrant, func() error {
 		return createSchema(db, txn, d)
 	}); err != nil {
 		return err
@@ -250,7 +258,7 @@ func resourcePostgreSQLSchemaDelete(db *DBConnection, d *schema.ResourceData) er
 
 	owner := d.Get("owner").(string)
 
-	if err = withRolesGranted(txn, []string{owner}, func() error {
+	if err = db.withRolesGranted(txn, []string{owner}, func() error {
 		dropMode := "RESTRICT"
 		if d.Get(schemaDropCascade).(bool) {
 			dropMode = "CASCADE"
@@ -308,6 +316,31 @@ func resourcePostgreSQLSchemaRead(db *DBConnection, d *schema.ResourceData) erro
 	return resourcePostgreSQLSchemaReadImpl(db, d)
 }
 
+// readSchemaIDByOID returns the ID of the schema with the OID and in the database of the identity.
+func readSchemaIDByOID(db *DBConnection, identity *schema.IdentityData) (string, error) {
+	database := db.client.databaseName
+	if v, ok := identity.GetOk(schemaDatabaseAttr); ok {
+		database = v.(string)
+	}
+	oid := identity.Get(identityOIDAttr).(int)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return "", err
+	}
+	defer deferredRollback(txn)
+
+	var schemaName string
+	err = txn.QueryRow("SELECT nspname FROM pg_catalog.pg_namespace WHERE oid=$1", oid).Scan(&schemaName)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", fmt.Errorf("schema with OID %d not found in database %s", oid, database)
+	case err != nil:
+		return "", fmt.Errorf("Error reading schema: %w", err)
+	}
+	return strings.Join([]string{database, schemaName}, "."), nil
+}
+
 func resourcePostgreSQLSchemaReadImpl(db *DBConnection, d *schema.ResourceData) error {
 	database, schemaName, err := getDBSchemaName(d, db.client.databaseName)
 	if err != nil {
@@ -321,8 +354,9 @@ func resourcePostgreSQLSchemaReadImpl(db *DBConnection, d *schema.ResourceData)
 	defer deferredRollback(txn)
 
 	var schemaOwner string
+	var schemaOID int
 	var schemaACLs []string
-	err = txn.QueryRow("SELECT pg_catalog.pg_get_userbyid(n.nspowner), COALESCE(n.nspacl, '{}'::aclitem[])::TEXT[] FROM pg_catalog.pg_namespace n WHERE n.nspname=$1", schemaName).Scan(&schemaOwner, pq.Array(&schemaACLs))
+	err = txn.QueryRow("SELECT n.oid, pg_catalog.pg_get_userbyid(n.nspowner), COALESCE(n.nspacl, '{}'::aclitem[])::TEXT[] FROM pg_catalog.pg_namespace n WHERE n.nspname=$1", schemaName).Scan(&schemaOID, &schemaOwner, pq.Array(&schemaACLs))
 	switch {
 	case err == sql.ErrNoRows:
 		log.Printf("[WARN] PostgreSQL schema (%s) not found in database %s", schemaName, database)
@@ -359,7 +393,10 @@ func resourcePostgreSQLSchemaReadImpl(db *DBConnection, d *schema.ResourceData)
 		d.Set(schemaDatabaseAttr, database)
 		d.SetId(generateSchemaID(d, database))
 
-		return nil
+		return setIdentity(d, map[string]interface{}{
+			schemaDatabaseAttr: database,
+			identityOIDAttr:    schemaOID,
+		})
 	}
 }
 
@@ -380,7 +417,7 @@ func resourcePostgreSQLSchemaUpdate(db *DBConnection, d *schema.ResourceData) er
 		return err
 	}
 
-	if err := setSchemaPolicy(txn, d); err != nil {
+	if err := setSchemaPolicy(db, txn, d); err != nil {
 		return err
 	}
 
@@ -432,7 +469,7 @@ func setSchemaOwner(txn *sql.Tx, d *schema.ResourceData) error {
 	return nil
 }
 
-func setSchemaPolicy(txn *sql.Tx, d *schema.ResourceData) error {
+func setSchemaPolicy(db *DBConnection, txn *sql.Tx, d *schema.ResourceData) error {
 	if !d.HasChange(schemaPolicyAttr) {
 		return nil
 	}
@@ -453,15 +490,13 @@ func setSchemaPolicy(txn *sql.Tx, d *schema.ResourceData) error {
 		// The PUBLIC role can not be DROP'ed, therefore we do not need
 		// to prevent revoking against it not existing.
 		if rolePolicy.Role != "" {
-			var foundUser bool
-			err := txn.QueryRow(`SELECT TRUE FROM pg_catalog.pg_roles WHERE rolname = $1`, rolePolicy.Role).Scan(&foundUser)
-			switch {
-			case err == sql.ErrNoRows:
-				// Don't execute this role's REVOKEs because the role
-				// was dropped first and therefore doesn't exist.
-			case err != nil:
+			// Don't execute this role's REVOKEs if the role
+			// was dropped first and therefore doesn't exist.
+			foundUser, err := roleExists(txn, rolePolicy.Role)
+			if err != nil {
 				return fmt.Errorf("Error reading schema: %w", err)
-			default:
+			}
+			if foundUser {
 				queries = append(queries, rolePolicy.Revokes(schemaName)...)
 			}
 		}
@@ -497,7 +532,7 @@ func setSchemaPolicy(txn *sql.Tx, d *schema.ResourceData) error {
 		rolesToGrant = append(rolesToGrant, owner)
 	}
 
-	return withRolesGranted(txn, rolesToGrant, func() error {
+	return db.withRolesGranted(txn, rolesToGrant, func() error {
 		for _, query := range queries {
 			if _, err := txn.Exec(query); err != nil {
 				return fmt.Errorf("Error updating schema DCL: %w", err)
@@ -48,6 +48,12 @@ func TestAccPostgresqlSchema_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("postgresql_schema.test3", "policy.1.role", "role_all_without_grant"),
 				),
 			},
+			{
+				ResourceName:            "postgresql_schema.test1",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{schemaIfNotExists, schemaDropCascade},
+			},
 		},
 	})
 }
@@ -0,0 +1,444 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	seqNameAttr      = "name"
+	seqSchemaAttr    = "schema"
+	seqDatabaseAttr  = "database"
+	seqDataTypeAttr  = "data_type"
+	seqStartAttr     = "start"
+	seqIncrementAttr = "increment"
+	seqMinValueAttr  = "min_value"
+	seqMaxValueAttr  = "max_value"
+	seqCacheAttr     = "cache"
+	seqCycleAttr     = "cycle"
+	seqOwnedByAttr   = "owned_by"
+)
+
+var allowedSequenceDataTypes = []string{"smallint", "integer", "bigint"}
+
+func resourcePostgreSQLSequence() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLSequenceCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLSequenceExists, resourcePostgreSQLSequenceRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLSequenceUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLSequenceDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: requireFeature(featureSequence, "postgresql_sequence resource", nil),
+
+		Schema: map[string]*schema.Schema{
+			seqNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the sequence",
+			},
+			seqSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the sequence is located",
+			},
+			seqDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the sequence is located",
+			},
+			seqDataTypeAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "bigint",
+				ValidateFunc: validation.StringInSlice(allowedSequenceDataTypes, false),
+				Description:  "The data type of the sequence (any of: " + strings.Join(allowedSequenceDataTypes, ", ") + ")",
+			},
+			seqStartAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The starting value of the sequence. Defaults to min_value for ascending sequences and max_value for descending ones",
+			},
+			seqIncrementAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntNotInSlice([]int{0}),
+				Description:  "The value added to the current sequence value to create a new value",
+			},
+			seqMinValueAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The minimum value of the sequence. Defaults to 1 for ascending sequences and the minimum value of the data type for descending ones",
+			},
+			seqMaxValueAttr: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum value of the sequence. Defaults to the maximum value of the data type for ascending sequences and -1 for descending ones",
+			},
+			seqCacheAttr: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "How many sequence numbers are to be preallocated and stored in memory for faster access",
+			},
+			seqCycleAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the sequence should wrap around when the max_value or min_value has been reached",
+			},
+			seqOwnedByAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The column (in the form schema.table.column) the sequence is associated with",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLSequenceCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSequence) {
+		return fmt.Errorf(
+			"postgresql_sequence resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE SEQUENCE ")
+	fmt.Fprint(b, getSequenceQualifiedName(d))
+	fmt.Fprint(b, " AS ", d.Get(seqDataTypeAttr).(string))
+	fmt.Fprint(b, " INCREMENT BY ", d.Get(seqIncrementAttr).(int))
+
+	// Zero is a valid value for these attributes so we check the raw config
+	// to know if they have been set.
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.GetAttr(seqMinValueAttr).IsNull() {
+		fmt.Fprint(b, " MINVALUE ", d.Get(seqMinValueAttr).(int))
+	}
+	if !rawConfig.GetAttr(seqMaxValueAttr).IsNull() {
+		fmt.Fprint(b, " MAXVALUE ", d.Get(seqMaxValueAttr).(int))
+	}
+	if !rawConfig.GetAttr(seqStartAttr).IsNull() {
+		fmt.Fprint(b, " START WITH ", d.Get(seqStartAttr).(int))
+	}
+
+	fmt.Fprint(b, " CACHE ", d.Get(seqCacheAttr).(int))
+
+	if d.Get(seqCycleAttr).(bool) {
+		fmt.Fprint(b, " CYCLE")
+	} else {
+		fmt.Fprint(b, " NO CYCLE")
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create sequence %s: %w", d.Get(seqNameAttr).(string), err)
+	}
+
+	if err := setSequenceOwnedBy(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating sequence: %w", err)
+	}
+
+	d.SetId(generateSequenceID(d, database))
+
+	return resourcePostgreSQLSequenceReadImpl(db, d)
+}
+
+func resourcePostgreSQLSequenceExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureSequence) {
+		return false, fmt.Errorf(
+			"postgresql_sequence resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, seqSchema, seqName, err := getDBSequenceName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return relationExists(txn, seqSchema, seqName, "S")
+}
+
+func resourcePostgreSQLSequenceRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSequence) {
+		return fmt.Errorf(
+			"postgresql_sequence resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLSequenceReadImpl(db, d)
+}
+
+func resourcePostgreSQLSequenceReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, seqSchema, seqName, err := getDBSequenceName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var dataType string
+	var start, increment, minValue, maxValue, cache int
+	var cycle bool
+
+	query := `SELECT data_type::text, start_value, increment_by, min_value, max_value, cache_size, cycle ` +
+		`FROM pg_catalog.pg_sequences ` +
+		`WHERE schemaname = $1 AND sequencename = $2`
+	err = txn.QueryRow(query, seqSchema, seqName).Scan(
+		&dataType, &start, &increment, &minValue, &maxValue, &cache, &cycle,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL sequence (%s.%s) not found for database %s", seqSchema, seqName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading sequence: %w", err)
+	}
+
+	ownedBy, err := getSequenceOwnedBy(txn, seqSchema, seqName)
+	if err != nil {
+		return err
+	}
+
+	d.Set(seqNameAttr, seqName)
+	d.Set(seqSchemaAttr, seqSchema)
+	d.Set(seqDatabaseAttr, database)
+	d.Set(seqDataTypeAttr, dataType)
+	d.Set(seqStartAttr, start)
+	d.Set(seqIncrementAttr, increment)
+	d.Set(seqMinValueAttr, minValue)
+	d.Set(seqMaxValueAttr, maxValue)
+	d.Set(seqCacheAttr, cache)
+	d.Set(seqCycleAttr, cycle)
+	d.Set(seqOwnedByAttr, ownedBy)
+
+	return nil
+}
+
+func resourcePostgreSQLSequenceUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSequence) {
+		return fmt.Errorf(
+			"postgresql_sequence resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// Only the changed parameters are altered, e.g.: changing the data type
+	// should not set the max value to the one of the previous type.
+	params := []string{}
+	if d.HasChange(seqDataTypeAttr) {
+		params = append(params, "AS "+d.Get(seqDataTypeAttr).(string))
+	}
+	if d.HasChange(seqIncrementAttr) {
+		params = append(params, fmt.Sprintf("INCREMENT BY %d", d.Get(seqIncrementAttr).(int)))
+	}
+	if d.HasChange(seqMinValueAttr) {
+		params = append(params, fmt.Sprintf("MINVALUE %d", d.Get(seqMinValueAttr).(int)))
+	}
+	if d.HasChange(seqMaxValueAttr) {
+		params = append(params, fmt.Sprintf("MAXVALUE %d", d.Get(seqMaxValueAttr).(int)))
+	}
+	if d.HasChange(seqStartAttr) {
+		params = append(params, fmt.Sprintf("START WITH %d", d.Get(seqStartAttr).(int)))
+	}
+	if d.HasChange(seqCacheAttr) {
+		params = append(params, fmt.Sprintf("CACHE %d", d.Get(seqCacheAttr).(int)))
+	}
+	if d.HasChange(seqCycleAttr) {
+		if d.Get(seqCycleAttr).(bool) {
+			params = append(params, "CYCLE")
+		} else {
+			params = append(params, "NO CYCLE")
+		}
+	}
+
+	if len(params) > 0 {
+		sql := fmt.Sprintf("ALTER SEQUENCE %s %s", getSequenceQualifiedName(d), strings.Join(params, " "))
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating sequence: %w", err)
+		}
+	}
+
+	if d.HasChange(seqOwnedByAttr) {
+		if err := setSequenceOwnedBy(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating sequence: %w", err)
+	}
+
+	return resourcePostgreSQLSequenceReadImpl(db, d)
+}
+
+func resourcePostgreSQLSequenceDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSequence) {
+		return fmt.Errorf(
+			"postgresql_sequence resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("DROP SEQUENCE %s", getSequenceQualifiedName(d))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop sequence %s: %w", d.Get(seqNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting sequence: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setSequenceOwnedBy(txn *sql.Tx, d *schema.ResourceData) error {
+	ownedBy := "NONE"
+	if v := d.Get(seqOwnedByAttr).(string); v != "" {
+		parsed := strings.Split(v, ".")
+		if len(parsed) != 3 {
+			return fmt.Errorf("owned_by %s has not the expected format 'schema.table.column'", v)
+		}
+		for i := range parsed {
+			parsed[i] = pq.QuoteIdentifier(parsed[i])
+		}
+		ownedBy = strings.Join(parsed, ".")
+	}
+
+	sql := fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s", getSequenceQualifiedName(d), ownedBy)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating sequence owned by: %w", err)
+	}
+
+	return nil
+}
+
+// getSequenceOwnedBy returns the column the sequence is associated with
+// (in the form schema.table.column) or an empty string.
+func getSequenceOwnedBy(txn *sql.Tx, seqSchema, seqName string) (string, error) {
+	var ownedBy string
+
+	query := `SELECT n.nspname || '.' || t.relname || '.' || a.attname ` +
+		`FROM pg_catalog.pg_depend d ` +
+		`JOIN pg_catalog.pg_class t ON t.oid = d.refobjid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace ` +
+		`JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid ` +
+		`WHERE d.classid = 'pg_catalog.pg_class'::regclass ` +
+		`AND d.refclassid = 'pg_catalog.pg_class'::regclass ` +
+		`AND d.deptype = 'a' ` +
+		`AND d.objid = to_regclass($1)`
+	err := txn.QueryRow(query, fmt.Sprintf("%s.%s", pq.QuoteIdentifier(seqSchema), pq.QuoteIdentifier(seqName))).Scan(&ownedBy)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("Error reading sequence owned by: %w", err)
+	}
+
+	return ownedBy, nil
+}
+
+func getSequenceQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(seqSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(seqNameAttr).(string)),
+	)
+}
+
+func generateSequenceID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(seqSchemaAttr).(string),
+		d.Get(seqNameAttr).(string),
+	}, ".")
+}
+
+// getDBSequenceName returns the database, schema and name of the sequence. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBSequenceName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	seqSchema := d.Get(seqSchemaAttr).(string)
+	seqName := d.Get(seqNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the sequence, schema and database names.
+	if seqName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("sequence ID %s has not the expected format 'database.schema.sequence': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		seqSchema = parsed[1]
+		seqName = parsed[2]
+	}
+
+	return database, seqSchema, seqName, nil
+}
@@ -0,0 +1,160 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlSequence_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_table (id bigint)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureSequence)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlSequenceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_sequence" "test" {
+					name     = "test_seq"
+					database = "%s"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlSequenceExists("postgresql_sequence.test"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "id", fmt.Sprintf("%s.public.test_seq", dbName)),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "data_type", "bigint"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "start", "1"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "increment", "1"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "min_value", "1"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "max_value", "9223372036854775807"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "cache", "1"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "cycle", "false"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "owned_by", ""),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_sequence" "test" {
+					name      = "test_seq"
+					database  = "%s"
+					data_type = "integer"
+					start     = 0
+					increment = 5
+					min_value = 0
+					max_value = 1000
+					cache     = 10
+					cycle     = true
+					owned_by  = "public.test_table.id"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlSequenceExists("postgresql_sequence.test"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "data_type", "integer"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "start", "0"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "increment", "5"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "min_value", "0"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "max_value", "1000"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "cache", "10"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "cycle", "true"),
+					resource.TestCheckResourceAttr("postgresql_sequence.test", "owned_by", "public.test_table.id"),
+				),
+			},
+			{
+				// Change the sequence outside of Terraform, the next plan should revert it.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "ALTER SEQUENCE test_seq INCREMENT BY 2")
+				},
+				Config: fmt.Sprintf(`
+				resource "postgresql_sequence" "test" {
+					name      = "test_seq"
+					database  = "%s"
+					data_type = "integer"
+					start     = 0
+					increment = 5
+					min_value = 0
+					max_value = 1000
+					cache     = 10
+					cycle     = true
+					owned_by  = "public.test_table.id"
+				}`, dbName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:      "postgresql_sequence.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlSequenceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_sequence" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[seqDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[seqSchemaAttr], rs.Primary.Attributes[seqNameAttr], "S")
+		if err != nil {
+			return fmt.Errorf("Error checking sequence %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Sequence still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlSequenceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[seqDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[seqSchemaAttr], rs.Primary.Attributes[seqNameAttr], "S")
+		if err != nil {
+			return fmt.Errorf("Error checking sequence %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Sequence not found")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	serverSettingNameAttr           = "name"
+	serverSettingValueAttr          = "value"
+	serverSettingPendingRestartAttr = "pending_restart"
+)
+
+func resourcePostgreSQLServerSetting() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLServerSettingCreate),
+		ReadContext:   PGReadFunc(resourcePostgreSQLServerSettingRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLServerSettingUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLServerSettingDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: requireFeature(featureAlterSystem, "postgresql_server_setting resource", nil),
+
+		Schema: map[string]*schema.Schema{
+			serverSettingNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the server parameter",
+			},
+			serverSettingValueAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The value of the server parameter",
+			},
+			serverSettingPendingRestartAttr: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If the new value of the parameter will only be applied after a restart of the server",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLServerSettingCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_server_setting resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	if err := setServerSetting(db, d); err != nil {
+		return fmt.Errorf("Error creating server setting: %w", err)
+	}
+
+	d.SetId(d.Get(serverSettingNameAttr).(string))
+
+	return resourcePostgreSQLServerSettingReadImpl(db, d)
+}
+
+func resourcePostgreSQLServerSettingRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureAlterSystem) {
+		return fmt.Errorf(
+			"postgresql_server_setting resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLServerSettingReadImpl(db, d)
+}
+
+func resourcePostgreSQLServerSettingReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Id()
+
+	// The value is read from postgresql.auto.conf (where ALTER SYSTEM writes it) rather than from pg_settings,
+	// as pg_settings shows the value in its base unit and only once it is applied.
+	var value sql.NullString
+	var pendingRestart bool
+	query := `SELECT f.setting, s.pending_restart FROM pg_catalog.pg_settings s ` +
+		`LEFT JOIN LATERAL (` +
+		`SELECT setting FROM pg_catalog.pg_file_settings ` +
+		`WHERE name = s.name AND sourcefile LIKE '%/postgresql.auto.conf' ` +
+		`ORDER BY seqno DESC LIMIT 1` +
+		`) f ON TRUE ` +
+		`WHERE s.name = lower($1)`
+	err := db.QueryRow(query, name).Scan(&value, &pendingRestart)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL server parameter (%s) not found", name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading server setting: %w", err)
+	}
+
+	if !value.Valid {
+		log.Printf("[WARN] PostgreSQL server parameter (%s) is not set with ALTER SYSTEM", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(serverSettingNameAttr, name)
+	d.Set(serverSettingValueAttr, value.String)
+	d.Set(serverSettingPendingRestartAttr, pendingRestart)
+
+	return nil
+}
+
+func resourcePostgreSQLServerSettingUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if d.HasChange(serverSettingValueAttr) {
+		if err := setServerSetting(db, d); err != nil {
+			return fmt.Errorf("Error updating server setting: %w", err)
+		}
+	}
+
+	return resourcePostgreSQLServerSettingReadImpl(db, d)
+}
+
+func resourcePostgreSQLServerSettingDelete(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(serverSettingNameAttr).(string)
+
+	// ALTER SYSTEM cannot be executed inside a transaction block.
+	sql := fmt.Sprintf("ALTER SYSTEM RESET %s", pq.QuoteIdentifier(name))
+	if err := db.execOutsideTransaction(db.client.ctx, sql); err != nil {
+		return fmt.Errorf("could not reset server parameter %s: %w", name, err)
+	}
+
+	if err := reloadServerConfiguration(db); err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setServerSetting(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(serverSettingNameAttr).(string)
+
+	// ALTER SYSTEM cannot be executed inside a transaction block.
+	sql := fmt.Sprintf(
+		"ALTER SYSTEM SET %s = '%s'",
+		pq.QuoteIdentifier(name), pqQuoteLiteral(d.Get(serverSettingValueAttr).(string)),
+	)
+	if err := db.execOutsideTransaction(db.client.ctx, sql); err != nil {
+		return fmt.Errorf("could not set server parameter %s: %w", name, err)
+	}
+
+	return reloadServerConfiguration(db)
+}
+
+// reloadServerConfiguration signals the server to reload its configuration files,
+// so the parameters which do not require a restart are applied.
+func reloadServerConfiguration(db *DBConnection) error {
+	if _, err := db.Exec("SELECT pg_catalog.pg_reload_conf()"); err != nil {
+		return fmt.Errorf("could not reload server configuration: %w", err)
+	}
+
+	return nil
+}
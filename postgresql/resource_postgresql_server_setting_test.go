@@ -0,0 +1,121 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlServerSetting_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+			testCheckCompatibleVersion(t, featureAlterSystem)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlServerSettingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "postgresql_server_setting" "test" {
+					name  = "log_min_duration_statement"
+					value = "250ms"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlServerSettingExists("postgresql_server_setting.test", "250ms"),
+					resource.TestCheckResourceAttr("postgresql_server_setting.test", "id", "log_min_duration_statement"),
+					resource.TestCheckResourceAttr("postgresql_server_setting.test", "pending_restart", "false"),
+				),
+			},
+			{
+				Config: `
+				resource "postgresql_server_setting" "test" {
+					name  = "log_min_duration_statement"
+					value = "1s"
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlServerSettingExists("postgresql_server_setting.test", "1s"),
+					resource.TestCheckResourceAttr("postgresql_server_setting.test", "value", "1s"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_server_setting.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlServerSettingDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_server_setting" {
+			continue
+		}
+
+		value, err := getTestServerSetting(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if value.Valid {
+			return fmt.Errorf("Server setting still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlServerSettingExists(n, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		value, err := getTestServerSetting(testAccProvider.Meta().(*Client), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if value.String != expected {
+			return fmt.Errorf("Expected server setting %q, got %q", expected, value.String)
+		}
+
+		return nil
+	}
+}
+
+func getTestServerSetting(client *Client, name string) (sql.NullString, error) {
+	var value sql.NullString
+
+	db, err := client.Connect()
+	if err != nil {
+		return value, err
+	}
+
+	query := `SELECT setting FROM pg_catalog.pg_file_settings ` +
+		`WHERE name = $1 AND sourcefile LIKE '%/postgresql.auto.conf' ORDER BY seqno DESC LIMIT 1`
+	err = db.QueryRow(query, name).Scan(&value)
+	switch {
+	case err == sql.ErrNoRows:
+		return value, nil
+	case err != nil:
+		return value, fmt.Errorf("Error checking server setting %s", err)
+	}
+
+	return value, nil
+}
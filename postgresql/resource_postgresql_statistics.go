@@ -0,0 +1,311 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	statisticsNameAttr     = "name"
+	statisticsSchemaAttr   = "schema"
+	statisticsDatabaseAttr = "database"
+	statisticsTableAttr    = "table"
+	statisticsColumnsAttr  = "columns"
+	statisticsKindsAttr    = "kinds"
+)
+
+var (
+	allowedStatisticsKinds = []string{"ndistinct", "dependencies", "mcv"}
+
+	// Values of pg_statistic_ext.stxkind
+	statisticsKindCodes = map[string]string{
+		"d": "ndistinct",
+		"f": "dependencies",
+		"m": "mcv",
+	}
+)
+
+func resourcePostgreSQLStatistics() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLStatisticsCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLStatisticsExists, resourcePostgreSQLStatisticsRead),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLStatisticsDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: requireFeature(featureStatistics, "postgresql_statistics resource", nil),
+
+		Schema: map[string]*schema.Schema{
+			statisticsNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the statistics object",
+			},
+			statisticsSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the statistics object and of its table",
+			},
+			statisticsDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the statistics object is located",
+			},
+			statisticsTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The table on which the statistics are computed",
+			},
+			statisticsColumnsAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    2,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The columns covered by the statistics",
+			},
+			statisticsKindsAttr: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(allowedStatisticsKinds, false),
+				},
+				Set:         schema.HashString,
+				Description: "The kinds of statistics to compute (any of: " + strings.Join(allowedStatisticsKinds, ", ") + "). All the kinds supported by the server are computed by default",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLStatisticsCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureStatistics) {
+		return fmt.Errorf(
+			"postgresql_statistics resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(statisticsNameAttr).(string)
+
+	b := bytes.NewBufferString("CREATE STATISTICS ")
+	fmt.Fprint(b, getStatisticsQualifiedName(d))
+
+	if v, ok := d.GetOk(statisticsKindsAttr); ok {
+		kinds := []string{}
+		for _, kind := range v.(*schema.Set).List() {
+			kinds = append(kinds, kind.(string))
+		}
+		sort.Strings(kinds)
+		fmt.Fprintf(b, " (%s)", strings.Join(kinds, ", "))
+	}
+
+	columns := []string{}
+	for _, column := range d.Get(statisticsColumnsAttr).([]interface{}) {
+		columns = append(columns, pq.QuoteIdentifier(column.(string)))
+	}
+	fmt.Fprint(b, " ON ", strings.Join(columns, ", "))
+
+	fmt.Fprintf(
+		b, " FROM %s.%s",
+		pq.QuoteIdentifier(d.Get(statisticsSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(statisticsTableAttr).(string)),
+	)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create statistics %s: %w", name, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating statistics: %w", err)
+	}
+
+	d.SetId(generateStatisticsID(d, database))
+
+	return resourcePostgreSQLStatisticsReadImpl(db, d)
+}
+
+func resourcePostgreSQLStatisticsExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureStatistics) {
+		return false, fmt.Errorf(
+			"postgresql_statistics resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, statisticsSchema, statisticsName, err := getDBStatisticsName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	query := `SELECT TRUE FROM pg_catalog.pg_statistic_ext s ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = s.stxnamespace ` +
+		`WHERE n.nspname = $1 AND s.stxname = $2`
+	err = txn.QueryRow(query, statisticsSchema, statisticsName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLStatisticsRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureStatistics) {
+		return fmt.Errorf(
+			"postgresql_statistics resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLStatisticsReadImpl(db, d)
+}
+
+func resourcePostgreSQLStatisticsReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, statisticsSchema, statisticsName, err := getDBStatisticsName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var table string
+	var kindCodes, columns pq.StringArray
+
+	query := `SELECT c.relname, s.stxkind::text[], ` +
+		`ARRAY(SELECT a.attname FROM unnest(s.stxkeys) WITH ORDINALITY AS k(attnum, ord) ` +
+		`JOIN pg_catalog.pg_attribute a ON a.attrelid = s.stxrelid AND a.attnum = k.attnum ORDER BY k.ord) ` +
+		`FROM pg_catalog.pg_statistic_ext s ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = s.stxnamespace ` +
+		`JOIN pg_catalog.pg_class c ON c.oid = s.stxrelid ` +
+		`WHERE n.nspname = $1 AND s.stxname = $2`
+	err = txn.QueryRow(query, statisticsSchema, statisticsName).Scan(&table, &kindCodes, &columns)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL statistics (%s.%s) not found for database %s", statisticsSchema, statisticsName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading statistics: %w", err)
+	}
+
+	kinds := []string{}
+	for _, code := range kindCodes {
+		// Statistics on expressions ('e') are implicit.
+		if kind, ok := statisticsKindCodes[code]; ok {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	d.Set(statisticsNameAttr, statisticsName)
+	d.Set(statisticsSchemaAttr, statisticsSchema)
+	d.Set(statisticsDatabaseAttr, database)
+	d.Set(statisticsTableAttr, table)
+	d.Set(statisticsColumnsAttr, columns)
+	d.Set(statisticsKindsAttr, stringSliceToSet(kinds))
+
+	return nil
+}
+
+func resourcePostgreSQLStatisticsDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("DROP STATISTICS %s", getStatisticsQualifiedName(d))
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop statistics %s: %w", d.Get(statisticsNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting statistics: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getStatisticsQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(statisticsSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(statisticsNameAttr).(string)),
+	)
+}
+
+func generateStatisticsID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(statisticsSchemaAttr).(string),
+		d.Get(statisticsNameAttr).(string),
+	}, ".")
+}
+
+// getDBStatisticsName returns the database, schema and name of the statistics object. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBStatisticsName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	statisticsSchema := d.Get(statisticsSchemaAttr).(string)
+	statisticsName := d.Get(statisticsNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the statistics, schema and database names.
+	if statisticsName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("statistics ID %s has not the expected format 'database.schema.statistics': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		statisticsSchema = parsed[1]
+		statisticsName = parsed[2]
+	}
+
+	return database, statisticsSchema, statisticsName, nil
+}
@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlStatistics_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE addresses (id integer, city text, zip text, country text)")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureStatistics)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlStatisticsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_statistics" "test" {
+					name     = "addresses_city_zip"
+					database = "%s"
+					table    = "addresses"
+					columns  = ["city", "zip"]
+					kinds    = ["ndistinct", "dependencies"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlStatisticsExists("postgresql_statistics.test"),
+					resource.TestCheckResourceAttr("postgresql_statistics.test", "id", fmt.Sprintf("%s.public.addresses_city_zip", dbName)),
+					resource.TestCheckResourceAttr("postgresql_statistics.test", "kinds.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_statistics.test", "columns.0", "city"),
+					resource.TestCheckResourceAttr("postgresql_statistics.test", "columns.1", "zip"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_statistics" "test" {
+					name     = "addresses_city_zip"
+					database = "%s"
+					table    = "addresses"
+					columns  = ["city", "zip", "country"]
+					kinds    = ["ndistinct"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlStatisticsExists("postgresql_statistics.test"),
+					resource.TestCheckResourceAttr("postgresql_statistics.test", "kinds.#", "1"),
+					resource.TestCheckResourceAttr("postgresql_statistics.test", "columns.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_statistics.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlStatisticsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_statistics" {
+			continue
+		}
+
+		exists, err := checkStatisticsExists(client, rs)
+		if err != nil {
+			return fmt.Errorf("Error checking statistics %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Statistics still exist after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlStatisticsExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		exists, err := checkStatisticsExists(testAccProvider.Meta().(*Client), rs)
+		if err != nil {
+			return fmt.Errorf("Error checking statistics %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Statistics not found")
+		}
+
+		return nil
+	}
+}
+
+func checkStatisticsExists(client *Client, rs *terraform.ResourceState) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	return resourcePostgreSQLStatisticsExists(db, resourcePostgreSQLStatistics().Data(rs.Primary))
+}
@@ -0,0 +1,404 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	subNameAttr         = "name"
+	subDatabaseAttr     = "database"
+	subConnInfoAttr     = "conninfo"
+	subPublicationsAttr = "publications"
+	subCreateSlotAttr   = "create_slot"
+	subSlotNameAttr     = "slot_name"
+	subEnabledAttr      = "enabled"
+	subRetainSlotAttr   = "retain_slot"
+)
+
+func resourcePostgreSQLSubscription() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLSubscriptionCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLSubscriptionExists, resourcePostgreSQLSubscriptionRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLSubscriptionUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLSubscriptionDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: requireFeature(featureSubscription, "postgresql_subscription resource", nil),
+
+		Schema: map[string]*schema.Schema{
+			subNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the subscription",
+			},
+			subDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Sets the database to add the subscription to",
+			},
+			subConnInfoAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The connection string to the publisher",
+			},
+			subPublicationsAttr: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				MinItems:    1,
+				Description: "Names of the publications on the publisher to subscribe to",
+			},
+			subCreateSlotAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "Specifies whether the command should create the replication slot on the publisher",
+			},
+			subSlotNameAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Name of the replication slot to use. The default behavior is to use the name of the subscription for the slot name",
+			},
+			subEnabledAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Specifies whether the subscription should be actively replicating",
+			},
+			subRetainSlotAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the replication slot on the publisher will not be dropped with the subscription",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLSubscriptionCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSubscription) {
+		return fmt.Errorf(
+			"postgresql_subscription resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	subName := d.Get(subNameAttr).(string)
+	databaseName := getDatabase(d, db.client.databaseName)
+
+	b := bytes.NewBufferString("CREATE SUBSCRIPTION ")
+	fmt.Fprint(b, pq.QuoteIdentifier(subName))
+	fmt.Fprintf(b, " CONNECTION '%s'", pqQuoteLiteral(d.Get(subConnInfoAttr).(string)))
+	fmt.Fprint(b, " PUBLICATION ", setToPgIdentListWithoutSchema(d.Get(subPublicationsAttr).(*schema.Set)))
+
+	params := []string{
+		fmt.Sprintf("create_slot = %t", d.Get(subCreateSlotAttr).(bool)),
+		fmt.Sprintf("enabled = %t", d.Get(subEnabledAttr).(bool)),
+	}
+	if v, ok := d.GetOk(subSlotNameAttr); ok {
+		params = append(params, fmt.Sprintf("slot_name = '%s'", pqQuoteLiteral(v.(string))))
+	}
+	fmt.Fprintf(b, " WITH (%s)", strings.Join(params, ", "))
+
+	conn, err := connectToDatabase(db.client, databaseName)
+	if err != nil {
+		return err
+	}
+
+	// CREATE SUBSCRIPTION cannot be executed inside a transaction block
+	// if it creates the replication slot.
+	if d.Get(subCreateSlotAttr).(bool) {
+		err = conn.execOutsideTransaction(conn.client.ctx, b.String())
+	} else {
+		_, err = conn.Exec(b.String())
+	}
+	if err != nil {
+		return fmt.Errorf("could not create subscription %s: %w", subName, err)
+	}
+
+	d.SetId(generateSubscriptionID(d, databaseName))
+
+	return resourcePostgreSQLSubscriptionReadImpl(db, d)
+}
+
+func resourcePostgreSQLSubscriptionExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureSubscription) {
+		return false, fmt.Errorf(
+			"postgresql_subscription resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, subName, err := getDBSubscriptionName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	query := `SELECT subname FROM pg_catalog.pg_subscription ` +
+		`WHERE subname = $1 AND subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = $2)`
+	err = txn.QueryRow(query, subName, database).Scan(&subName)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLSubscriptionRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSubscription) {
+		return fmt.Errorf(
+			"postgresql_subscription resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLSubscriptionReadImpl(db, d)
+}
+
+func resourcePostgreSQLSubscriptionReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, subName, err := getDBSubscriptionName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var enabled bool
+	var slotName sql.NullString
+	var publications pq.ByteaArray
+
+	// The connection string is not read back as it's only visible to superusers
+	// and it can contain a password.
+	query := `SELECT subenabled, subslotname, subpublication ` +
+		`FROM pg_catalog.pg_subscription ` +
+		`WHERE subname = $1 AND subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = $2)`
+	err = txn.QueryRow(query, subName, database).Scan(&enabled, &slotName, &publications)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL subscription (%s) not found for database %s", subName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading subscription: %w", err)
+	}
+
+	d.Set(subNameAttr, subName)
+	d.Set(subDatabaseAttr, database)
+	d.Set(subEnabledAttr, enabled)
+	d.Set(subPublicationsAttr, pgArrayToSet(publications))
+	if slotName.Valid {
+		d.Set(subSlotNameAttr, slotName.String)
+	}
+	d.SetId(generateSubscriptionID(d, database))
+
+	return nil
+}
+
+func resourcePostgreSQLSubscriptionUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSubscription) {
+		return fmt.Errorf(
+			"postgresql_subscription resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	conn, err := connectToDatabase(db.client, database)
+	if err != nil {
+		return err
+	}
+
+	if err := setSubscriptionConnInfo(conn, d); err != nil {
+		return err
+	}
+
+	if err := setSubscriptionPublications(conn, d); err != nil {
+		return err
+	}
+
+	if err := setSubscriptionEnabled(conn, d); err != nil {
+		return err
+	}
+
+	return resourcePostgreSQLSubscriptionReadImpl(db, d)
+}
+
+func resourcePostgreSQLSubscriptionDelete(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureSubscription) {
+		return fmt.Errorf(
+			"postgresql_subscription resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	subName := d.Get(subNameAttr).(string)
+	database := getDatabase(d, db.client.databaseName)
+
+	conn, err := connectToDatabase(db.client, database)
+	if err != nil {
+		return err
+	}
+
+	queries := []string{}
+	if d.Get(subRetainSlotAttr).(bool) {
+		// Dissociate the subscription from its replication slot,
+		// so the slot on the publisher is kept when dropping the subscription.
+		queries = append(queries,
+			fmt.Sprintf("ALTER SUBSCRIPTION %s DISABLE", pq.QuoteIdentifier(subName)),
+			fmt.Sprintf("ALTER SUBSCRIPTION %s SET (slot_name = NONE)", pq.QuoteIdentifier(subName)),
+		)
+	}
+	for _, query := range queries {
+		if _, err := conn.Exec(query); err != nil {
+			return fmt.Errorf("could not drop subscription %s: %w", subName, err)
+		}
+	}
+
+	// DROP SUBSCRIPTION cannot be executed inside a transaction block
+	// if the subscription is associated with a replication slot.
+	sql := fmt.Sprintf("DROP SUBSCRIPTION %s", pq.QuoteIdentifier(subName))
+	if d.Get(subRetainSlotAttr).(bool) {
+		_, err = conn.Exec(sql)
+	} else {
+		err = conn.execOutsideTransaction(conn.client.ctx, sql)
+	}
+	if err != nil {
+		return fmt.Errorf("could not drop subscription %s: %w", subName, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setSubscriptionConnInfo(db QueryAble, d *schema.ResourceData) error {
+	if !d.HasChange(subConnInfoAttr) {
+		return nil
+	}
+
+	subName := d.Get(subNameAttr).(string)
+	sql := fmt.Sprintf(
+		"ALTER SUBSCRIPTION %s CONNECTION '%s'",
+		pq.QuoteIdentifier(subName), pqQuoteLiteral(d.Get(subConnInfoAttr).(string)),
+	)
+	if _, err := db.Exec(sql); err != nil {
+		// Don't wrap the query in the error as it contains the connection string.
+		return fmt.Errorf("Error updating subscription CONNECTION: %w", err)
+	}
+
+	return nil
+}
+
+func setSubscriptionPublications(db *DBConnection, d *schema.ResourceData) error {
+	if !d.HasChange(subPublicationsAttr) {
+		return nil
+	}
+
+	subName := d.Get(subNameAttr).(string)
+	sql := fmt.Sprintf(
+		"ALTER SUBSCRIPTION %s SET PUBLICATION %s",
+		pq.QuoteIdentifier(subName), setToPgIdentListWithoutSchema(d.Get(subPublicationsAttr).(*schema.Set)),
+	)
+	// A disabled subscription cannot be refreshed, and the refresh
+	// cannot be executed inside a transaction block.
+	var err error
+	if d.Get(subEnabledAttr).(bool) {
+		err = db.execOutsideTransaction(db.client.ctx, sql)
+	} else {
+		_, err = db.Exec(sql + " WITH (refresh = false)")
+	}
+	if err != nil {
+		return fmt.Errorf("Error updating subscription PUBLICATION: %w", err)
+	}
+
+	return nil
+}
+
+func setSubscriptionEnabled(db QueryAble, d *schema.ResourceData) error {
+	if !d.HasChange(subEnabledAttr) {
+		return nil
+	}
+
+	tok := "DISABLE"
+	if d.Get(subEnabledAttr).(bool) {
+		tok = "ENABLE"
+	}
+
+	subName := d.Get(subNameAttr).(string)
+	sql := fmt.Sprintf("ALTER SUBSCRIPTION %s %s", pq.QuoteIdentifier(subName), tok)
+	if _, err := db.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating subscription %s: %w", tok, err)
+	}
+
+	return nil
+}
+
+func setToPgIdentListWithoutSchema(idents *schema.Set) string {
+	quotedIdents := make([]string, idents.Len())
+	for i, ident := range idents.List() {
+		quotedIdents[i] = pq.QuoteIdentifier(ident.(string))
+	}
+	return strings.Join(quotedIdents, ",")
+}
+
+func generateSubscriptionID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(subNameAttr).(string),
+	}, ".")
+}
+
+// getDBSubscriptionName returns database and subscription name. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBSubscriptionName(d *schema.ResourceData, client *Client) (string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	subName := d.Get(subNameAttr).(string)
+
+	// When importing, we have to parse the ID to find subscription and database names.
+	if subName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 2 {
+			return "", "", fmt.Errorf("subscription ID %s has not the expected format 'database.subscription': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		subName = parsed[1]
+	}
+	return database, subName, nil
+}
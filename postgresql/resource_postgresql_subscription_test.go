@@ -0,0 +1,156 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlSubscription_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	// The publication and the subscription are created in two different databases
+	// of the test server.
+	pubDBSuffix, pubTeardown := setupTestDatabase(t, true, false)
+	defer pubTeardown()
+	subDBSuffix, subTeardown := setupTestDatabase(t, true, false)
+	defer subTeardown()
+
+	pubDBName, _ := getTestDBNames(pubDBSuffix)
+	subDBName, _ := getTestDBNames(subDBSuffix)
+
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(pubDBName), "CREATE PUBLICATION test_publication")
+	dbExecute(t, config.connStr(pubDBName), "CREATE PUBLICATION test_publication_2")
+
+	connInfo := fmt.Sprintf(
+		"host=%s port=%d dbname=%s user=%s password=%s",
+		config.Host, config.Port, pubDBName, config.Username, config.Password,
+	)
+
+	// The replication slot is not created as the publisher is on the same cluster.
+	// (It would wait for the end of the transaction of the subscriber).
+	testAccPostgresqlSubscriptionConfig := func(publications string, enabled bool) string {
+		return fmt.Sprintf(`
+		resource "postgresql_subscription" "test" {
+			name         = "test_subscription"
+			database     = "%s"
+			conninfo     = "%s"
+			publications = %s
+			create_slot  = false
+			slot_name    = "test_subscription"
+			enabled      = %t
+			retain_slot  = true
+		}`, subDBName, connInfo, publications, enabled)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featureSubscription)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlSubscriptionConfig(`["test_publication"]`, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlSubscriptionExists("postgresql_subscription.test"),
+					resource.TestCheckResourceAttr("postgresql_subscription.test", "name", "test_subscription"),
+					resource.TestCheckResourceAttr("postgresql_subscription.test", "database", subDBName),
+					resource.TestCheckResourceAttr("postgresql_subscription.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("postgresql_subscription.test", "slot_name", "test_subscription"),
+					resource.TestCheckResourceAttr("postgresql_subscription.test", "publications.#", "1"),
+				),
+			},
+			{
+				Config: testAccPostgresqlSubscriptionConfig(`["test_publication", "test_publication_2"]`, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlSubscriptionExists("postgresql_subscription.test"),
+					resource.TestCheckResourceAttr("postgresql_subscription.test", "publications.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_subscription.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{subConnInfoAttr, subCreateSlotAttr, subRetainSlotAttr},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlSubscriptionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_subscription" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[subDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkSubscriptionExists(txn, rs.Primary.Attributes[subNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking subscription %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Subscription still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlSubscriptionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[subDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := checkSubscriptionExists(txn, rs.Primary.Attributes[subNameAttr])
+		if err != nil {
+			return fmt.Errorf("Error checking subscription %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Subscription not found")
+		}
+
+		return nil
+	}
+}
+
+func checkSubscriptionExists(txn *sql.Tx, subName string) (bool, error) {
+	var _rez bool
+	err := txn.QueryRow("SELECT TRUE FROM pg_catalog.pg_subscription WHERE subname = $1", subName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about subscription: %s", err)
+	}
+
+	return true, nil
+}
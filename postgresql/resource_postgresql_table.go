@@ -0,0 +1,624 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	tableNameAttr                    = "name"
+	tableSchemaAttr                  = "schema"
+	tableDatabaseAttr                = "database"
+	tableColumnAttr                  = "column"
+	tableColumnNameAttr              = "name"
+	tableColumnTypeAttr              = "type"
+	tableColumnNotNullAttr           = "not_null"
+	tableColumnDefaultAttr           = "default"
+	tablePrimaryKeyAttr              = "primary_key"
+	tableUniqueConstraintAttr        = "unique_constraint"
+	tableUniqueConstraintNameAttr    = "name"
+	tableUniqueConstraintColumnsAttr = "columns"
+	tablePartitionByAttr             = "partition_by"
+	tableDropCascadeAttr             = "drop_cascade"
+)
+
+func resourcePostgreSQLTable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLTableCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLTableExists, resourcePostgreSQLTableRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLTableUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLTableDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			tableNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the table",
+			},
+			tableSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the table is located",
+			},
+			tableDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the table is located",
+			},
+			tableColumnAttr: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The columns of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableColumnNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column",
+						},
+						tableColumnTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The data type of the column",
+						},
+						tableColumnNotNullAttr: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "If the column is not allowed to contain null values",
+						},
+						tableColumnDefaultAttr: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The default value expression of the column",
+						},
+					},
+				},
+			},
+			tablePrimaryKeyAttr: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The columns of the primary key of the table",
+			},
+			tableUniqueConstraintAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The unique constraints of the table",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tableUniqueConstraintNameAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the constraint",
+						},
+						tableUniqueConstraintColumnsAttr: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The columns of the constraint",
+						},
+					},
+				},
+			},
+			tablePartitionByAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The partitioning strategy and key of a partitioned table (e.g.: RANGE (created_at))",
+			},
+			tableDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the table (e.g.: views, foreign keys)",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLTableCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(tableNameAttr).(string)
+
+	definitions := []string{}
+	for _, column := range d.Get(tableColumnAttr).([]interface{}) {
+		definitions = append(definitions, getTableColumnDefinition(column.(map[string]interface{})))
+	}
+
+	if primaryKey := d.Get(tablePrimaryKeyAttr).([]interface{}); len(primaryKey) > 0 {
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", quoteIdentifierList(primaryKey)))
+	}
+
+	for _, constraint := range d.Get(tableUniqueConstraintAttr).(*schema.Set).List() {
+		definitions = append(definitions, getTableUniqueConstraintDefinition(constraint.(map[string]interface{})))
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf("CREATE TABLE %s (%s)", getTableQualifiedName(d), strings.Join(definitions, ", "))
+	if partitionBy := d.Get(tablePartitionByAttr).(string); partitionBy != "" {
+		sql += " PARTITION BY " + partitionBy
+	}
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not create table %s: %w", name, err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating table: %w", err)
+	}
+
+	d.SetId(generateTableID(d, database))
+
+	return resourcePostgreSQLTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLTableExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, tableSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return relationExists(txn, tableSchema, tableName, getTableRelKind(d))
+}
+
+func resourcePostgreSQLTableRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLTableReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, tableSchema, tableName, err := getDBTableName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var tableOID int
+	var partitionBy string
+	// pg_get_partkeydef doesn't exist before PostgreSQL 10 (e.g.: Greenplum 6).
+	partitionKey := "''"
+	if db.featureSupported(featurePartition) {
+		partitionKey = "CASE WHEN c.relkind = 'p' THEN pg_catalog.pg_get_partkeydef(c.oid) ELSE '' END"
+	}
+	query := `SELECT c.oid, ` + partitionKey + ` ` +
+		`FROM pg_catalog.pg_class c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p')`
+	err = txn.QueryRow(query, tableSchema, tableName).Scan(&tableOID, &partitionBy)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL table (%s.%s) not found for database %s", tableSchema, tableName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading table: %w", err)
+	}
+
+	primaryKey, uniqueConstraints, err := getTableConstraints(txn, tableOID)
+	if err != nil {
+		return err
+	}
+
+	columns, err := getTableColumns(txn, tableOID, primaryKey, d)
+	if err != nil {
+		return err
+	}
+
+	// As PostgreSQL normalizes the partition key, it is only read from the server when importing
+	// or when the table is not partitioned anymore.
+	if state := d.Get(tablePartitionByAttr).(string); state == "" || partitionBy == "" {
+		d.Set(tablePartitionByAttr, partitionBy)
+	}
+
+	d.Set(tableNameAttr, tableName)
+	d.Set(tableSchemaAttr, tableSchema)
+	d.Set(tableDatabaseAttr, database)
+	d.Set(tableColumnAttr, columns)
+	d.Set(tablePrimaryKeyAttr, primaryKey)
+	d.Set(tableUniqueConstraintAttr, uniqueConstraints)
+
+	return nil
+}
+
+func resourcePostgreSQLTableUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// Constraints are dropped before the columns are changed, and added after.
+	if err := dropTableConstraints(txn, d); err != nil {
+		return err
+	}
+
+	if err := setTableColumns(txn, d); err != nil {
+		return err
+	}
+
+	if err := addTableConstraints(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating table: %w", err)
+	}
+
+	return resourcePostgreSQLTableReadImpl(db, d)
+}
+
+func resourcePostgreSQLTableDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(tableDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP TABLE %s %s", getTableQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop table %s: %w", d.Get(tableNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting table: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// setTableColumns adds the new columns, drops the removed ones and alters the changed ones.
+// Columns are matched by name, the new columns are always added at the end of the table.
+func setTableColumns(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(tableColumnAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(tableColumnAttr)
+	oldColumns := getTableColumnsByName(oraw.([]interface{}))
+	newColumns := getTableColumnsByName(nraw.([]interface{}))
+
+	primaryKey := map[string]bool{}
+	for _, column := range d.Get(tablePrimaryKeyAttr).([]interface{}) {
+		primaryKey[column.(string)] = true
+	}
+
+	tableName := getTableQualifiedName(d)
+	statements := []string{}
+
+	for _, o := range oraw.([]interface{}) {
+		name := o.(map[string]interface{})[tableColumnNameAttr].(string)
+		if _, ok := newColumns[name]; !ok {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", tableName, pq.QuoteIdentifier(name)))
+		}
+	}
+
+	for _, n := range nraw.([]interface{}) {
+		newColumn := n.(map[string]interface{})
+		name := newColumn[tableColumnNameAttr].(string)
+		column := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", tableName, pq.QuoteIdentifier(name))
+
+		oldColumn, ok := oldColumns[name]
+		if !ok {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableName, getTableColumnDefinition(newColumn)))
+			continue
+		}
+
+		if oldColumn[tableColumnTypeAttr] != newColumn[tableColumnTypeAttr] {
+			statements = append(statements, fmt.Sprintf("%s TYPE %s", column, newColumn[tableColumnTypeAttr].(string)))
+		}
+
+		if oldColumn[tableColumnDefaultAttr] != newColumn[tableColumnDefaultAttr] {
+			if def := newColumn[tableColumnDefaultAttr].(string); def != "" {
+				statements = append(statements, fmt.Sprintf("%s SET DEFAULT %s", column, def))
+			} else {
+				statements = append(statements, fmt.Sprintf("%s DROP DEFAULT", column))
+			}
+		}
+
+		// The columns of the primary key are always not null.
+		if oldColumn[tableColumnNotNullAttr] != newColumn[tableColumnNotNullAttr] && !primaryKey[name] {
+			if newColumn[tableColumnNotNullAttr].(bool) {
+				statements = append(statements, fmt.Sprintf("%s SET NOT NULL", column))
+			} else {
+				statements = append(statements, fmt.Sprintf("%s DROP NOT NULL", column))
+			}
+		}
+	}
+
+	for _, statement := range statements {
+		if _, err := txn.Exec(statement); err != nil {
+			return fmt.Errorf("could not update columns of table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// dropTableConstraints drops the primary key if it has changed and the unique constraints
+// which have been removed or changed.
+func dropTableConstraints(txn *sql.Tx, d *schema.ResourceData) error {
+	if d.HasChange(tablePrimaryKeyAttr) {
+		var name string
+		query := "SELECT conname FROM pg_catalog.pg_constraint WHERE conrelid = $1::regclass AND contype = 'p'"
+		err := txn.QueryRow(query, getTableQualifiedName(d)).Scan(&name)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return fmt.Errorf("could not read primary key of table: %w", err)
+		default:
+			sql := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", getTableQualifiedName(d), pq.QuoteIdentifier(name))
+			if _, err := txn.Exec(sql); err != nil {
+				return fmt.Errorf("could not drop primary key of table: %w", err)
+			}
+		}
+	}
+
+	if d.HasChange(tableUniqueConstraintAttr) {
+		oraw, nraw := d.GetChange(tableUniqueConstraintAttr)
+		for _, constraint := range oraw.(*schema.Set).Difference(nraw.(*schema.Set)).List() {
+			name := constraint.(map[string]interface{})[tableUniqueConstraintNameAttr].(string)
+			sql := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s", getTableQualifiedName(d), pq.QuoteIdentifier(name))
+			if _, err := txn.Exec(sql); err != nil {
+				return fmt.Errorf("could not drop constraint %s of table: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// addTableConstraints adds the primary key if it has changed and the new unique constraints.
+func addTableConstraints(txn *sql.Tx, d *schema.ResourceData) error {
+	if d.HasChange(tablePrimaryKeyAttr) {
+		if primaryKey := d.Get(tablePrimaryKeyAttr).([]interface{}); len(primaryKey) > 0 {
+			sql := fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", getTableQualifiedName(d), quoteIdentifierList(primaryKey))
+			if _, err := txn.Exec(sql); err != nil {
+				return fmt.Errorf("could not add primary key to table: %w", err)
+			}
+		}
+	}
+
+	if d.HasChange(tableUniqueConstraintAttr) {
+		oraw, nraw := d.GetChange(tableUniqueConstraintAttr)
+		for _, constraint := range nraw.(*schema.Set).Difference(oraw.(*schema.Set)).List() {
+			sql := fmt.Sprintf(
+				"ALTER TABLE %s ADD %s",
+				getTableQualifiedName(d), getTableUniqueConstraintDefinition(constraint.(map[string]interface{})),
+			)
+			if _, err := txn.Exec(sql); err != nil {
+				return fmt.Errorf("could not add constraint to table: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getTableColumns reads the columns of the table.
+// As PostgreSQL normalizes the types and the default expressions, the values of a column
+// already in the state are kept, the values from the server are only used for new columns
+// (e.g.: when importing) or when a default has been added or removed.
+func getTableColumns(txn *sql.Tx, tableOID int, primaryKey []string, d *schema.ResourceData) ([]interface{}, error) {
+	knownColumns := getTableColumnsByName(d.Get(tableColumnAttr).([]interface{}))
+
+	inPrimaryKey := map[string]bool{}
+	for _, column := range primaryKey {
+		inPrimaryKey[column] = true
+	}
+
+	query := `SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod), a.attnotnull, ` +
+		`pg_catalog.pg_get_expr(ad.adbin, ad.adrelid) ` +
+		`FROM pg_catalog.pg_attribute a ` +
+		`LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum ` +
+		`WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped ` +
+		`ORDER BY a.attnum`
+	rows, err := txn.Query(query, tableOID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read columns of table: %w", err)
+	}
+	defer rows.Close()
+
+	columns := []interface{}{}
+	for rows.Next() {
+		var name, columnType string
+		var notNull bool
+		var def sql.NullString
+		if err := rows.Scan(&name, &columnType, &notNull, &def); err != nil {
+			return nil, fmt.Errorf("could not scan column of table: %w", err)
+		}
+
+		if known, ok := knownColumns[name]; ok {
+			columnType = known[tableColumnTypeAttr].(string)
+			if knownDef := known[tableColumnDefaultAttr].(string); (knownDef != "") == def.Valid {
+				def.String = knownDef
+			}
+			// The columns of the primary key are implicitly not null.
+			if inPrimaryKey[name] {
+				notNull = known[tableColumnNotNullAttr].(bool)
+			}
+		}
+
+		columns = append(columns, map[string]interface{}{
+			tableColumnNameAttr:    name,
+			tableColumnTypeAttr:    columnType,
+			tableColumnNotNullAttr: notNull,
+			tableColumnDefaultAttr: def.String,
+		})
+	}
+
+	return columns, rows.Err()
+}
+
+// getTableConstraints reads the columns of the primary key and the unique constraints of the table.
+func getTableConstraints(txn *sql.Tx, tableOID int) ([]string, []interface{}, error) {
+	query := `SELECT c.conname, c.contype, array_agg(a.attname ORDER BY k.ord) ` +
+		`FROM pg_catalog.pg_constraint c ` +
+		`CROSS JOIN LATERAL unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord) ` +
+		`JOIN pg_catalog.pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum ` +
+		`WHERE c.conrelid = $1 AND c.contype IN ('p', 'u') ` +
+		`GROUP BY c.conname, c.contype`
+	rows, err := txn.Query(query, tableOID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read constraints of table: %w", err)
+	}
+	defer rows.Close()
+
+	primaryKey := []string{}
+	uniqueConstraints := []interface{}{}
+	for rows.Next() {
+		var name, constraintType string
+		var columns pq.StringArray
+		if err := rows.Scan(&name, &constraintType, &columns); err != nil {
+			return nil, nil, fmt.Errorf("could not scan constraint of table: %w", err)
+		}
+
+		if constraintType == "p" {
+			primaryKey = columns
+			continue
+		}
+
+		uniqueConstraints = append(uniqueConstraints, map[string]interface{}{
+			tableUniqueConstraintNameAttr:    name,
+			tableUniqueConstraintColumnsAttr: []string(columns),
+		})
+	}
+
+	return primaryKey, uniqueConstraints, rows.Err()
+}
+
+func getTableColumnsByName(columns []interface{}) map[string]map[string]interface{} {
+	result := make(map[string]map[string]interface{}, len(columns))
+	for _, c := range columns {
+		column := c.(map[string]interface{})
+		result[column[tableColumnNameAttr].(string)] = column
+	}
+
+	return result
+}
+
+func getTableColumnDefinition(column map[string]interface{}) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "%s %s", pq.QuoteIdentifier(column[tableColumnNameAttr].(string)), column[tableColumnTypeAttr].(string))
+
+	if def := column[tableColumnDefaultAttr].(string); def != "" {
+		fmt.Fprint(&b, " DEFAULT ", def)
+	}
+	if column[tableColumnNotNullAttr].(bool) {
+		fmt.Fprint(&b, " NOT NULL")
+	}
+
+	return b.String()
+}
+
+func getTableUniqueConstraintDefinition(constraint map[string]interface{}) string {
+	return fmt.Sprintf(
+		"CONSTRAINT %s UNIQUE (%s)",
+		pq.QuoteIdentifier(constraint[tableUniqueConstraintNameAttr].(string)),
+		quoteIdentifierList(constraint[tableUniqueConstraintColumnsAttr].([]interface{})),
+	)
+}
+
+// quoteIdentifierList returns the comma separated list of the quoted identifiers.
+func quoteIdentifierList(identifiers []interface{}) string {
+	quoted := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		quoted = append(quoted, pq.QuoteIdentifier(identifier.(string)))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// getTableRelKind returns the kind of the table in pg_class, i.e.: 'p' for a partitioned table, 'r' otherwise.
+func getTableRelKind(d *schema.ResourceData) string {
+	if d.Get(tablePartitionByAttr).(string) != "" {
+		return "p"
+	}
+
+	return "r"
+}
+
+func getTableQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(tableSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(tableNameAttr).(string)),
+	)
+}
+
+func generateTableID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(tableSchemaAttr).(string),
+		d.Get(tableNameAttr).(string),
+	}, ".")
+}
+
+// getDBTableName returns the database, schema and name of the table. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTableName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	tableSchema := d.Get(tableSchemaAttr).(string)
+	tableName := d.Get(tableNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the table, schema and database names.
+	if tableName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("table ID %s has not the expected format 'database.schema.table': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		tableSchema = parsed[1]
+		tableName = parsed[2]
+	}
+
+	return database, tableSchema, tableName, nil
+}
@@ -0,0 +1,303 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	tablePartitionDatabaseAttr           = "database"
+	tablePartitionSchemaAttr             = "schema"
+	tablePartitionTableAttr              = "table"
+	tablePartitionPartitionSchemaAttr    = "partition_schema"
+	tablePartitionPartitionAttr          = "partition"
+	tablePartitionBoundAttr              = "bound"
+	tablePartitionDetachConcurrentlyAttr = "detach_concurrently"
+)
+
+func resourcePostgreSQLTablePartition() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLTablePartitionCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLTablePartitionExists, resourcePostgreSQLTablePartitionRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLTablePartitionUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLTablePartitionDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.All(
+			requireFeature(featurePartition, "postgresql_table_partition resource", nil),
+			requireFeature(featureDetachPartitionConcurrently, "DETACH PARTITION CONCURRENTLY", attributeSet(tablePartitionDetachConcurrentlyAttr)),
+		),
+
+		Schema: map[string]*schema.Schema{
+			tablePartitionDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the tables are located",
+			},
+			tablePartitionSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the partitioned table",
+			},
+			tablePartitionTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the partitioned table",
+			},
+			tablePartitionPartitionSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the table attached as a partition",
+			},
+			tablePartitionPartitionAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the table attached as a partition",
+			},
+			tablePartitionBoundAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The partition bound, e.g.: FOR VALUES FROM ('2024-01-01') TO ('2024-02-01') or DEFAULT",
+			},
+			tablePartitionDetachConcurrentlyAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the partition is detached with DETACH PARTITION CONCURRENTLY (PostgreSQL 14 or above)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTablePartitionCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featurePartition) {
+		return fmt.Errorf(
+			"postgresql_table_partition resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s ATTACH PARTITION %s %s",
+		getTablePartitionParentQualifiedName(d), getTablePartitionQualifiedName(d), d.Get(tablePartitionBoundAttr).(string),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not attach partition %s: %w", d.Get(tablePartitionPartitionAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating table partition: %w", err)
+	}
+
+	d.SetId(generateTablePartitionID(d, database))
+
+	return resourcePostgreSQLTablePartitionReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablePartitionExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, partitionSchema, partition, err := getDBTablePartitionName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	_, _, _, err = getTablePartitionParent(txn, partitionSchema, partition)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTablePartitionRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTablePartitionReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablePartitionReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, partitionSchema, partition, err := getDBTablePartitionName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	parentSchema, parent, bound, err := getTablePartitionParent(txn, partitionSchema, partition)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL partition (%s.%s) not attached for database %s", partitionSchema, partition, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading table partition: %w", err)
+	}
+
+	// As PostgreSQL normalizes the bound, it is only read from the server when importing.
+	if d.Get(tablePartitionBoundAttr).(string) == "" {
+		d.Set(tablePartitionBoundAttr, bound)
+	}
+
+	d.Set(tablePartitionDatabaseAttr, database)
+	d.Set(tablePartitionSchemaAttr, parentSchema)
+	d.Set(tablePartitionTableAttr, parent)
+	d.Set(tablePartitionPartitionSchemaAttr, partitionSchema)
+	d.Set(tablePartitionPartitionAttr, partition)
+
+	return nil
+}
+
+func resourcePostgreSQLTablePartitionUpdate(db *DBConnection, d *schema.ResourceData) error {
+	// All the attributes force a new resource, except detach_concurrently which is only used on delete.
+	return resourcePostgreSQLTablePartitionReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablePartitionDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	partition := d.Get(tablePartitionPartitionAttr).(string)
+
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s DETACH PARTITION %s",
+		getTablePartitionParentQualifiedName(d), getTablePartitionQualifiedName(d),
+	)
+
+	if d.Get(tablePartitionDetachConcurrentlyAttr).(bool) {
+		if !db.featureSupported(featureDetachPartitionConcurrently) {
+			return fmt.Errorf(
+				"DETACH PARTITION CONCURRENTLY is not supported for this Postgres version (%s)",
+				db.version,
+			)
+		}
+
+		// DETACH PARTITION CONCURRENTLY cannot be executed inside a transaction block.
+		conn, err := connectToDatabase(db.client, database)
+		if err != nil {
+			return err
+		}
+
+		if err := conn.execOutsideTransaction(conn.client.ctx, sql+" CONCURRENTLY"); err != nil {
+			return fmt.Errorf("could not detach partition %s: %w", partition, err)
+		}
+	} else {
+		txn, err := startTransaction(db.client, database)
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("could not detach partition %s: %w", partition, err)
+		}
+
+		if err = txn.Commit(); err != nil {
+			return fmt.Errorf("Error deleting table partition: %w", err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// getTablePartitionParent returns the schema and name of the partitioned table to which the table is attached,
+// and the bound of the partition. It returns sql.ErrNoRows if the table is not attached as a partition.
+func getTablePartitionParent(txn *sql.Tx, partitionSchema, partition string) (string, string, string, error) {
+	var parentSchema, parent, bound string
+
+	query := `SELECT pn.nspname, p.relname, pg_catalog.pg_get_expr(c.relpartbound, c.oid) ` +
+		`FROM pg_catalog.pg_class c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid ` +
+		`JOIN pg_catalog.pg_class p ON p.oid = i.inhparent ` +
+		`JOIN pg_catalog.pg_namespace pn ON pn.oid = p.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND c.relispartition`
+	err := txn.QueryRow(query, partitionSchema, partition).Scan(&parentSchema, &parent, &bound)
+
+	return parentSchema, parent, bound, err
+}
+
+func getTablePartitionParentQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(tablePartitionSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(tablePartitionTableAttr).(string)),
+	)
+}
+
+func getTablePartitionQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(tablePartitionPartitionSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(tablePartitionPartitionAttr).(string)),
+	)
+}
+
+func generateTablePartitionID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(tablePartitionPartitionSchemaAttr).(string),
+		d.Get(tablePartitionPartitionAttr).(string),
+	}, ".")
+}
+
+// getDBTablePartitionName returns the database, schema and name of the partition. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTablePartitionName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	partitionSchema := d.Get(tablePartitionPartitionSchemaAttr).(string)
+	partition := d.Get(tablePartitionPartitionAttr).(string)
+
+	// When importing, we have to parse the ID to find the partition, schema and database names.
+	if partition == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("table partition ID %s has not the expected format 'database.schema.partition': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		partitionSchema = parsed[1]
+		partition = parsed[2]
+	}
+
+	return database, partitionSchema, partition, nil
+}
@@ -0,0 +1,173 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlTablePartition_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	testAccPostgresqlTablePartitionConfig := func(partitions string) string {
+		return fmt.Sprintf(`
+		resource "postgresql_table" "events" {
+			name         = "events"
+			database     = "%[1]s"
+			partition_by = "RANGE (created_at)"
+
+			column {
+				name     = "created_at"
+				type     = "date"
+				not_null = true
+			}
+			column {
+				name = "payload"
+				type = "text"
+			}
+		}
+
+		resource "postgresql_table" "events_2024_01" {
+			name     = "events_2024_01"
+			database = "%[1]s"
+
+			column {
+				name     = "created_at"
+				type     = "date"
+				not_null = true
+			}
+			column {
+				name = "payload"
+				type = "text"
+			}
+		}
+
+		resource "postgresql_table" "events_2024_02" {
+			name     = "events_2024_02"
+			database = "%[1]s"
+
+			column {
+				name     = "created_at"
+				type     = "date"
+				not_null = true
+			}
+			column {
+				name = "payload"
+				type = "text"
+			}
+		}
+
+		%[2]s`, dbName, partitions)
+	}
+
+	january := fmt.Sprintf(`
+		resource "postgresql_table_partition" "events_2024_01" {
+			database  = "%s"
+			table     = postgresql_table.events.name
+			partition = postgresql_table.events_2024_01.name
+			bound     = "FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')"
+		}`, dbName)
+
+	february := fmt.Sprintf(`
+		resource "postgresql_table_partition" "events_2024_02" {
+			database  = "%s"
+			table     = postgresql_table.events.name
+			partition = postgresql_table.events_2024_02.name
+			bound     = "FOR VALUES FROM ('2024-02-01') TO ('2024-03-01')"
+		}`, dbName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testCheckCompatibleVersion(t, featurePartition)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTablePartitionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlTablePartitionConfig(january),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTablePartitionExists("postgresql_table_partition.events_2024_01"),
+					resource.TestCheckResourceAttr("postgresql_table.events", "partition_by", "RANGE (created_at)"),
+					resource.TestCheckResourceAttr(
+						"postgresql_table_partition.events_2024_01", "id", fmt.Sprintf("%s.public.events_2024_01", dbName),
+					),
+				),
+			},
+			{
+				// Rolls the partitions: January is detached and February is attached.
+				Config: testAccPostgresqlTablePartitionConfig(february),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTablePartitionExists("postgresql_table_partition.events_2024_02"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_table_partition.events_2024_02",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{tablePartitionBoundAttr},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTablePartitionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_table_partition" {
+			continue
+		}
+
+		exists, err := checkTablePartitionExists(client, rs)
+		if err != nil {
+			return fmt.Errorf("Error checking table partition %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Table partition still attached after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlTablePartitionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		exists, err := checkTablePartitionExists(testAccProvider.Meta().(*Client), rs)
+		if err != nil {
+			return fmt.Errorf("Error checking table partition %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Table partition not attached")
+		}
+
+		return nil
+	}
+}
+
+func checkTablePartitionExists(client *Client, rs *terraform.ResourceState) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	return resourcePostgreSQLTablePartitionExists(db, resourcePostgreSQLTablePartition().Data(rs.Primary))
+}
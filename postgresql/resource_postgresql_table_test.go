@@ -0,0 +1,155 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlTable_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_table" "test" {
+					name     = "audit_log"
+					database = "%s"
+
+					column {
+						name     = "id"
+						type     = "bigint"
+						not_null = true
+					}
+					column {
+						name    = "created_at"
+						type    = "timestamp with time zone"
+						default = "now()"
+					}
+
+					primary_key = ["id"]
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTableExists("postgresql_table.test"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "id", fmt.Sprintf("%s.public.audit_log", dbName)),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "primary_key.0", "id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_table" "test" {
+					name     = "audit_log"
+					database = "%s"
+
+					column {
+						name     = "id"
+						type     = "bigint"
+						not_null = true
+					}
+					column {
+						name     = "created_at"
+						type     = "timestamp with time zone"
+						default  = "now()"
+						not_null = true
+					}
+					column {
+						name = "message"
+						type = "text"
+					}
+
+					primary_key = ["id"]
+
+					unique_constraint {
+						name    = "audit_log_created_at_key"
+						columns = ["created_at", "message"]
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTableExists("postgresql_table.test"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.#", "3"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.1.not_null", "true"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "column.2.name", "message"),
+					resource.TestCheckResourceAttr("postgresql_table.test", "unique_constraint.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "postgresql_table.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{tableDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTableDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_table" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[tableDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[tableSchemaAttr], rs.Primary.Attributes[tableNameAttr], "r")
+		if err != nil {
+			return fmt.Errorf("Error checking table %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Table still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[tableDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[tableSchemaAttr], rs.Primary.Attributes[tableNameAttr], "r")
+		if err != nil {
+			return fmt.Errorf("Error checking table %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Table not found")
+		}
+
+		return nil
+	}
+}
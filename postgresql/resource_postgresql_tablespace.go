@@ -0,0 +1,271 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	tablespaceNameAttr           = "name"
+	tablespaceLocationAttr       = "location"
+	tablespaceOwnerAttr          = "owner"
+	tablespaceSeqPageCostAttr    = "seq_page_cost"
+	tablespaceRandomPageCostAttr = "random_page_cost"
+)
+
+// tablespaceOptions are the tablespace parameters managed by the resource.
+var tablespaceOptions = []string{tablespaceSeqPageCostAttr, tablespaceRandomPageCostAttr}
+
+func resourcePostgreSQLTablespace() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLTablespaceCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLTablespaceExists, resourcePostgreSQLTablespaceRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLTablespaceUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLTablespaceDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			tablespaceNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the tablespace",
+			},
+			tablespaceLocationAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The directory that will be used for the tablespace",
+			},
+			tablespaceOwnerAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ROLE which owns the tablespace",
+			},
+			tablespaceSeqPageCostAttr: {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The planner's estimate of the cost of a sequentially fetched page for the tables in this tablespace",
+			},
+			tablespaceRandomPageCostAttr: {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The planner's estimate of the cost of a non-sequentially fetched page for the tables in this tablespace",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTablespaceCreate(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(tablespaceNameAttr).(string)
+
+	b := bytes.NewBufferString("CREATE TABLESPACE ")
+	fmt.Fprint(b, pq.QuoteIdentifier(name))
+
+	if v, ok := d.GetOk(tablespaceOwnerAttr); ok {
+		fmt.Fprint(b, " OWNER ", pq.QuoteIdentifier(v.(string)))
+	}
+
+	fmt.Fprintf(b, " LOCATION '%s'", pqQuoteLiteral(d.Get(tablespaceLocationAttr).(string)))
+
+	options := []string{}
+	for _, option := range tablespaceOptions {
+		if v, ok := d.GetOk(option); ok {
+			options = append(options, fmt.Sprintf("%s = %s", option, formatTablespaceOption(v.(float64))))
+		}
+	}
+	if len(options) > 0 {
+		fmt.Fprintf(b, " WITH (%s)", strings.Join(options, ", "))
+	}
+
+	// CREATE TABLESPACE cannot be executed inside a transaction block.
+	if err := db.execOutsideTransaction(db.client.ctx, b.String()); err != nil {
+		return fmt.Errorf("could not create tablespace %s: %w", name, err)
+	}
+
+	d.SetId(name)
+
+	return resourcePostgreSQLTablespaceReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablespaceExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT spcname FROM pg_catalog.pg_tablespace WHERE spcname = $1", d.Id()).Scan(&name)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTablespaceRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTablespaceReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablespaceReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Id()
+
+	var owner, location string
+	var options pq.StringArray
+
+	query := `SELECT pg_catalog.pg_get_userbyid(spcowner), pg_catalog.pg_tablespace_location(oid), ` +
+		`COALESCE(spcoptions, '{}') ` +
+		`FROM pg_catalog.pg_tablespace WHERE spcname = $1`
+	err := db.QueryRow(query, name).Scan(&owner, &location, &options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL tablespace (%s) not found", name)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading tablespace: %w", err)
+	}
+
+	values := map[string]float64{}
+	for _, option := range options {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			// Not a cost parameter (e.g.: effective_io_concurrency is an integer but is not managed here).
+			continue
+		}
+		values[parts[0]] = value
+	}
+
+	d.Set(tablespaceNameAttr, name)
+	d.Set(tablespaceLocationAttr, location)
+	d.Set(tablespaceOwnerAttr, owner)
+	for _, option := range tablespaceOptions {
+		d.Set(option, values[option])
+	}
+
+	return nil
+}
+
+func resourcePostgreSQLTablespaceUpdate(db *DBConnection, d *schema.ResourceData) error {
+	if err := setTablespaceName(db, d); err != nil {
+		return err
+	}
+
+	if err := setTablespaceOwner(db, d); err != nil {
+		return err
+	}
+
+	if err := setTablespaceOptions(db, d); err != nil {
+		return err
+	}
+
+	return resourcePostgreSQLTablespaceReadImpl(db, d)
+}
+
+func resourcePostgreSQLTablespaceDelete(db *DBConnection, d *schema.ResourceData) error {
+	name := d.Get(tablespaceNameAttr).(string)
+
+	// DROP TABLESPACE cannot be executed inside a transaction block.
+	sql := fmt.Sprintf("DROP TABLESPACE %s", pq.QuoteIdentifier(name))
+	if err := db.execOutsideTransaction(db.client.ctx, sql); err != nil {
+		return fmt.Errorf("could not drop tablespace %s: %w", name, err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setTablespaceName(db QueryAble, d *schema.ResourceData) error {
+	if !d.HasChange(tablespaceNameAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(tablespaceNameAttr)
+	o := oraw.(string)
+	n := nraw.(string)
+	if n == "" {
+		return errors.New("Error setting tablespace name to an empty string")
+	}
+
+	sql := fmt.Sprintf("ALTER TABLESPACE %s RENAME TO %s", pq.QuoteIdentifier(o), pq.QuoteIdentifier(n))
+	if _, err := db.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating tablespace name: %w", err)
+	}
+	d.SetId(n)
+
+	return nil
+}
+
+func setTablespaceOwner(db QueryAble, d *schema.ResourceData) error {
+	if !d.HasChange(tablespaceOwnerAttr) {
+		return nil
+	}
+
+	owner := d.Get(tablespaceOwnerAttr).(string)
+	if owner == "" {
+		return nil
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER TABLESPACE %s OWNER TO %s",
+		pq.QuoteIdentifier(d.Get(tablespaceNameAttr).(string)), pq.QuoteIdentifier(owner),
+	)
+	if _, err := db.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating tablespace owner: %w", err)
+	}
+
+	return nil
+}
+
+func setTablespaceOptions(db QueryAble, d *schema.ResourceData) error {
+	var toSet, toReset []string
+	for _, option := range tablespaceOptions {
+		if !d.HasChange(option) {
+			continue
+		}
+		// A zero value means that the option is not set.
+		if v, ok := d.GetOk(option); ok {
+			toSet = append(toSet, fmt.Sprintf("%s = %s", option, formatTablespaceOption(v.(float64))))
+		} else {
+			toReset = append(toReset, option)
+		}
+	}
+
+	name := pq.QuoteIdentifier(d.Get(tablespaceNameAttr).(string))
+
+	if len(toSet) > 0 {
+		sql := fmt.Sprintf("ALTER TABLESPACE %s SET (%s)", name, strings.Join(toSet, ", "))
+		if _, err := db.Exec(sql); err != nil {
+			return fmt.Errorf("Error updating tablespace options: %w", err)
+		}
+	}
+
+	if len(toReset) > 0 {
+		sql := fmt.Sprintf("ALTER TABLESPACE %s RESET (%s)", name, strings.Join(toReset, ", "))
+		if _, err := db.Exec(sql); err != nil {
+			return fmt.Errorf("Error resetting tablespace options: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func formatTablespaceOption(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
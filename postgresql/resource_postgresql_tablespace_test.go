@@ -0,0 +1,142 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// getTestTablespaceLocation returns a directory, owned by the postgres user on the server,
+// where the tablespaces will be created during the tests.
+func getTestTablespaceLocation() string {
+	if location := os.Getenv("PGTABLESPACE_LOCATION"); location != "" {
+		return location
+	}
+	return "/var/lib/postgresql"
+}
+
+func TestAccPostgresqlTablespace_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	location := getTestTablespaceLocation()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTablespaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_tablespace" "test" {
+					name          = "tf_tests_tablespace"
+					location      = "%s"
+					seq_page_cost = 1.5
+				}`, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTablespaceExists("postgresql_tablespace.test"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "id", "tf_tests_tablespace"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "location", location),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "seq_page_cost", "1.5"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "random_page_cost", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_role" "owner" {
+					name = "tf_tests_tablespace_owner"
+				}
+
+				resource "postgresql_tablespace" "test" {
+					name             = "tf_tests_tablespace_renamed"
+					location         = "%s"
+					owner            = postgresql_role.owner.name
+					random_page_cost = 2
+				}`, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTablespaceExists("postgresql_tablespace.test"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "id", "tf_tests_tablespace_renamed"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "owner", "tf_tests_tablespace_owner"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "seq_page_cost", "0"),
+					resource.TestCheckResourceAttr("postgresql_tablespace.test", "random_page_cost", "2"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_tablespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTablespaceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_tablespace" {
+			continue
+		}
+
+		exists, err := checkTablespaceExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking tablespace %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Tablespace still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlTablespaceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkTablespaceExists(client, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking tablespace %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Tablespace not found")
+		}
+
+		return nil
+	}
+}
+
+func checkTablespaceExists(client *Client, name string) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	var _rez int
+	err = db.QueryRow("SELECT 1 FROM pg_catalog.pg_tablespace WHERE spcname = $1", name).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about tablespace: %s", err)
+	}
+
+	return true, nil
+}
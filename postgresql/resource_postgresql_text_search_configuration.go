@@ -0,0 +1,380 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	tsConfigNameAttr             = "name"
+	tsConfigSchemaAttr           = "schema"
+	tsConfigDatabaseAttr         = "database"
+	tsConfigParserAttr           = "parser"
+	tsConfigMappingAttr          = "mapping"
+	tsConfigMappingTokenTypeAttr = "token_type"
+	tsConfigMappingDictsAttr     = "dictionaries"
+	tsConfigDropCascadeAttr      = "drop_cascade"
+)
+
+func resourcePostgreSQLTextSearchConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLTextSearchConfigurationCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLTextSearchConfigurationExists, resourcePostgreSQLTextSearchConfigurationRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLTextSearchConfigurationUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLTextSearchConfigurationDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			tsConfigNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the text search configuration",
+			},
+			tsConfigSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the text search configuration is located",
+			},
+			tsConfigDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the text search configuration is located",
+			},
+			tsConfigParserAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				ForceNew:    true,
+				Description: "The name of the text search parser to use for this configuration",
+			},
+			tsConfigMappingAttr: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The mappings of the token types of the parser to dictionaries",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tsConfigMappingTokenTypeAttr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of a token type of the parser",
+						},
+						tsConfigMappingDictsAttr: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The dictionaries to consult, in order, for the token type",
+						},
+					},
+				},
+			},
+			tsConfigDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the text search configuration",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTextSearchConfigurationCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+	name := d.Get(tsConfigNameAttr).(string)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// The parser is not quoted so it can be schema qualified.
+	sql := fmt.Sprintf(
+		"CREATE TEXT SEARCH CONFIGURATION %s (PARSER = %s)",
+		getTextSearchConfigurationQualifiedName(d), d.Get(tsConfigParserAttr).(string),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not create text search configuration %s: %w", name, err)
+	}
+
+	if err := setTextSearchConfigurationMappings(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating text search configuration: %w", err)
+	}
+
+	d.SetId(generateTextSearchConfigurationID(d, database))
+
+	return resourcePostgreSQLTextSearchConfigurationReadImpl(db, d)
+}
+
+func resourcePostgreSQLTextSearchConfigurationExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, configSchema, configName, err := getDBTextSearchConfigurationName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez bool
+	query := `SELECT TRUE FROM pg_catalog.pg_ts_config c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.cfgnamespace ` +
+		`WHERE n.nspname = $1 AND c.cfgname = $2`
+	err = txn.QueryRow(query, configSchema, configName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTextSearchConfigurationRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTextSearchConfigurationReadImpl(db, d)
+}
+
+func resourcePostgreSQLTextSearchConfigurationReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, configSchema, configName, err := getDBTextSearchConfigurationName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var configOID int
+	var parser string
+
+	// The parser name is only qualified if it's not a built-in one.
+	query := `SELECT c.oid, CASE WHEN pn.nspname = 'pg_catalog' THEN p.prsname ELSE pn.nspname || '.' || p.prsname END ` +
+		`FROM pg_catalog.pg_ts_config c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.cfgnamespace ` +
+		`JOIN pg_catalog.pg_ts_parser p ON p.oid = c.cfgparser ` +
+		`JOIN pg_catalog.pg_namespace pn ON pn.oid = p.prsnamespace ` +
+		`WHERE n.nspname = $1 AND c.cfgname = $2`
+	err = txn.QueryRow(query, configSchema, configName).Scan(&configOID, &parser)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL text search configuration (%s.%s) not found for database %s", configSchema, configName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading text search configuration: %w", err)
+	}
+
+	query = `SELECT t.alias, array_agg(m.mapdict::regdictionary::text ORDER BY m.mapseqno) ` +
+		`FROM pg_catalog.pg_ts_config_map m ` +
+		`JOIN pg_catalog.pg_ts_config c ON c.oid = m.mapcfg ` +
+		`JOIN pg_catalog.ts_token_type(c.cfgparser) t ON t.tokid = m.maptokentype ` +
+		`WHERE m.mapcfg = $1 ` +
+		`GROUP BY t.alias`
+	rows, err := txn.Query(query, configOID)
+	if err != nil {
+		return fmt.Errorf("Error reading text search configuration mappings: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := []interface{}{}
+	for rows.Next() {
+		var tokenType string
+		var dictionaries pq.StringArray
+		if err := rows.Scan(&tokenType, &dictionaries); err != nil {
+			return fmt.Errorf("could not scan text search configuration mapping: %w", err)
+		}
+		mappings = append(mappings, map[string]interface{}{
+			tsConfigMappingTokenTypeAttr: tokenType,
+			tsConfigMappingDictsAttr:     []string(dictionaries),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	d.Set(tsConfigNameAttr, configName)
+	d.Set(tsConfigSchemaAttr, configSchema)
+	d.Set(tsConfigDatabaseAttr, database)
+	d.Set(tsConfigParserAttr, parser)
+	d.Set(tsConfigMappingAttr, mappings)
+
+	return nil
+}
+
+func resourcePostgreSQLTextSearchConfigurationUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := setTextSearchConfigurationMappings(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating text search configuration: %w", err)
+	}
+
+	return resourcePostgreSQLTextSearchConfigurationReadImpl(db, d)
+}
+
+func resourcePostgreSQLTextSearchConfigurationDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(tsConfigDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP TEXT SEARCH CONFIGURATION %s %s", getTextSearchConfigurationQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop text search configuration %s: %w", d.Get(tsConfigNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting text search configuration: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// setTextSearchConfigurationMappings adds, alters or drops the mappings of the configuration
+// by comparing the dictionaries of each token type in the state and in the config.
+func setTextSearchConfigurationMappings(txn *sql.Tx, d *schema.ResourceData) error {
+	if !d.HasChange(tsConfigMappingAttr) {
+		return nil
+	}
+
+	oraw, nraw := d.GetChange(tsConfigMappingAttr)
+	oldMappings := getTextSearchConfigurationMappings(oraw.(*schema.Set))
+	newMappings := getTextSearchConfigurationMappings(nraw.(*schema.Set))
+
+	tokenTypes := make([]string, 0, len(oldMappings)+len(newMappings))
+	for tokenType := range oldMappings {
+		tokenTypes = append(tokenTypes, tokenType)
+	}
+	for tokenType := range newMappings {
+		if _, ok := oldMappings[tokenType]; !ok {
+			tokenTypes = append(tokenTypes, tokenType)
+		}
+	}
+	sort.Strings(tokenTypes)
+
+	configName := getTextSearchConfigurationQualifiedName(d)
+	for _, tokenType := range tokenTypes {
+		oldDicts, inOld := oldMappings[tokenType]
+		newDicts, inNew := newMappings[tokenType]
+
+		var sql string
+		switch {
+		case !inNew:
+			sql = fmt.Sprintf("ALTER TEXT SEARCH CONFIGURATION %s DROP MAPPING IF EXISTS FOR %s", configName, tokenType)
+		case !inOld:
+			sql = fmt.Sprintf("ALTER TEXT SEARCH CONFIGURATION %s ADD MAPPING FOR %s WITH %s", configName, tokenType, newDicts)
+		case oldDicts != newDicts:
+			sql = fmt.Sprintf("ALTER TEXT SEARCH CONFIGURATION %s ALTER MAPPING FOR %s WITH %s", configName, tokenType, newDicts)
+		default:
+			continue
+		}
+
+		if _, err := txn.Exec(sql); err != nil {
+			return fmt.Errorf("could not update text search configuration mapping for %s: %w", tokenType, err)
+		}
+	}
+
+	return nil
+}
+
+// getTextSearchConfigurationMappings returns the comma separated list of dictionaries for each token type.
+// Token types and dictionaries are not quoted, so the dictionaries can be schema qualified.
+func getTextSearchConfigurationMappings(mappings *schema.Set) map[string]string {
+	result := make(map[string]string, mappings.Len())
+	for _, m := range mappings.List() {
+		mapping := m.(map[string]interface{})
+
+		dictionaries := []string{}
+		for _, dictionary := range mapping[tsConfigMappingDictsAttr].([]interface{}) {
+			dictionaries = append(dictionaries, dictionary.(string))
+		}
+		result[mapping[tsConfigMappingTokenTypeAttr].(string)] = strings.Join(dictionaries, ", ")
+	}
+
+	return result
+}
+
+func getTextSearchConfigurationQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(tsConfigSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(tsConfigNameAttr).(string)),
+	)
+}
+
+func generateTextSearchConfigurationID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(tsConfigSchemaAttr).(string),
+		d.Get(tsConfigNameAttr).(string),
+	}, ".")
+}
+
+// getDBTextSearchConfigurationName returns the database, schema and name of the text search configuration.
+// If we are importing this resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTextSearchConfigurationName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	configSchema := d.Get(tsConfigSchemaAttr).(string)
+	configName := d.Get(tsConfigNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the configuration, schema and database names.
+	if configName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("text search configuration ID %s has not the expected format 'database.schema.configuration': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		configSchema = parsed[1]
+		configName = parsed[2]
+	}
+
+	return database, configSchema, configName, nil
+}
@@ -0,0 +1,175 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlTextSearchConfiguration_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTextSearchConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_text_search_configuration" "test" {
+					name     = "my_english"
+					database = "%s"
+
+					mapping {
+						token_type   = "asciiword"
+						dictionaries = ["english_stem"]
+					}
+					mapping {
+						token_type   = "word"
+						dictionaries = ["simple"]
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTextSearchConfigurationExists("postgresql_text_search_configuration.test"),
+					resource.TestCheckResourceAttr("postgresql_text_search_configuration.test", "id", fmt.Sprintf("%s.public.my_english", dbName)),
+					resource.TestCheckResourceAttr("postgresql_text_search_configuration.test", "parser", "default"),
+					resource.TestCheckResourceAttr("postgresql_text_search_configuration.test", "mapping.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_text_search_configuration" "test" {
+					name     = "my_english"
+					database = "%s"
+
+					mapping {
+						token_type   = "asciiword"
+						dictionaries = ["english_stem", "simple"]
+					}
+					mapping {
+						token_type   = "email"
+						dictionaries = ["simple"]
+					}
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTextSearchConfigurationExists("postgresql_text_search_configuration.test"),
+					resource.TestCheckResourceAttr("postgresql_text_search_configuration.test", "mapping.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("postgresql_text_search_configuration.test", "mapping.*", map[string]string{
+						"token_type":     "asciiword",
+						"dictionaries.#": "2",
+						"dictionaries.1": "simple",
+					}),
+				),
+			},
+			{
+				// Drop a mapping outside of Terraform, the next plan should add it again.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "ALTER TEXT SEARCH CONFIGURATION my_english DROP MAPPING FOR email")
+				},
+				Config: fmt.Sprintf(`
+				resource "postgresql_text_search_configuration" "test" {
+					name     = "my_english"
+					database = "%s"
+
+					mapping {
+						token_type   = "asciiword"
+						dictionaries = ["english_stem", "simple"]
+					}
+					mapping {
+						token_type   = "email"
+						dictionaries = ["simple"]
+					}
+				}`, dbName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:            "postgresql_text_search_configuration.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{tsConfigDropCascadeAttr},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTextSearchConfigurationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_text_search_configuration" {
+			continue
+		}
+
+		exists, err := checkTextSearchConfigurationExists(client, rs.Primary.Attributes)
+		if err != nil {
+			return fmt.Errorf("Error checking text search configuration %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Text search configuration still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlTextSearchConfigurationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkTextSearchConfigurationExists(client, rs.Primary.Attributes)
+		if err != nil {
+			return fmt.Errorf("Error checking text search configuration %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Text search configuration not found")
+		}
+
+		return nil
+	}
+}
+
+func checkTextSearchConfigurationExists(client *Client, attributes map[string]string) (bool, error) {
+	txn, err := startTransaction(client, attributes[tsConfigDatabaseAttr])
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	err = txn.QueryRow(
+		`SELECT 1 FROM pg_catalog.pg_ts_config c `+
+			`JOIN pg_catalog.pg_namespace n ON n.oid = c.cfgnamespace `+
+			`WHERE n.nspname = $1 AND c.cfgname = $2`,
+		attributes[tsConfigSchemaAttr], attributes[tsConfigNameAttr],
+	).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about text search configuration: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,311 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	transformTypeAttr            = "type"
+	transformLanguageAttr        = "language"
+	transformDatabaseAttr        = "database"
+	transformFromSQLFunctionAttr = "from_sql_function"
+	transformToSQLFunctionAttr   = "to_sql_function"
+	transformDropCascadeAttr     = "drop_cascade"
+)
+
+func resourcePostgreSQLTransform() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLTransformCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLTransformExists, resourcePostgreSQLTransformRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLTransformUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLTransformDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: requireFeature(featureTransform, "postgresql_transform resource", nil),
+
+		Schema: map[string]*schema.Schema{
+			transformTypeAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The data type of the transform",
+			},
+			transformLanguageAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The procedural language of the transform",
+			},
+			transformDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the transform is located",
+			},
+			transformFromSQLFunctionAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{transformFromSQLFunctionAttr, transformToSQLFunctionAttr},
+				Description:  "The function converting the type from the SQL environment to the language, with its argument type (e.g.: hstore_to_plpython3(internal))",
+			},
+			transformToSQLFunctionAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{transformFromSQLFunctionAttr, transformToSQLFunctionAttr},
+				Description:  "The function converting the type from the language to the SQL environment, with its argument type (e.g.: plpython3_to_hstore(internal))",
+			},
+			transformDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, will also drop all the objects that depend on the transform",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTransformCreate(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureTransform) {
+		return fmt.Errorf(
+			"postgresql_transform resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if err := createTransform(txn, d, false); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating transform: %w", err)
+	}
+
+	d.SetId(generateTransformID(d, database))
+
+	return resourcePostgreSQLTransformReadImpl(db, d)
+}
+
+func resourcePostgreSQLTransformExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	if !db.featureSupported(featureTransform) {
+		return false, fmt.Errorf(
+			"postgresql_transform resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	database, transformType, language, err := getDBTransformName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	_, _, err = getTransformFunctions(txn, transformType, language)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLTransformRead(db *DBConnection, d *schema.ResourceData) error {
+	if !db.featureSupported(featureTransform) {
+		return fmt.Errorf(
+			"postgresql_transform resource is not supported for this Postgres version (%s)",
+			db.version,
+		)
+	}
+
+	return resourcePostgreSQLTransformReadImpl(db, d)
+}
+
+func resourcePostgreSQLTransformReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, transformType, language, err := getDBTransformName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	fromSQL, toSQL, err := getTransformFunctions(txn, transformType, language)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL transform for %s language %s not found for database %s", transformType, language, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading transform: %w", err)
+	}
+
+	// As PostgreSQL normalizes the function signatures, the functions are only read from the server
+	// when importing or when a function has been added or removed.
+	if state := d.Get(transformFromSQLFunctionAttr).(string); (state != "") != fromSQL.Valid {
+		d.Set(transformFromSQLFunctionAttr, fromSQL.String)
+	}
+	if state := d.Get(transformToSQLFunctionAttr).(string); (state != "") != toSQL.Valid {
+		d.Set(transformToSQLFunctionAttr, toSQL.String)
+	}
+
+	d.Set(transformTypeAttr, transformType)
+	d.Set(transformLanguageAttr, language)
+	d.Set(transformDatabaseAttr, database)
+
+	return nil
+}
+
+func resourcePostgreSQLTransformUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChanges(transformFromSQLFunctionAttr, transformToSQLFunctionAttr) {
+		if err := createTransform(txn, d, true); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating transform: %w", err)
+	}
+
+	return resourcePostgreSQLTransformReadImpl(db, d)
+}
+
+func resourcePostgreSQLTransformDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(transformDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf(
+		"DROP TRANSFORM FOR %s LANGUAGE %s %s",
+		d.Get(transformTypeAttr).(string), pq.QuoteIdentifier(d.Get(transformLanguageAttr).(string)), dropMode,
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop transform: %w", err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting transform: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// createTransform creates the transform, or replaces its functions if replace is true.
+func createTransform(txn *sql.Tx, d *schema.ResourceData, replace bool) error {
+	b := bytes.NewBufferString("CREATE ")
+	if replace {
+		fmt.Fprint(b, "OR REPLACE ")
+	}
+	fmt.Fprint(b, "TRANSFORM FOR ", d.Get(transformTypeAttr).(string))
+	fmt.Fprint(b, " LANGUAGE ", pq.QuoteIdentifier(d.Get(transformLanguageAttr).(string)))
+
+	functions := []string{}
+	if v, ok := d.GetOk(transformFromSQLFunctionAttr); ok {
+		functions = append(functions, "FROM SQL WITH FUNCTION "+v.(string))
+	}
+	if v, ok := d.GetOk(transformToSQLFunctionAttr); ok {
+		functions = append(functions, "TO SQL WITH FUNCTION "+v.(string))
+	}
+	fmt.Fprintf(b, " (%s)", strings.Join(functions, ", "))
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create transform: %w", err)
+	}
+
+	return nil
+}
+
+// getTransformFunctions returns the signatures of the from SQL and to SQL functions of the transform,
+// it returns sql.ErrNoRows if the transform (or its type) does not exist.
+func getTransformFunctions(txn *sql.Tx, transformType, language string) (sql.NullString, sql.NullString, error) {
+	var fromSQL, toSQL sql.NullString
+
+	query := `SELECT NULLIF(t.trffromsql, 0)::regprocedure::text, NULLIF(t.trftosql, 0)::regprocedure::text ` +
+		`FROM pg_catalog.pg_transform t ` +
+		`JOIN pg_catalog.pg_language l ON l.oid = t.trflang ` +
+		`WHERE t.trftype = pg_catalog.to_regtype($1) AND l.lanname = $2`
+	err := txn.QueryRow(query, transformType, language).Scan(&fromSQL, &toSQL)
+
+	return fromSQL, toSQL, err
+}
+
+func generateTransformID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(transformLanguageAttr).(string),
+		d.Get(transformTypeAttr).(string),
+	}, ".")
+}
+
+// getDBTransformName returns the database, type and language of the transform. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTransformName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	transformType := d.Get(transformTypeAttr).(string)
+	language := d.Get(transformLanguageAttr).(string)
+
+	// When importing, we have to parse the ID to find the type, language and database names.
+	// The type is the last part as it can be schema-qualified.
+	if transformType == "" {
+		parsed := strings.SplitN(d.Id(), ".", 3)
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("transform ID %s has not the expected format 'database.language.type': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		language = parsed[1]
+		transformType = parsed[2]
+	}
+
+	return database, transformType, language, nil
+}
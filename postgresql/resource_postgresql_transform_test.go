@@ -0,0 +1,143 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlTransform_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+	skipIfNotSuperuser(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+
+	var available bool
+	db, err := sql.Open("postgres", config.connStr(dbName))
+	if err != nil {
+		t.Fatalf("could to create connection pool: %v", err)
+	}
+	defer db.Close()
+	err = db.QueryRow("SELECT TRUE FROM pg_catalog.pg_available_extensions WHERE name = 'hstore_plpython3u'").Scan(&available)
+	if err == sql.ErrNoRows {
+		t.Skip("Skip test: hstore_plpython3u is not available")
+	}
+	if err != nil {
+		t.Fatalf("could not check available extensions: %v", err)
+	}
+
+	// The functions of the hstore_plpython3u extension are created without the transform itself.
+	dbExecute(t, config.connStr(dbName), "CREATE EXTENSION hstore")
+	dbExecute(t, config.connStr(dbName), "CREATE EXTENSION plpython3u")
+	dbExecute(t, config.connStr(dbName), `
+	CREATE FUNCTION test_hstore_to_plpython(val internal) RETURNS internal
+	LANGUAGE C STRICT IMMUTABLE AS '$libdir/hstore_plpython3', 'hstore_to_plpython'`)
+	dbExecute(t, config.connStr(dbName), `
+	CREATE FUNCTION test_plpython_to_hstore(val internal) RETURNS hstore
+	LANGUAGE C STRICT IMMUTABLE AS '$libdir/hstore_plpython3', 'plpython_to_hstore'`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testSuperuserPreCheck(t)
+			testCheckCompatibleVersion(t, featureTransform)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTransformDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_transform" "test" {
+					type              = "hstore"
+					language          = "plpython3u"
+					database          = "%s"
+					from_sql_function = "test_hstore_to_plpython(internal)"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTransformExists("postgresql_transform.test"),
+					resource.TestCheckResourceAttr("postgresql_transform.test", "id", fmt.Sprintf("%s.plpython3u.hstore", dbName)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "postgresql_transform" "test" {
+					type              = "hstore"
+					language          = "plpython3u"
+					database          = "%s"
+					from_sql_function = "test_hstore_to_plpython(internal)"
+					to_sql_function   = "test_plpython_to_hstore(internal)"
+				}`, dbName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTransformExists("postgresql_transform.test"),
+					resource.TestCheckResourceAttr("postgresql_transform.test", "to_sql_function", "test_plpython_to_hstore(internal)"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_transform.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTransformDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_transform" {
+			continue
+		}
+
+		exists, err := checkTransformExists(client, rs)
+		if err != nil {
+			return fmt.Errorf("Error checking transform %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Transform still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlTransformExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		exists, err := checkTransformExists(testAccProvider.Meta().(*Client), rs)
+		if err != nil {
+			return fmt.Errorf("Error checking transform %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Transform not found")
+		}
+
+		return nil
+	}
+}
+
+func checkTransformExists(client *Client, rs *terraform.ResourceState) (bool, error) {
+	db, err := client.Connect()
+	if err != nil {
+		return false, err
+	}
+
+	return resourcePostgreSQLTransformExists(db, resourcePostgreSQLTransform().Data(rs.Primary))
+}
@@ -0,0 +1,427 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	triggerNameAttr           = "name"
+	triggerDatabaseAttr       = "database"
+	triggerSchemaAttr         = "schema"
+	triggerTableAttr          = "table"
+	triggerTimingAttr         = "timing"
+	triggerEventsAttr         = "events"
+	triggerForEachAttr        = "for_each"
+	triggerWhenAttr           = "when"
+	triggerFunctionAttr       = "function"
+	triggerFunctionSchemaAttr = "function_schema"
+	triggerEnabledAttr        = "enabled"
+)
+
+// Bits of pg_trigger.tgtype, see include/catalog/pg_trigger.h
+const (
+	triggerTypeRow      = 1 << 0
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+var (
+	allowedTriggerTimings  = []string{"BEFORE", "AFTER", "INSTEAD OF"}
+	allowedTriggerEvents   = []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}
+	allowedTriggerForEachs = []string{"ROW", "STATEMENT"}
+)
+
+func resourcePostgreSQLTrigger() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLTriggerCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLTriggerExists, resourcePostgreSQLTriggerRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLTriggerUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLTriggerDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			triggerNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the trigger",
+			},
+			triggerDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the trigger is located",
+			},
+			triggerSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the table of the trigger",
+			},
+			triggerTableAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The table (or view) of the trigger",
+			},
+			triggerTimingAttr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(allowedTriggerTimings, false),
+				Description:  "When the function is called (any of: " + strings.Join(allowedTriggerTimings, ", ") + ")",
+			},
+			triggerEventsAttr: {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(allowedTriggerEvents, false),
+				},
+				Set:         schema.HashString,
+				Description: "The events that will fire the trigger (any of: " + strings.Join(allowedTriggerEvents, ", ") + ")",
+			},
+			triggerForEachAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "STATEMENT",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(allowedTriggerForEachs, false),
+				Description:  "If the function is called once for every row or once per statement (any of: " + strings.Join(allowedTriggerForEachs, ", ") + ")",
+			},
+			triggerWhenAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A boolean expression that determines whether the function will actually be executed",
+			},
+			triggerFunctionAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the trigger function",
+			},
+			triggerFunctionSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema of the trigger function",
+			},
+			triggerEnabledAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If the trigger is enabled",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLTriggerCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	events := []string{}
+	for _, event := range d.Get(triggerEventsAttr).(*schema.Set).List() {
+		events = append(events, event.(string))
+	}
+	sortTriggerEvents(events)
+
+	b := bytes.NewBufferString("CREATE TRIGGER ")
+	fmt.Fprint(b, pq.QuoteIdentifier(d.Get(triggerNameAttr).(string)))
+	fmt.Fprint(b, " ", d.Get(triggerTimingAttr).(string), " ", strings.Join(events, " OR "))
+	fmt.Fprint(b, " ON ", getTriggerTableQualifiedName(d))
+	fmt.Fprint(b, " FOR EACH ", d.Get(triggerForEachAttr).(string))
+
+	if when := d.Get(triggerWhenAttr).(string); when != "" {
+		fmt.Fprintf(b, " WHEN (%s)", when)
+	}
+
+	fmt.Fprintf(
+		b, " EXECUTE PROCEDURE %s.%s()",
+		pq.QuoteIdentifier(d.Get(triggerFunctionSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(triggerFunctionAttr).(string)),
+	)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(b.String()); err != nil {
+		return fmt.Errorf("could not create trigger %s: %w", d.Get(triggerNameAttr).(string), err)
+	}
+
+	if !d.Get(triggerEnabledAttr).(bool) {
+		if err := setTriggerEnabled(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating trigger: %w", err)
+	}
+
+	d.SetId(generateTriggerID(d, database))
+
+	return resourcePostgreSQLTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLTriggerExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, triggerSchema, triggerTable, triggerName, err := getDBTriggerName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return triggerExists(txn, triggerSchema, triggerTable, triggerName)
+}
+
+func resourcePostgreSQLTriggerRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLTriggerReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, triggerSchema, triggerTable, triggerName, err := getDBTriggerName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var triggerType int
+	var enabled, function, functionSchema string
+
+	query := `SELECT t.tgtype, t.tgenabled, p.proname, pn.nspname ` +
+		`FROM pg_catalog.pg_trigger t ` +
+		`JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`JOIN pg_catalog.pg_proc p ON p.oid = t.tgfoid ` +
+		`JOIN pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND t.tgname = $3 AND NOT t.tgisinternal`
+	err = txn.QueryRow(query, triggerSchema, triggerTable, triggerName).Scan(
+		&triggerType, &enabled, &function, &functionSchema,
+	)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL trigger (%s) on %s.%s not found for database %s", triggerName, triggerSchema, triggerTable, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading trigger: %w", err)
+	}
+
+	timing := "AFTER"
+	switch {
+	case triggerType&triggerTypeBefore != 0:
+		timing = "BEFORE"
+	case triggerType&triggerTypeInstead != 0:
+		timing = "INSTEAD OF"
+	}
+
+	forEach := "STATEMENT"
+	if triggerType&triggerTypeRow != 0 {
+		forEach = "ROW"
+	}
+
+	events := []string{}
+	for event, bit := range map[string]int{
+		"INSERT":   triggerTypeInsert,
+		"UPDATE":   triggerTypeUpdate,
+		"DELETE":   triggerTypeDelete,
+		"TRUNCATE": triggerTypeTruncate,
+	} {
+		if triggerType&bit != 0 {
+			events = append(events, event)
+		}
+	}
+
+	d.Set(triggerNameAttr, triggerName)
+	d.Set(triggerDatabaseAttr, database)
+	d.Set(triggerSchemaAttr, triggerSchema)
+	d.Set(triggerTableAttr, triggerTable)
+	d.Set(triggerTimingAttr, timing)
+	d.Set(triggerEventsAttr, stringSliceToSet(events))
+	d.Set(triggerForEachAttr, forEach)
+	d.Set(triggerFunctionAttr, function)
+	d.Set(triggerFunctionSchemaAttr, functionSchema)
+	// 'D' means disabled, the other values mean enabled in different session_replication_role modes.
+	d.Set(triggerEnabledAttr, enabled != "D")
+
+	return nil
+}
+
+func resourcePostgreSQLTriggerUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if d.HasChange(triggerEnabledAttr) {
+		if err := setTriggerEnabled(txn, d); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating trigger: %w", err)
+	}
+
+	return resourcePostgreSQLTriggerReadImpl(db, d)
+}
+
+func resourcePostgreSQLTriggerDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf(
+		"DROP TRIGGER %s ON %s",
+		pq.QuoteIdentifier(d.Get(triggerNameAttr).(string)), getTriggerTableQualifiedName(d),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop trigger %s: %w", d.Get(triggerNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting trigger: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setTriggerEnabled(txn *sql.Tx, d *schema.ResourceData) error {
+	action := "DISABLE"
+	if d.Get(triggerEnabledAttr).(bool) {
+		action = "ENABLE"
+	}
+
+	sql := fmt.Sprintf(
+		"ALTER TABLE %s %s TRIGGER %s",
+		getTriggerTableQualifiedName(d), action, pq.QuoteIdentifier(d.Get(triggerNameAttr).(string)),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating trigger enabled state: %w", err)
+	}
+
+	return nil
+}
+
+func triggerExists(txn *sql.Tx, triggerSchema, triggerTable, triggerName string) (bool, error) {
+	var _rez bool
+
+	query := `SELECT TRUE FROM pg_catalog.pg_trigger t ` +
+		`JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND t.tgname = $3`
+	err := txn.QueryRow(query, triggerSchema, triggerTable, triggerName).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+// sortTriggerEvents sorts the events in the order of allowedTriggerEvents
+// so the generated statement is stable.
+func sortTriggerEvents(events []string) {
+	order := map[string]int{}
+	for i, event := range allowedTriggerEvents {
+		order[event] = i
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return order[events[i]] < order[events[j]]
+	})
+}
+
+func getTriggerTableQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(triggerSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(triggerTableAttr).(string)),
+	)
+}
+
+func generateTriggerID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(triggerSchemaAttr).(string),
+		d.Get(triggerTableAttr).(string),
+		d.Get(triggerNameAttr).(string),
+	}, ".")
+}
+
+// getDBTriggerName returns the database, schema, table and name of the trigger. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBTriggerName(d *schema.ResourceData, client *Client) (string, string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	triggerSchema := d.Get(triggerSchemaAttr).(string)
+	triggerTable := d.Get(triggerTableAttr).(string)
+	triggerName := d.Get(triggerNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the trigger, table, schema and database names.
+	if triggerName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 4 {
+			return "", "", "", "", fmt.Errorf(
+				"trigger ID %s has not the expected format 'database.schema.table.trigger': %v",
+				d.Id(), parsed,
+			)
+		}
+		database = parsed[0]
+		triggerSchema = parsed[1]
+		triggerTable = parsed[2]
+		triggerName = parsed[3]
+	}
+
+	return database, triggerSchema, triggerTable, triggerName, nil
+}
@@ -0,0 +1,142 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlTrigger_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_table (id integer, updated_at timestamptz)")
+	dbExecute(t, config.connStr(dbName), `
+	CREATE FUNCTION set_updated_at() RETURNS trigger LANGUAGE plpgsql AS $$
+	BEGIN
+		NEW.updated_at = now();
+		RETURN NEW;
+	END;
+	$$`)
+
+	testAccPostgresqlTriggerConfig := func(enabled bool) string {
+		return fmt.Sprintf(`
+		resource "postgresql_trigger" "test" {
+			name     = "test_trigger"
+			database = "%s"
+			table    = "test_table"
+			timing   = "BEFORE"
+			events   = ["INSERT", "UPDATE"]
+			for_each = "ROW"
+			when     = "NEW.id IS NOT NULL"
+			function = "set_updated_at"
+			enabled  = %t
+		}`, dbName, enabled)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlTriggerConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTriggerExists("postgresql_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "id", fmt.Sprintf("%s.public.test_table.test_trigger", dbName)),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "timing", "BEFORE"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "events.#", "2"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "for_each", "ROW"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "function", "set_updated_at"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "function_schema", "public"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccPostgresqlTriggerConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlTriggerExists("postgresql_trigger.test"),
+					resource.TestCheckResourceAttr("postgresql_trigger.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"when",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlTriggerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_trigger" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[triggerDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := triggerExists(
+			txn, rs.Primary.Attributes[triggerSchemaAttr], rs.Primary.Attributes[triggerTableAttr], rs.Primary.Attributes[triggerNameAttr],
+		)
+		if err != nil {
+			return fmt.Errorf("Error checking trigger %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("Trigger still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlTriggerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[triggerDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := triggerExists(
+			txn, rs.Primary.Attributes[triggerSchemaAttr], rs.Primary.Attributes[triggerTableAttr], rs.Primary.Attributes[triggerNameAttr],
+		)
+		if err != nil {
+			return fmt.Errorf("Error checking trigger %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Trigger not found")
+		}
+
+		return nil
+	}
+}
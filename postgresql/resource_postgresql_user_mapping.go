@@ -0,0 +1,274 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+const (
+	userMappingUserNameAttr   = "user_name"
+	userMappingServerNameAttr = "server_name"
+	userMappingDatabaseAttr   = "database"
+	userMappingOptionsAttr    = "options"
+)
+
+func resourcePostgreSQLUserMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLUserMappingCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLUserMappingExists, resourcePostgreSQLUserMappingRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLUserMappingUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLUserMappingDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			userMappingUserNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of an existing user that is mapped to the foreign server (or PUBLIC)",
+			},
+			userMappingServerNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of an existing foreign server for which the user mapping is to be created",
+			},
+			userMappingDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the foreign server is located",
+			},
+			userMappingOptionsAttr: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The options of the user mapping (e.g.: user, password)",
+			},
+		},
+	}
+}
+
+func resourcePostgreSQLUserMappingCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	sql := fmt.Sprintf(
+		"CREATE USER MAPPING FOR %s SERVER %s",
+		quoteUserMappingUser(d.Get(userMappingUserNameAttr).(string)),
+		pq.QuoteIdentifier(d.Get(userMappingServerNameAttr).(string)),
+	)
+	if options := d.Get(userMappingOptionsAttr).(map[string]interface{}); len(options) > 0 {
+		sql += " " + getFDWOptionsClause(options)
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not create user mapping: %w", err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating user mapping: %w", err)
+	}
+
+	d.SetId(generateUserMappingID(d))
+
+	return resourcePostgreSQLUserMappingReadImpl(db, d)
+}
+
+func resourcePostgreSQLUserMappingExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, serverName, userName, err := getDBUserMappingName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	query := "SELECT 1 FROM pg_catalog.pg_user_mappings WHERE srvname = $1 AND usename = $2"
+	err = txn.QueryRow(query, serverName, userMappingCatalogUser(userName)).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func resourcePostgreSQLUserMappingRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLUserMappingReadImpl(db, d)
+}
+
+func resourcePostgreSQLUserMappingReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, serverName, userName, err := getDBUserMappingName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// umoptions is only visible to the owner of the server or to the mapped user (or a superuser),
+	// otherwise it is NULL.
+	var options pq.StringArray
+
+	query := "SELECT umoptions FROM pg_catalog.pg_user_mappings WHERE srvname = $1 AND usename = $2"
+	err = txn.QueryRow(query, serverName, userMappingCatalogUser(userName)).Scan(&options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL user mapping (%s/%s) not found for database %s", serverName, userName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading user mapping: %w", err)
+	}
+
+	d.Set(userMappingUserNameAttr, userName)
+	d.Set(userMappingServerNameAttr, serverName)
+	d.Set(userMappingDatabaseAttr, database)
+	// The ID can be prefixed by the database when importing.
+	d.SetId(generateUserMappingID(d))
+	// The options are kept from the state if they are not visible (or if there is none).
+	if options != nil {
+		d.Set(userMappingOptionsAttr, parseFDWOptions(options))
+	}
+
+	return nil
+}
+
+func resourcePostgreSQLUserMappingUpdate(db *DBConnection, d *schema.ResourceData) error {
+	options := getFDWAlterOptionsClause(d, userMappingOptionsAttr)
+	if options == "" {
+		return resourcePostgreSQLUserMappingReadImpl(db, d)
+	}
+
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf(
+		"ALTER USER MAPPING FOR %s SERVER %s %s",
+		quoteUserMappingUser(d.Get(userMappingUserNameAttr).(string)),
+		pq.QuoteIdentifier(d.Get(userMappingServerNameAttr).(string)),
+		options,
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("Error updating user mapping options: %w", err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating user mapping: %w", err)
+	}
+
+	return resourcePostgreSQLUserMappingReadImpl(db, d)
+}
+
+func resourcePostgreSQLUserMappingDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	sql := fmt.Sprintf(
+		"DROP USER MAPPING FOR %s SERVER %s",
+		quoteUserMappingUser(d.Get(userMappingUserNameAttr).(string)),
+		pq.QuoteIdentifier(d.Get(userMappingServerNameAttr).(string)),
+	)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop user mapping: %w", err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting user mapping: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// quoteUserMappingUser quotes the user name of a user mapping,
+// PUBLIC is a keyword so it's not quoted.
+func quoteUserMappingUser(user string) string {
+	if strings.ToUpper(user) == "PUBLIC" {
+		return "PUBLIC"
+	}
+	return pq.QuoteIdentifier(user)
+}
+
+// userMappingCatalogUser returns the user name as displayed in pg_user_mappings.
+func userMappingCatalogUser(user string) string {
+	if strings.ToUpper(user) == "PUBLIC" {
+		return "public"
+	}
+	return user
+}
+
+func generateUserMappingID(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		d.Get(userMappingServerNameAttr).(string),
+		d.Get(userMappingUserNameAttr).(string),
+	}, "/")
+}
+
+// getDBUserMappingName returns the database, server and user names of the user mapping. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBUserMappingName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	serverName := d.Get(userMappingServerNameAttr).(string)
+	userName := d.Get(userMappingUserNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the server and user names (and optionally the database).
+	if serverName == "" {
+		parsed := strings.Split(d.Id(), "/")
+		switch len(parsed) {
+		case 2:
+			serverName = parsed[0]
+			userName = parsed[1]
+		case 3:
+			database = parsed[0]
+			serverName = parsed[1]
+			userName = parsed[2]
+		default:
+			return "", "", "", fmt.Errorf("user mapping ID %s has not the expected format '[database/]server/user': %v", d.Id(), parsed)
+		}
+	}
+
+	return database, serverName, userName, nil
+}
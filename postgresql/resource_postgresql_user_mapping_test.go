@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlUserMapping_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, roleName := getTestDBNames(dbSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlUserMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlUserMappingConfig(dbName, roleName, `
+						user     = "remote_user"
+						password = "secret"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlUserMappingExists("postgresql_user_mapping.test"),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test", "id", fmt.Sprintf("test_server/%s", roleName)),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test", "options.%", "2"),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test", "options.user", "remote_user"),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test", "options.password", "secret"),
+				),
+			},
+			{
+				Config: testAccPostgresqlUserMappingConfig(dbName, roleName, `
+						user = "other_user"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlUserMappingExists("postgresql_user_mapping.test"),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test", "options.%", "1"),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test", "options.user", "other_user"),
+				),
+			},
+			{
+				ResourceName:      "postgresql_user_mapping.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/test_server/%s", dbName, roleName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPostgresqlUserMappingConfig(dbName, roleName, options string) string {
+	return fmt.Sprintf(`
+resource "postgresql_extension" "postgres_fdw" {
+  name     = "postgres_fdw"
+  database = "%[1]s"
+}
+
+resource "postgresql_foreign_server" "test" {
+  name                 = "test_server"
+  database             = "%[1]s"
+  foreign_data_wrapper = postgresql_extension.postgres_fdw.name
+  options = {
+    host   = "localhost"
+    dbname = "remote"
+  }
+}
+
+resource "postgresql_user_mapping" "test" {
+  server_name = postgresql_foreign_server.test.name
+  user_name   = "%[2]s"
+  database    = "%[1]s"
+  options = {%[3]s
+  }
+}
+`, dbName, roleName, options)
+}
+
+func testAccCheckPostgresqlUserMappingDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_user_mapping" {
+			continue
+		}
+
+		exists, err := checkUserMappingExists(client, rs.Primary.Attributes)
+		if err != nil {
+			return fmt.Errorf("Error checking user mapping %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("User mapping still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlUserMappingExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		exists, err := checkUserMappingExists(client, rs.Primary.Attributes)
+		if err != nil {
+			return fmt.Errorf("Error checking user mapping %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("User mapping not found")
+		}
+
+		return nil
+	}
+}
+
+func checkUserMappingExists(client *Client, attributes map[string]string) (bool, error) {
+	txn, err := startTransaction(client, attributes[userMappingDatabaseAttr])
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	var _rez int
+	err = txn.QueryRow(
+		"SELECT 1 FROM pg_catalog.pg_user_mappings WHERE srvname = $1 AND usename = $2",
+		attributes[userMappingServerNameAttr], userMappingCatalogUser(attributes[userMappingUserNameAttr]),
+	).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("Error reading info about user mapping: %s", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,352 @@
+package postgresql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+const (
+	viewNameAttr            = "name"
+	viewSchemaAttr          = "schema"
+	viewDatabaseAttr        = "database"
+	viewQueryAttr           = "query"
+	viewDefinitionAttr      = "definition"
+	viewCheckOptionAttr     = "check_option"
+	viewSecurityBarrierAttr = "security_barrier"
+	viewDropCascadeAttr     = "drop_cascade"
+)
+
+var allowedViewCheckOptions = []string{"LOCAL", "CASCADED"}
+
+func resourcePostgreSQLView() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: PGResourceFunc(resourcePostgreSQLViewCreate),
+		ReadContext:   PGResourceReadFunc(resourcePostgreSQLViewExists, resourcePostgreSQLViewRead),
+		UpdateContext: PGResourceFunc(resourcePostgreSQLViewUpdate),
+		DeleteContext: PGResourceFunc(resourcePostgreSQLViewDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			viewNameAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the view",
+			},
+			viewSchemaAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "public",
+				ForceNew:    true,
+				Description: "The schema where the view is located",
+			},
+			viewDatabaseAttr: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The database where the view is located",
+			},
+			viewQueryAttr: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SELECT query of the view",
+			},
+			viewDefinitionAttr: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The query of the view as reconstructed by PostgreSQL",
+			},
+			viewCheckOptionAttr: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(allowedViewCheckOptions, false),
+				Description:  "The check option of the view (any of: " + strings.Join(allowedViewCheckOptions, ", ") + ")",
+			},
+			viewSecurityBarrierAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If the view is intended to provide row-level security",
+			},
+			viewDropCascadeAttr: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the objects depending on the view will be dropped when the view is dropped or cannot be replaced",
+			},
+			assumeRoleAttr: assumeRoleSchema(),
+		},
+	}
+}
+
+func resourcePostgreSQLViewCreate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	if _, err := txn.Exec(getViewCreateQuery(d, false)); err != nil {
+		return fmt.Errorf("could not create view %s: %w", d.Get(viewNameAttr).(string), err)
+	}
+
+	if err := setViewDefinition(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error creating view: %w", err)
+	}
+
+	d.SetId(generateViewID(d, database))
+
+	return resourcePostgreSQLViewReadImpl(db, d)
+}
+
+func resourcePostgreSQLViewExists(db *DBConnection, d *schema.ResourceData) (bool, error) {
+	database, viewSchema, viewName, err := getDBViewName(d, db.client)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if the database exists
+	exists, err := dbExists(db, database)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return false, err
+	}
+	defer deferredRollback(txn)
+
+	return relationExists(txn, viewSchema, viewName, "v")
+}
+
+func resourcePostgreSQLViewRead(db *DBConnection, d *schema.ResourceData) error {
+	return resourcePostgreSQLViewReadImpl(db, d)
+}
+
+func resourcePostgreSQLViewReadImpl(db *DBConnection, d *schema.ResourceData) error {
+	database, viewSchema, viewName, err := getDBViewName(d, db.client)
+	if err != nil {
+		return err
+	}
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	var definition string
+	var options pq.StringArray
+
+	query := `SELECT pg_catalog.pg_get_viewdef(c.oid), c.reloptions ` +
+		`FROM pg_catalog.pg_class c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = 'v'`
+	err = txn.QueryRow(query, viewSchema, viewName).Scan(&definition, &options)
+	switch {
+	case err == sql.ErrNoRows:
+		log.Printf("[WARN] PostgreSQL view (%s.%s) not found for database %s", viewSchema, viewName, database)
+		d.SetId("")
+		return nil
+	case err != nil:
+		return fmt.Errorf("Error reading view: %w", err)
+	}
+
+	// PostgreSQL does not keep the query as it was written, so we compare the
+	// reconstructed definition with the one we stored when applying the view.
+	// If they are different, the view has been changed outside of Terraform
+	// (or is being imported) and the query is replaced to show the drift.
+	if definition != d.Get(viewDefinitionAttr).(string) {
+		d.Set(viewQueryAttr, definition)
+	}
+
+	checkOption := ""
+	securityBarrier := false
+	for _, option := range options {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		switch parts[0] {
+		case "check_option":
+			checkOption = strings.ToUpper(parts[1])
+		case "security_barrier":
+			securityBarrier = parts[1] == "true"
+		}
+	}
+
+	d.Set(viewNameAttr, viewName)
+	d.Set(viewSchemaAttr, viewSchema)
+	d.Set(viewDatabaseAttr, database)
+	d.Set(viewDefinitionAttr, definition)
+	d.Set(viewCheckOptionAttr, checkOption)
+	d.Set(viewSecurityBarrierAttr, securityBarrier)
+
+	return nil
+}
+
+func resourcePostgreSQLViewUpdate(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	// CREATE OR REPLACE VIEW cannot remove or change the type of existing columns,
+	// if drop_cascade is set we recreate the view (and drop its dependent objects) instead.
+	if d.HasChange(viewQueryAttr) && d.Get(viewDropCascadeAttr).(bool) {
+		if _, err := txn.Exec(fmt.Sprintf("DROP VIEW %s CASCADE", getViewQualifiedName(d))); err != nil {
+			return fmt.Errorf("could not drop view %s: %w", d.Get(viewNameAttr).(string), err)
+		}
+		if _, err := txn.Exec(getViewCreateQuery(d, false)); err != nil {
+			return fmt.Errorf("could not create view %s: %w", d.Get(viewNameAttr).(string), err)
+		}
+	} else if _, err := txn.Exec(getViewCreateQuery(d, true)); err != nil {
+		return fmt.Errorf("could not replace view %s: %w", d.Get(viewNameAttr).(string), err)
+	}
+
+	if err := setViewDefinition(txn, d); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error updating view: %w", err)
+	}
+
+	return resourcePostgreSQLViewReadImpl(db, d)
+}
+
+func resourcePostgreSQLViewDelete(db *DBConnection, d *schema.ResourceData) error {
+	database := getDatabase(d, db.client.databaseName)
+
+	txn, err := startTransaction(db.client, database)
+	if err != nil {
+		return err
+	}
+	defer deferredRollback(txn)
+
+	dropMode := "RESTRICT"
+	if d.Get(viewDropCascadeAttr).(bool) {
+		dropMode = "CASCADE"
+	}
+
+	sql := fmt.Sprintf("DROP VIEW %s %s", getViewQualifiedName(d), dropMode)
+	if _, err := txn.Exec(sql); err != nil {
+		return fmt.Errorf("could not drop view %s: %w", d.Get(viewNameAttr).(string), err)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return fmt.Errorf("Error deleting view: %w", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getViewCreateQuery(d *schema.ResourceData, replace bool) string {
+	b := bytes.NewBufferString("CREATE ")
+	if replace {
+		fmt.Fprint(b, "OR REPLACE ")
+	}
+	fmt.Fprint(b, "VIEW ", getViewQualifiedName(d))
+
+	options := []string{fmt.Sprintf("security_barrier = %t", d.Get(viewSecurityBarrierAttr).(bool))}
+	if checkOption := d.Get(viewCheckOptionAttr).(string); checkOption != "" {
+		options = append(options, fmt.Sprintf("check_option = %s", strings.ToLower(checkOption)))
+	}
+	fmt.Fprintf(b, " WITH (%s)", strings.Join(options, ", "))
+
+	fmt.Fprint(b, " AS ", d.Get(viewQueryAttr).(string))
+
+	return b.String()
+}
+
+// setViewDefinition stores the definition of the view as reconstructed by PostgreSQL
+// so it can be compared with the actual one when reading the view.
+func setViewDefinition(txn *sql.Tx, d *schema.ResourceData) error {
+	var definition string
+
+	query := "SELECT pg_catalog.pg_get_viewdef(to_regclass($1))"
+	if err := txn.QueryRow(query, getViewQualifiedName(d)).Scan(&definition); err != nil {
+		return fmt.Errorf("could not read definition of view %s: %w", d.Get(viewNameAttr).(string), err)
+	}
+	d.Set(viewDefinitionAttr, definition)
+
+	return nil
+}
+
+func getViewQualifiedName(d *schema.ResourceData) string {
+	return fmt.Sprintf(
+		"%s.%s",
+		pq.QuoteIdentifier(d.Get(viewSchemaAttr).(string)),
+		pq.QuoteIdentifier(d.Get(viewNameAttr).(string)),
+	)
+}
+
+// relationExists checks if a relation of the given kind (pg_class.relkind) exists.
+func relationExists(txn *sql.Tx, schemaName, relName, relKind string) (bool, error) {
+	var _rez bool
+
+	query := `SELECT TRUE FROM pg_catalog.pg_class c ` +
+		`JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace ` +
+		`WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind = $3`
+	err := txn.QueryRow(query, schemaName, relName, relKind).Scan(&_rez)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
+}
+
+func generateViewID(d *schema.ResourceData, databaseName string) string {
+	return strings.Join([]string{
+		databaseName,
+		d.Get(viewSchemaAttr).(string),
+		d.Get(viewNameAttr).(string),
+	}, ".")
+}
+
+// getDBViewName returns the database, schema and name of the view. If we are importing this
+// resource, they will be parsed from the resource ID (it will return an error if parsing failed)
+// otherwise they will be simply get from the state.
+func getDBViewName(d *schema.ResourceData, client *Client) (string, string, string, error) {
+	database := getDatabase(d, client.databaseName)
+	viewSchema := d.Get(viewSchemaAttr).(string)
+	viewName := d.Get(viewNameAttr).(string)
+
+	// When importing, we have to parse the ID to find the view, schema and database names.
+	if viewName == "" {
+		parsed := strings.Split(d.Id(), ".")
+		if len(parsed) != 3 {
+			return "", "", "", fmt.Errorf("view ID %s has not the expected format 'database.schema.view': %v", d.Id(), parsed)
+		}
+		database = parsed[0]
+		viewSchema = parsed[1]
+		viewName = parsed[2]
+	}
+
+	return database, viewSchema, viewName, nil
+}
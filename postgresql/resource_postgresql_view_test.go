@@ -0,0 +1,181 @@
+package postgresql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPostgresqlView_Basic(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_table (id integer, val text)")
+
+	testAccPostgresqlViewConfig := func(query, checkOption string, securityBarrier bool) string {
+		return fmt.Sprintf(`
+		resource "postgresql_view" "test" {
+			name             = "test_view"
+			database         = "%s"
+			query            = "%s"
+			check_option     = "%s"
+			security_barrier = %t
+		}`, dbName, query, checkOption, securityBarrier)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlViewConfig("SELECT id, val FROM test_table WHERE id > 0", "", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.test"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "id", fmt.Sprintf("%s.public.test_view", dbName)),
+					resource.TestCheckResourceAttr("postgresql_view.test", "check_option", ""),
+					resource.TestCheckResourceAttr("postgresql_view.test", "security_barrier", "false"),
+					resource.TestCheckResourceAttrSet("postgresql_view.test", "definition"),
+				),
+			},
+			{
+				Config: testAccPostgresqlViewConfig("SELECT id, val FROM test_table WHERE id > 10", "LOCAL", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.test"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "query", "SELECT id, val FROM test_table WHERE id > 10"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "check_option", "LOCAL"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "security_barrier", "true"),
+				),
+			},
+			{
+				// Change the view outside of Terraform, the next plan should revert it.
+				PreConfig: func() {
+					dbExecute(t, config.connStr(dbName), "CREATE OR REPLACE VIEW test_view AS SELECT id, val FROM test_table")
+				},
+				Config:             testAccPostgresqlViewConfig("SELECT id, val FROM test_table WHERE id > 10", "LOCAL", true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:      "postgresql_view.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"query",
+					"drop_cascade",
+				},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlView_DropCascade(t *testing.T) {
+	skipIfNotAcc(t)
+
+	dbSuffix, teardown := setupTestDatabase(t, true, true)
+	defer teardown()
+
+	dbName, _ := getTestDBNames(dbSuffix)
+	config := getTestConfig(t)
+	dbExecute(t, config.connStr(dbName), "CREATE TABLE test_table (id integer, val text)")
+
+	testAccPostgresqlViewConfig := func(query string) string {
+		return fmt.Sprintf(`
+		resource "postgresql_view" "test" {
+			name         = "test_view"
+			database     = "%s"
+			query        = "%s"
+			drop_cascade = true
+		}`, dbName, query)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPostgresqlViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostgresqlViewConfig("SELECT id, val FROM test_table"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.test"),
+				),
+			},
+			{
+				// Removing a column cannot be done with CREATE OR REPLACE VIEW.
+				Config: testAccPostgresqlViewConfig("SELECT id FROM test_table"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPostgresqlViewExists("postgresql_view.test"),
+					resource.TestCheckResourceAttr("postgresql_view.test", "query", "SELECT id FROM test_table"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPostgresqlViewDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "postgresql_view" {
+			continue
+		}
+
+		txn, err := startTransaction(client, rs.Primary.Attributes[viewDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[viewSchemaAttr], rs.Primary.Attributes[viewNameAttr], "v")
+		if err != nil {
+			return fmt.Errorf("Error checking view %s", err)
+		}
+
+		if exists {
+			return fmt.Errorf("View still exists after destroy")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckPostgresqlViewExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*Client)
+		txn, err := startTransaction(client, rs.Primary.Attributes[viewDatabaseAttr])
+		if err != nil {
+			return err
+		}
+		defer deferredRollback(txn)
+
+		exists, err := relationExists(txn, rs.Primary.Attributes[viewSchemaAttr], rs.Primary.Attributes[viewNameAttr], "v")
+		if err != nil {
+			return fmt.Errorf("Error checking view %s", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("View not found")
+		}
+
+		return nil
+	}
+}
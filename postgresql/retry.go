@@ -0,0 +1,194 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// maxRetryBackoff caps the exponential backoff between two retries.
+const maxRetryBackoff = 30 * time.Second
+
+// retryableErrorCodes are the SQLSTATEs of the transient errors.
+var retryableErrorCodes = map[pq.ErrorCode]bool{
+	"57P03": true, // cannot_connect_now
+	"53300": true, // too_many_connections
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// failoverMaxRetries is the minimum number of retries after a read-only error, to let
+// the time to a failover (e.g. Aurora) to complete, about 30s with the default retry_backoff.
+const failoverMaxRetries = 6
+
+// isReadOnlyError returns whether err is due to a write on a read-only server
+// (e.g.: cannot execute CREATE ROLE in a read-only transaction), which happens when
+// the pools still have connections to a primary which has been demoted by a failover.
+func isReadOnlyError(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "25006" // read_only_sql_transaction
+}
+
+// isRetryableError returns whether err is transient, i.e. the operation can succeed if retried.
+func isRetryableError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return retryableErrorCodes[pqErr.Code]
+	}
+
+	// The connection was refused or lost.
+	var netErr *net.OpError
+	return errors.As(err, &netErr) || errors.Is(err, driver.ErrBadConn)
+}
+
+// retriedKey marks the context of the operations retried as a whole (see PGReadFunc),
+// whose connections are then not retried on their own.
+type retriedKey struct{}
+
+// withRetries calls fn until it succeeds, fails with an error which is not transient,
+// or MaxRetries retries have been done, with an exponential backoff between the attempts.
+// fn must be safe to call again, i.e. it only connects or reads.
+// The retries stop when ctx is done (e.g.: the timeout of the operation is reached).
+// After a read-only error the pools used by the operation are reset (see resetPools), so the
+// connections are established to the new primary, and fn is retried at least failoverMaxRetries times.
+func (c *Client) withRetries(ctx context.Context, fn func() error) error {
+	if ctx.Value(retriedKey{}) != nil {
+		return fn()
+	}
+	backoff := c.config.RetryBackoff
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return err
+		}
+
+		maxRetries := c.config.MaxRetries
+		switch {
+		case isReadOnlyError(err):
+			if maxRetries < failoverMaxRetries {
+				maxRetries = failoverMaxRetries
+			}
+			resetPools(ctx, start)
+		case !isRetryableError(err):
+			return err
+		}
+		if attempt > maxRetries {
+			return err
+		}
+
+		log.Printf("[WARN] transient error, retrying in %s (%d/%d): %v", backoff, attempt, maxRetries, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (not retried: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// usedPoolsKey is the key of the usedPools of an operation in its context.
+type usedPoolsKey struct{}
+
+// usedPools are the DSNs of the pools an operation connected to (see Client.Connect),
+// the ones reset after a read-only error.
+type usedPools struct {
+	sync.Mutex
+	dsns map[string]bool
+}
+
+// withUsedPools returns ctx recording the pools used by the operation, if it doesn't yet.
+func withUsedPools(ctx context.Context) context.Context {
+	if ctx.Value(usedPoolsKey{}) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, usedPoolsKey{}, &usedPools{dsns: map[string]bool{}})
+}
+
+// addUsedPool records that the operation of ctx uses the pool of dsn.
+func addUsedPool(ctx context.Context, dsn string) {
+	if pools, ok := ctx.Value(usedPoolsKey{}).(*usedPools); ok {
+		pools.Lock()
+		pools.dsns[dsn] = true
+		pools.Unlock()
+	}
+}
+
+// resetPools closes the idle connections of the pools used by the operation of ctx after a
+// read-only error, so the next connections resolve the hosts again (e.g.: the Aurora cluster
+// endpoint pointing to the new writer after a failover).
+// It's done once per failover: the pools already reset since the operation started, by it
+// or by another operation, are kept. The pools are not closed as they can be used by other
+// resources at the same time.
+func resetPools(ctx context.Context, operationStart time.Time) {
+	pools, ok := ctx.Value(usedPoolsKey{}).(*usedPools)
+	if !ok {
+		return
+	}
+	pools.Lock()
+	defer pools.Unlock()
+	dbRegistryLock.Lock()
+	defer dbRegistryLock.Unlock()
+
+	for dsn := range pools.dsns {
+		conn, found := dbRegistry[dsn]
+		if !found || conn.resetAt.After(operationStart) {
+			continue
+		}
+		log.Printf("[WARN] the server is read-only, reconnecting in case of failover")
+		conn.SetMaxIdleConns(0)
+		conn.SetMaxIdleConns(conn.client.config.MaxIdleConns)
+		conn.resetAt = time.Now()
+	}
+}
+
+// waitForReadyBackoff caps the time between two connection attempts of waitForReady.
+const waitForReadyBackoff = 5 * time.Second
+
+// waitForReady tries to connect to the server until it accepts the connections,
+// timeout elapses or ctx is done, so the databases which are suspended when idle (e.g.
+// Neon or Aurora Serverless) have the time to resume. Only the errors returned by a running
+// server which are not transient (e.g. authentication failed) are not retried.
+func waitForReady(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	backoff := time.Second
+
+	for {
+		err := db.PingContext(waitCtx)
+		if err == nil {
+			return nil
+		}
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && !retryableErrorCodes[pqErr.Code] {
+			return err
+		}
+
+		log.Printf("[WARN] server not ready, retrying in %s: %v", backoff, err)
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("%w (not retried: %v)", err, ctx.Err())
+			}
+			return fmt.Errorf("server not ready after %s: %w", timeout, err)
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > waitForReadyBackoff {
+			backoff = waitForReadyBackoff
+		}
+	}
+}
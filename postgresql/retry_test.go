@@ -0,0 +1,217 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	var tests = []struct {
+		err  error
+		want bool
+	}{
+		{&pq.Error{Code: "57P03"}, true},
+		{&pq.Error{Code: "53300"}, true},
+		{fmt.Errorf("could not grant: %w", &pq.Error{Code: "40P01"}), true},
+		{&pq.Error{Code: "40001"}, true},
+		{&pq.Error{Code: "42501"}, false},
+		{&pq.Error{Code: "25006"}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{errors.New("role does not exist"), false},
+	}
+
+	for _, test := range tests {
+		if got := isRetryableError(test.err); got != test.want {
+			t.Errorf("isRetryableError(%v) returned %t, want %t", test.err, got, test.want)
+		}
+	}
+}
+
+func TestClientWithRetries(t *testing.T) {
+	client := &Client{config: Config{MaxRetries: 2}}
+
+	attempts := 0
+	err := client.withRetries(context.Background(), func() error {
+		attempts++
+		return &pq.Error{Code: "53300"}
+	})
+	if err == nil || attempts != 3 {
+		t.Errorf("expected 3 attempts and an error, got %d attempts and error %v", attempts, err)
+	}
+
+	attempts = 0
+	err = client.withRetries(context.Background(), func() error {
+		attempts++
+		if attempts == 1 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("expected 2 attempts and no error, got %d attempts and error %v", attempts, err)
+	}
+
+	attempts = 0
+	err = client.withRetries(context.Background(), func() error {
+		attempts++
+		return &pq.Error{Code: "42501"}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("expected 1 attempt for a non transient error, got %d", attempts)
+	}
+
+	// The connections of an operation retried as a whole are not retried on their own.
+	attempts = 0
+	err = client.withRetries(context.WithValue(context.Background(), retriedKey{}, true), func() error {
+		attempts++
+		return &pq.Error{Code: "40P01"}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("expected 1 attempt in an operation already retried, got %d", attempts)
+	}
+}
+
+func TestClientWithRetriesContext(t *testing.T) {
+	client := &Client{config: Config{MaxRetries: 10, RetryBackoff: time.Hour}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The retries stop when the timeout of the operation is reached.
+	attempts := 0
+	err := client.withRetries(ctx, func() error {
+		attempts++
+		return &pq.Error{Code: "53300"}
+	})
+	if attempts != 1 || !isRetryableError(err) {
+		t.Errorf("expected 1 attempt and the transient error, got %d attempts and error %v", attempts, err)
+	}
+}
+
+func TestErrorDiagnostics(t *testing.T) {
+	if diags := errorDiagnostics(nil); diags != nil {
+		t.Errorf("expected no diagnostics without error, got %v", diags)
+	}
+
+	err := fmt.Errorf("could not create schema: %w", &pq.Error{
+		Message: "permission denied for database test",
+		Detail:  "detail",
+		Hint:    "hint",
+	})
+	diags := errorDiagnostics(err)
+	if len(diags) != 1 || !diags.HasError() {
+		t.Fatalf("expected an error diagnostic, got %v", diags)
+	}
+	if diags[0].Summary != err.Error() || diags[0].Detail != "detail\nHINT: hint" {
+		t.Errorf("unexpected diagnostic %+v", diags[0])
+	}
+}
+
+func TestClientWithRetriesReadOnly(t *testing.T) {
+	client := &Client{config: Config{}}
+
+	// A failover is waited for even if max_retries is not set.
+	attempts := 0
+	err := client.withRetries(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return &pq.Error{Code: "25006"}
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Errorf("expected 3 attempts and no error, got %d attempts and error %v", attempts, err)
+	}
+
+	attempts = 0
+	err = client.withRetries(context.Background(), func() error {
+		attempts++
+		return &pq.Error{Code: "25006"}
+	})
+	if err == nil || attempts != failoverMaxRetries+1 {
+		t.Errorf("expected %d attempts and an error, got %d attempts and error %v", failoverMaxRetries+1, attempts, err)
+	}
+}
+
+func TestResetPools(t *testing.T) {
+	config := &Config{Scheme: "postgres", Host: "localhost", Port: 5432, Username: "postgres_user", SSLMode: "disable", MaxIdleConns: 2}
+	pools := map[string]*DBConnection{}
+	for _, database := range []string{"used_db", "other_db"} {
+		dsn := config.connStr(database)
+		// sql.Open doesn't connect to the database.
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			t.Fatalf("could not open database: %v", err)
+		}
+		defer db.Close()
+		pools[database] = &DBConnection{DB: db, client: config.NewClient(database)}
+		dbRegistryLock.Lock()
+		dbRegistry[dsn] = pools[database]
+		dbRegistryLock.Unlock()
+		defer func() {
+			dbRegistryLock.Lock()
+			delete(dbRegistry, dsn)
+			dbRegistryLock.Unlock()
+		}()
+	}
+
+	start := time.Now()
+	ctx := withUsedPools(context.Background())
+	addUsedPool(ctx, config.connStr("used_db"))
+
+	resetPools(ctx, start)
+	resetAt := pools["used_db"].resetAt
+	if resetAt.IsZero() {
+		t.Errorf("the pool used by the operation has not been reset")
+	}
+	if !pools["other_db"].resetAt.IsZero() {
+		t.Errorf("a pool not used by the operation has been reset")
+	}
+
+	// The pool is reset once per failover.
+	resetPools(ctx, start)
+	if !pools["used_db"].resetAt.Equal(resetAt) {
+		t.Errorf("the pool has been reset again during the same failover")
+	}
+	resetPools(ctx, time.Now())
+	if pools["used_db"].resetAt.Equal(resetAt) {
+		t.Errorf("the pool has not been reset by a later operation")
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	// Nothing listens on port 1, the connection is refused until the timeout.
+	db, err := sql.Open("postgres", "host=127.0.0.1 port=1 sslmode=disable connect_timeout=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	start := time.Now()
+	err = waitForReady(context.Background(), db, 1500*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected an error as the server is not reachable")
+	}
+	if elapsed := time.Since(start); elapsed < time.Second || elapsed > 5*time.Second {
+		t.Errorf("expected to retry during the timeout, returned after %s", elapsed)
+	}
+
+	// The wait stops with the operation (e.g.: its timeout is reached).
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	err = waitForReady(ctx, db, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "not retried") {
+		t.Errorf("expected an error as the context is done, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected to stop with the context, returned after %s", elapsed)
+	}
+}
@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loadConnectionService reads the connection settings of a service from the
+// connection service files, as libpq does: the user file (PGSERVICEFILE or
+// ~/.pg_service.conf) first, then the system file (PGSYSCONFDIR/pg_service.conf).
+func loadConnectionService(service string) (*connectionSettings, error) {
+	var files []string
+	if file := os.Getenv("PGSERVICEFILE"); file != "" {
+		files = append(files, file)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		files = append(files, filepath.Join(home, ".pg_service.conf"))
+	}
+	if dir := os.Getenv("PGSYSCONFDIR"); dir != "" {
+		files = append(files, filepath.Join(dir, "pg_service.conf"))
+	}
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not open connection service file: %w", err)
+		}
+		settings, err := parseConnectionService(f, service)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read service %q from %s: %w", service, file, err)
+		}
+		if settings != nil {
+			return settings, nil
+		}
+	}
+
+	return nil, fmt.Errorf("service %q not found in the connection service files (%s)", service, strings.Join(files, ", "))
+}
+
+// parseConnectionService reads the settings of a service from a connection service file,
+// it returns nil if the service is not in the file.
+func parseConnectionService(r io.Reader, service string) (*connectionSettings, error) {
+	var settings *connectionSettings
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			if settings != nil {
+				// End of the service section
+				break
+			}
+			if line[1:len(line)-1] == service {
+				settings = newConnectionSettings()
+			}
+			continue
+		}
+		if settings == nil {
+			continue
+		}
+
+		i := strings.Index(line, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("syntax error on line %d", lineNumber)
+		}
+		key := strings.TrimSpace(line[:i])
+		if err := settings.setParam(key, strings.TrimSpace(line[i+1:])); err != nil {
+			return nil, fmt.Errorf("%w on line %d", err, lineNumber)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if settings != nil {
+		if err := settings.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
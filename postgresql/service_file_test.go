@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testServiceFile = `
+# Managed by the platform team
+[reporting]
+host=reporting.example.com
+dbname=reporting
+
+[terraform]
+host = db.example.com
+port = 5433
+user = tf_user
+dbname = tf_db
+sslmode = verify-full
+sslrootcert = /etc/ssl/root.pem
+`
+
+func TestParseConnectionService(t *testing.T) {
+	settings, err := parseConnectionService(strings.NewReader(testServiceFile), "terraform")
+	if err != nil {
+		t.Fatalf("parseConnectionService returned an error: %v", err)
+	}
+	want := &connectionSettings{
+		Host:              "db.example.com",
+		Port:              5433,
+		Username:          "tf_user",
+		Database:          "tf_db",
+		SSLMode:           "verify-full",
+		SSLRootCert:       "/etc/ssl/root.pem",
+		ConnectTimeoutSec: -1,
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("parseConnectionService returned %+v, want %+v", settings, want)
+	}
+
+	if settings, err := parseConnectionService(strings.NewReader(testServiceFile), "unknown"); settings != nil || err != nil {
+		t.Errorf("parseConnectionService returned (%+v, %v) for an unknown service, want (nil, nil)", settings, err)
+	}
+
+	if _, err := parseConnectionService(strings.NewReader("[terraform]\nhost\n"), "terraform"); err == nil {
+		t.Errorf("parseConnectionService did not return an error for an invalid line")
+	}
+}
+
+func TestLoadConnectionService(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pg_service.conf"), []byte(testServiceFile), 0600); err != nil {
+		t.Fatalf("could not write service file: %v", err)
+	}
+	t.Setenv("PGSERVICEFILE", filepath.Join(dir, "missing.conf"))
+	t.Setenv("PGSYSCONFDIR", dir)
+
+	settings, err := loadConnectionService("reporting")
+	if err != nil {
+		t.Fatalf("loadConnectionService returned an error: %v", err)
+	}
+	if settings.Host != "reporting.example.com" || settings.Database != "reporting" {
+		t.Errorf("loadConnectionService returned %+v", settings)
+	}
+
+	if _, err := loadConnectionService("unknown"); err == nil {
+		t.Errorf("loadConnectionService did not return an error for an unknown service")
+	}
+}
@@ -0,0 +1,195 @@
+package postgresql
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHJumpHost is an SSH server to go through to reach the SSH server of the tunnel.
+type SSHJumpHost struct {
+	Host string
+	Port int
+	User string
+}
+
+// SSHTunnel dials the connections to the database through an SSH server (e.g.: a bastion),
+// optionally reached through jump hosts.
+// It implements pq.Dialer.
+type SSHTunnel struct {
+	Host string
+	Port int
+	User string
+
+	// PrivateKey is the PEM encoded private key used to authenticate
+	// on the SSH servers, the SSH agent is used if it is empty.
+	PrivateKey string
+
+	// KnownHostsFile defaults to ~/.ssh/known_hosts.
+	KnownHostsFile        string
+	InsecureIgnoreHostKey bool
+
+	// JumpHosts are connected to in order before the SSH server of the tunnel.
+	// They use the same authentication, and the user of the tunnel if they have none.
+	JumpHosts []SSHJumpHost
+
+	Timeout time.Duration
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// Dial opens a connection to address through the SSH tunnel.
+func (t *SSHTunnel) Dial(network, address string) (net.Conn, error) {
+	client, err := t.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.Dial(network, address)
+	if err != nil {
+		// The SSH connection may have been closed by the server (e.g.: idle timeout),
+		// so we retry once with a new one.
+		t.resetClient(client)
+		if client, err = t.getClient(); err != nil {
+			return nil, err
+		}
+		return client.Dial(network, address)
+	}
+
+	return conn, nil
+}
+
+// DialTimeout opens a connection to address through the SSH tunnel.
+// The timeout only applies to the connection to the SSH servers,
+// the connection to the database is opened by the SSH server.
+func (t *SSHTunnel) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	return t.Dial(network, address)
+}
+
+func (t *SSHTunnel) getClient() (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client == nil {
+		client, err := t.connect()
+		if err != nil {
+			return nil, fmt.Errorf("could not open SSH tunnel through %s: %w", t.Host, err)
+		}
+		t.client = client
+	}
+
+	return t.client, nil
+}
+
+func (t *SSHTunnel) resetClient(client *ssh.Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client == client {
+		t.client.Close()
+		t.client = nil
+	}
+}
+
+func (t *SSHTunnel) connect() (*ssh.Client, error) {
+	authMethods, err := t.authMethods()
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := t.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	hops := append(append([]SSHJumpHost{}, t.JumpHosts...), SSHJumpHost{Host: t.Host, Port: t.Port, User: t.User})
+
+	var client *ssh.Client
+	for _, hop := range hops {
+		user := hop.User
+		if user == "" {
+			user = t.User
+		}
+		config := &ssh.ClientConfig{
+			User:            user,
+			Auth:            authMethods,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         t.Timeout,
+		}
+		address := net.JoinHostPort(hop.Host, strconv.Itoa(hop.Port))
+
+		if client == nil {
+			client, err = ssh.Dial("tcp", address, config)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		conn, err := client.Dial("tcp", address)
+		if err != nil {
+			client.Close()
+			return nil, fmt.Errorf("could not connect to %s: %w", address, err)
+		}
+		sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		client = ssh.NewClient(sshConn, chans, reqs)
+	}
+
+	return client, nil
+}
+
+func (t *SSHTunnel) authMethods() ([]ssh.AuthMethod, error) {
+	if t.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(t.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse SSH private key: %w", err)
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, fmt.Errorf("no SSH private key configured and SSH_AUTH_SOCK is not set")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to SSH agent: %w", err)
+	}
+
+	return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}, nil
+}
+
+func (t *SSHTunnel) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	if t.InsecureIgnoreHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	knownHostsFile := t.KnownHostsFile
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read SSH known hosts: %w", err)
+	}
+
+	return callback, nil
+}
@@ -0,0 +1,60 @@
+package postgresql
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSSHTunnelAuthMethods(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	tunnel := &SSHTunnel{PrivateKey: string(privateKey)}
+	methods, err := tunnel.authMethods()
+	if err != nil {
+		t.Fatalf("authMethods returned an error: %v", err)
+	}
+	if len(methods) != 1 {
+		t.Errorf("authMethods returned %d methods, want 1", len(methods))
+	}
+
+	tunnel = &SSHTunnel{PrivateKey: "not a key"}
+	if _, err := tunnel.authMethods(); err == nil {
+		t.Error("authMethods should return an error for an invalid private key")
+	}
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	tunnel = &SSHTunnel{}
+	if _, err := tunnel.authMethods(); err == nil {
+		t.Error("authMethods should return an error without private key nor SSH agent")
+	}
+}
+
+func TestSSHTunnelHostKeyCallback(t *testing.T) {
+	tunnel := &SSHTunnel{InsecureIgnoreHostKey: true}
+	if _, err := tunnel.hostKeyCallback(); err != nil {
+		t.Errorf("hostKeyCallback returned an error: %v", err)
+	}
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	if err := os.WriteFile(knownHostsFile, []byte{}, 0600); err != nil {
+		t.Fatalf("could not write known hosts file: %v", err)
+	}
+	tunnel = &SSHTunnel{KnownHostsFile: knownHostsFile}
+	if _, err := tunnel.hostKeyCallback(); err != nil {
+		t.Errorf("hostKeyCallback returned an error: %v", err)
+	}
+
+	tunnel = &SSHTunnel{KnownHostsFile: filepath.Join(t.TempDir(), "missing")}
+	if _, err := tunnel.hostKeyCallback(); err == nil {
+		t.Error("hostKeyCallback should return an error for a missing known hosts file")
+	}
+}
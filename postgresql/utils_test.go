@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 const (
@@ -90,6 +91,27 @@ func dbExecute(t *testing.T, dsn, query string, args ...interface{}) {
 	}
 }
 
+// testCheckOwner checks that query, which returns the owner of the object name, returns owner.
+func testCheckOwner(t *testing.T, dbName, query, name, owner string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		config := getTestConfig(t)
+		db, err := sql.Open("postgres", config.connStr(dbName))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var actual string
+		if err := db.QueryRow(query, name).Scan(&actual); err != nil {
+			return fmt.Errorf("could not read the owner of %s: %w", name, err)
+		}
+		if actual != owner {
+			return fmt.Errorf("%s is owned by %s instead of %s", name, actual, owner)
+		}
+		return nil
+	}
+}
+
 func getTestDBNames(dbSuffix string) (dbName string, roleName string) {
 	dbName = fmt.Sprintf("%s_%s", dbNamePrefix, dbSuffix)
 	roleName = fmt.Sprintf("%s_%s", roleNamePrefix, dbSuffix)
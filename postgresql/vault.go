@@ -0,0 +1,224 @@
+package postgresql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// vaultRevokeTimeout bounds the revocation of the lease when the provider stops,
+// as the plugin process is killed shortly after.
+const vaultRevokeTimeout = time.Second
+
+// VaultConfig configures the short-lived credentials fetched from a Vault
+// database secrets engine.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	Address string
+	// Token used to authenticate to Vault.
+	Token string
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+
+	// Mount is the path where the database secrets engine is mounted.
+	Mount string
+	// Role is the name of the database secrets engine role.
+	Role string
+
+	// mu protects creds, generated when the provider first connects.
+	mu    sync.Mutex
+	creds *vaultCredentials
+}
+
+// vaultCredentials are the dynamic credentials generated by Vault.
+type vaultCredentials struct {
+	Username      string
+	Password      string
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+}
+
+// vaultLeaseResponse is the part of the Vault responses describing the lease.
+type vaultLeaseResponse struct {
+	LeaseID       string `json:"lease_id"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// vaultRequest sends a request to the Vault API and decodes the JSON response in out,
+// if any (some endpoints answer with no content).
+func (v *VaultConfig) vaultRequest(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(v.Address, "/")+"/v1/"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&vaultErr); err == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("%s: %s", resp.Status, strings.Join(vaultErr.Errors, ", "))
+		}
+		return errors.New(resp.Status)
+	}
+	if resp.StatusCode == http.StatusNoContent || out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// credentials returns the database credentials, generated when the provider first connects
+// so no lease is issued by the runs which don't (e.g.: a plan without refresh), and the
+// configuration is only checked once its values are known.
+// The lease is renewed in the background, and revoked when the provider stops.
+func (v *VaultConfig) credentials() (*vaultCredentials, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.creds != nil {
+		return v.creds, nil
+	}
+	if v.Address == "" {
+		return nil, fmt.Errorf("vault address must be set (or VAULT_ADDR)")
+	}
+
+	creds, err := v.readCredentials()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] connecting as %s with Vault lease %s (%s)", creds.Username, creds.LeaseID, creds.LeaseDuration)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go v.keepLeaseAlive(ctx, creds)
+	onShutdown(func() {
+		cancel()
+		if err := v.revokeLease(creds.LeaseID); err != nil {
+			log.Printf("[WARN] %v", err)
+		}
+	})
+
+	v.creds = creds
+	return creds, nil
+}
+
+// readCredentials generates new database credentials for the role.
+func (v *VaultConfig) readCredentials() (*vaultCredentials, error) {
+	var resp struct {
+		vaultLeaseResponse
+		Data struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"data"`
+	}
+
+	path := fmt.Sprintf("%s/creds/%s", strings.Trim(v.Mount, "/"), v.Role)
+	if err := v.vaultRequest(context.Background(), http.MethodGet, path, nil, &resp); err != nil {
+		return nil, fmt.Errorf("could not read database credentials from Vault %s: %w", path, err)
+	}
+	if resp.Data.Username == "" {
+		return nil, fmt.Errorf("no database credentials returned by Vault %s", path)
+	}
+
+	return &vaultCredentials{
+		Username:      resp.Data.Username,
+		Password:      resp.Data.Password,
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}, nil
+}
+
+// renewLease extends the lease of the credentials and returns its new duration,
+// which is shorter than requested once the max TTL of the role is reached.
+func (v *VaultConfig) renewLease(ctx context.Context, leaseID string, increment time.Duration) (time.Duration, error) {
+	request := map[string]interface{}{
+		"lease_id":  leaseID,
+		"increment": int(increment.Seconds()),
+	}
+
+	var resp vaultLeaseResponse
+	if err := v.vaultRequest(ctx, http.MethodPut, "sys/leases/renew", request, &resp); err != nil {
+		return 0, fmt.Errorf("could not renew Vault lease %s: %w", leaseID, err)
+	}
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// revokeLease revokes the lease, so the secrets engine drops the credentials
+// instead of letting them expire.
+func (v *VaultConfig) revokeLease(leaseID string) error {
+	if leaseID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vaultRevokeTimeout)
+	defer cancel()
+
+	request := map[string]interface{}{"lease_id": leaseID}
+	if err := v.vaultRequest(ctx, http.MethodPut, "sys/leases/revoke", request, nil); err != nil {
+		return fmt.Errorf("could not revoke Vault lease %s: %w", leaseID, err)
+	}
+	return nil
+}
+
+// keepLeaseAlive renews the lease of creds at half of its duration, so the credentials
+// stay valid during long applies, until the max TTL of the role is reached or ctx is done.
+// It's meant to be run in its own goroutine.
+func (v *VaultConfig) keepLeaseAlive(ctx context.Context, creds *vaultCredentials) {
+	if !creds.Renewable || creds.LeaseDuration <= 0 {
+		return
+	}
+
+	duration := creds.LeaseDuration
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(duration / 2):
+		}
+
+		renewed, err := v.renewLease(ctx, creds.LeaseID, creds.LeaseDuration)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("[WARN] %v, the database credentials expire in %s", err, duration/2)
+			return
+		}
+		if renewed < duration/2 {
+			log.Printf("[WARN] Vault lease %s reached its max TTL, the database credentials expire in %s", creds.LeaseID, renewed)
+			return
+		}
+		log.Printf("[DEBUG] Vault lease %s renewed for %s", creds.LeaseID, renewed)
+		duration = renewed
+	}
+}
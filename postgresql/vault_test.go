@@ -0,0 +1,109 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVaultConfigCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" || r.Header.Get("X-Vault-Namespace") != "team" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/database/creds/terraform":
+			w.Write([]byte(`{"lease_id":"database/creds/terraform/abcd","lease_duration":3600,"renewable":true,"data":{"username":"v-token-terraform","password":"secret"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/sys/leases/renew":
+			var request struct {
+				LeaseID   string `json:"lease_id"`
+				Increment int    `json:"increment"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.LeaseID != "database/creds/terraform/abcd" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"lease_id":"database/creds/terraform/abcd","lease_duration":1800,"renewable":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	vault := &VaultConfig{Address: server.URL + "/", Token: "test-token", Namespace: "team", Mount: "/database/", Role: "terraform"}
+
+	creds, err := vault.readCredentials()
+	if err != nil {
+		t.Fatalf("readCredentials returned an error: %v", err)
+	}
+	want := vaultCredentials{
+		Username:      "v-token-terraform",
+		Password:      "secret",
+		LeaseID:       "database/creds/terraform/abcd",
+		LeaseDuration: time.Hour,
+		Renewable:     true,
+	}
+	if *creds != want {
+		t.Errorf("readCredentials returned %+v, want %+v", *creds, want)
+	}
+
+	duration, err := vault.renewLease(context.Background(), creds.LeaseID, creds.LeaseDuration)
+	if err != nil {
+		t.Fatalf("renewLease returned an error: %v", err)
+	}
+	if duration != 30*time.Minute {
+		t.Errorf("renewLease returned %s, want 30m", duration)
+	}
+
+	vault.Token = "invalid"
+	if _, err := vault.readCredentials(); err == nil {
+		t.Error("readCredentials with an invalid token should return an error")
+	}
+}
+
+func TestVaultConfigCredentialsLease(t *testing.T) {
+	var issued, revoked int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/database/creds/terraform":
+			issued++
+			w.Write([]byte(`{"lease_id":"database/creds/terraform/abcd","lease_duration":3600,"renewable":true,"data":{"username":"v-token-terraform","password":"secret"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/sys/leases/revoke":
+			revoked++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The address is only required when connecting, it may not be known when configuring.
+	if _, err := (&VaultConfig{Mount: "database", Role: "terraform"}).credentials(); err == nil {
+		t.Error("credentials without address should return an error")
+	}
+
+	vault := &VaultConfig{Address: server.URL, Mount: "database", Role: "terraform"}
+	for i := 0; i < 3; i++ {
+		creds, err := vault.credentials()
+		if err != nil {
+			t.Fatalf("credentials returned an error: %v", err)
+		}
+		if creds.Username != "v-token-terraform" {
+			t.Errorf("credentials returned the username %s", creds.Username)
+		}
+	}
+	if issued != 1 {
+		t.Errorf("expected one lease for all the connections, got %d", issued)
+	}
+
+	Shutdown()
+	if revoked != 1 {
+		t.Errorf("expected the lease to be revoked when the provider stops, got %d revocations", revoked)
+	}
+}